@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+)
+
+const apiKeyUsage = `usage:
+  showcase-service-fiap apikey create -name <name> -scopes <scope,...> [-expires-in <duration>]
+  showcase-service-fiap apikey list
+  showcase-service-fiap apikey revoke -id <id>`
+
+// runAPIKeyCommand implementa o subcomando administrativo "apikey". A chave criada só é
+// exibida uma vez; o banco guarda apenas o hash.
+func runAPIKeyCommand(ctx context.Context, apiKeys usecase.APIKeyUseCaseInterface, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		name := flags.String("name", "", "client name, e.g. catalog-service")
+		scopes := flags.String("scopes", "", "comma-separated scopes: catalog-service, payment-gateway")
+		expiresIn := flags.Duration("expires-in", 0, "key lifetime, e.g. 720h (default: never expires)")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		input := dto.InputCreateAPIKeyDTO{Name: *name}
+		if *scopes != "" {
+			input.Scopes = strings.Split(*scopes, ",")
+		}
+		if *expiresIn > 0 {
+			expiresAt := time.Now().Add(*expiresIn)
+			input.ExpiresAt = &expiresAt
+		}
+
+		output, err := apiKeys.Create(ctx, input)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "id:     %s\nname:   %s\nscopes: %s\nkey:    %s\n", output.ID, output.Name, strings.Join(output.Scopes, ","), output.Key)
+		fmt.Fprintln(out, "Store the key now, it cannot be shown again.")
+		return nil
+
+	case "list":
+		keys, err := apiKeys.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tEXPIRES\tREVOKED")
+		for _, key := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key.ID, key.Name, key.Prefix, strings.Join(key.Scopes, ","), formatOptionalTime(key.ExpiresAt), formatOptionalTime(key.RevokedAt))
+		}
+		return w.Flush()
+
+	case "revoke":
+		flags := flag.NewFlagSet("apikey revoke", flag.ContinueOnError)
+		id := flags.String("id", "", "id of the key to revoke")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *id == "" {
+			return errors.New("-id is required")
+		}
+
+		if err := apiKeys.Revoke(ctx, *id); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "API key %s revoked\n", *id)
+		return nil
+
+	default:
+		return errors.New(apiKeyUsage)
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
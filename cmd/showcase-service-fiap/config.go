@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"io"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/config"
+)
+
+const configUsage = `usage:
+  showcase-service-fiap config print`
+
+// runConfigCommand implementa o subcomando "config". O print mostra a configuração efetiva, já
+// com arquivo e variáveis de ambiente aplicados, sem os segredos.
+func runConfigCommand(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+
+	data, err := cfg.Redacted().YAML()
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
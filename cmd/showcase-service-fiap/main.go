@@ -3,77 +3,246 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/NicolasNSC/showcase-service-fiap/internal/app"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/config"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/database"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
 	handler "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
-	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/logger"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/server"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
-	"github.com/go-chi/chi"
-	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 )
 
+const (
+	readinessCheckTimeout = 2 * time.Second
+	redisPoolSize         = 10
+)
+
 // @title           Showcase Service FIAP
 // @version         1.0
 // @description     Microservice for managing vehicle sales, listings, and payment webhooks.
 
 // @host      localhost:8081
 // @BasePath  /
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+
+// @securityDefinitions.apikey  APIKeyAuth
+// @in                          header
+// @name                        X-API-Key
 func main() {
-	loadConfig()
-	db := setupDatabase()
-	defer db.Close()
+	cfg := loadConfig()
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfigCommand(cfg, os.Args[2:], os.Stdout); err != nil {
+			fatal("config command failed", err)
+		}
+		return
+	}
 
-	saleHandler := wireDependencies(db)
-	router := setupRouter(saleHandler)
+	store := setupStorage(cfg.Database)
 
-	startServer(router)
-}
+	apiKeys := usecase.NewAPIKeyUseCase(store.apiKeys)
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if store.db == nil {
+			fatal("apikey command failed", errors.New("api keys are not persisted with DB_DRIVER=memory"))
+		}
+		err := runAPIKeyCommand(context.Background(), apiKeys, os.Args[2:], os.Stdout)
+		store.close()
+		if err != nil {
+			fatal("apikey command failed", err)
+		}
+		return
+	}
 
-func loadConfig() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found, using environment variables")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// Depois do primeiro sinal, um segundo encerra o processo na hora.
+		<-ctx.Done()
+		stop()
+	}()
+
+	shutdownTracing := setupTracing(cfg.Tracing)
+	workers := server.NewWorkers()
+	readiness := health.NewReadiness(readinessCheckTimeout)
+	appMetrics := metrics.New()
+	if store.db != nil {
+		readiness.Register("database", health.Database(store.db))
+		readiness.Register("migrations", migrationsCheck(store))
+		appMetrics.RegisterDB(store.db, "showcase")
+		if cfg.Database.StatsInterval > 0 {
+			workers.Go("db_stats", func(ctx context.Context) {
+				database.LogStats(ctx, store.db, cfg.Database.StatsInterval, slog.Default())
+			})
+		}
+	}
+	if store.pool != nil {
+		appMetrics.RegisterPgxPool(store.pool)
+		if cfg.Database.StatsInterval > 0 {
+			workers.Go("pgx_pool_stats", func(ctx context.Context) {
+				database.LogPoolStats(ctx, store.pool, cfg.Database.StatsInterval, slog.Default())
+			})
+		}
 	}
+
+	broker := events.NewBroker(cfg.Events.HistorySize)
+	publisher := setupEvents(cfg, store.db, broker, readiness, workers)
+	salesCache, closeCache := setupCache(cfg.Cache, appMetrics)
+
+	application := app.New(app.Storage{
+		Sales:     store.sales,
+		Tx:        store.tx,
+		Analytics: store.analytics,
+		APIKeys:   store.apiKeys,
+	}, app.Options{
+		Features: handler.Features{
+			Swagger:     cfg.Features.Swagger,
+			Metrics:     cfg.Features.Metrics,
+			EventStream: cfg.Features.EventStream,
+		},
+		Verifier:          setupAuth(cfg.Auth),
+		Broker:            broker,
+		Publisher:         publisher,
+		HeartbeatInterval: cfg.Events.HeartbeatInterval,
+		Metrics:           appMetrics,
+		Readiness:         readiness,
+		SalesCache:        salesCache,
+		Workers:           workers,
+	})
+
+	srv := server.New(serverConfig(cfg.Server), application.Router)
+	srv.OnDrain(readiness.ShutDown)
+	srv.OnShutdown(application.Stream.Shutdown)
+	srv.OnStop("workers", workers.Stop)
+	srv.OnStop("tracing", shutdownTracing)
+	srv.OnStop("cache", func(context.Context) error { return closeCache() })
+	srv.OnStop("database", func(context.Context) error { return store.close() })
+
+	if err := srv.ListenAndServe(ctx); err != nil {
+		fatal("server stopped with errors", err)
+	}
+	slog.Info("server stopped")
 }
 
-func setupDatabase() *sql.DB {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-	)
+// loadConfig carrega o .env, o arquivo YAML de CONFIG_FILE e as variáveis de ambiente, e
+// configura o logger padrão. Uma configuração inválida encerra o processo listando todos os erros.
+func loadConfig() *config.Config {
+	envErr := godotenv.Load()
+
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"), os.LookupEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	log, err := logger.New(os.Stdout, logger.Config{
+		Level:  cfg.Log.Level,
+		Format: cfg.Log.Format,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid logging configuration: %v\n", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(log)
 
-	db, err := sql.Open("pgx", dsn)
+	if envErr != nil {
+		slog.Warn(".env file not found, using environment variables")
+	}
+	return cfg
+}
+
+// setupTracing configura o OpenTelemetry. O exportador OTLP lê o endpoint das variáveis
+// OTEL_EXPORTER_OTLP_* padrão.
+func setupTracing(cfg config.TracingConfig) func(ctx context.Context) error {
+	shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{
+		Exporter:    cfg.Exporter,
+		ServiceName: cfg.ServiceName,
+		SampleRatio: cfg.SampleRatio,
+		Stdout:      os.Stdout,
+	})
 	if err != nil {
-		log.Fatalf("Fatal: could not connect to database: %v", err)
+		fatal("invalid tracing configuration", err)
 	}
-	if err = db.PingContext(context.Background()); err != nil {
-		log.Fatalf("Fatal: could not ping database: %v", err)
+
+	return shutdown
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// setupEvents devolve o publisher usado pelo caso de uso. Com EVENTS_BROKER=memory os eventos
+// ficam restritos a esta instância; por padrão passam pelo LISTEN/NOTIFY do Postgres para que
+// os streams de todas as instâncias recebam as mudanças feitas em qualquer uma delas.
+func setupEvents(cfg *config.Config, db *sql.DB, broker *events.Broker, readiness *health.Readiness, workers *server.Workers) events.Publisher {
+	if cfg.Events.Broker == config.EventsBrokerMemory {
+		return broker
 	}
-	return db
+
+	listener := events.NewPostgresListener(cfg.Database.DSN(), events.DefaultNotifyChannel, broker)
+	workers.Go("event_listener", listener.Run)
+	readiness.Register("event_listener", listener.Check)
+
+	return events.NewPostgresPublisher(db, events.DefaultNotifyChannel)
 }
 
-func wireDependencies(db *sql.DB) *handler.SaleHandler {
-	repo := repository.NewPostgresSaleRepository(db)
-	useCase := usecase.NewSaleUseCase(repo)
-	return handler.NewSaleHandler(useCase)
+// setupCache monta a cache da lista de vendas disponíveis conforme CACHE_DRIVER. Com
+// CACHE_DRIVER=none devolve um loader nil e a lista é sempre lida do repositório. O Redis não
+// entra na readiness: se ele cair, as leituras seguem direto para o banco.
+func setupCache(cfg config.CacheConfig, recorder metrics.CacheRecorder) (*cache.Loader, func() error) {
+	noop := func() error { return nil }
+
+	switch cfg.Driver {
+	case config.CacheDriverNone:
+		return nil, noop
+	case config.CacheDriverRedis:
+		redis := cache.NewRedis(cache.RedisConfig{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+			Timeout:  cfg.RedisTimeout,
+			PoolSize: redisPoolSize,
+		})
+		return cache.NewLoader("available_sales", redis, cfg.TTL, recorder), redis.Close
+	default:
+		return cache.NewLoader("available_sales", cache.NewLRU(cfg.Size), cfg.TTL, recorder), noop
+	}
 }
 
-func setupRouter(saleHandler *handler.SaleHandler) *chi.Mux {
-	r := chi.NewRouter()
-	handler.SetupRoutes(r, saleHandler)
-	return r
+// setupAuth carrega o JWKS de AUTH_JWKS, que pode ser o caminho de um arquivo ou uma URL.
+func setupAuth(cfg config.AuthConfig) auth.TokenVerifier {
+	keys, err := auth.LoadKeySource(context.Background(), cfg.JWKS, cfg.JWKSRefreshInterval)
+	if err != nil {
+		fatal("could not load JWKS", err)
+	}
+	return auth.NewVerifier(keys, cfg.Issuer, cfg.Audience)
 }
 
-func startServer(router *chi.Mux) {
-	apiPort := os.Getenv("API_PORT")
-	log.Printf("Info: server starting on port %s", apiPort)
-	if err := http.ListenAndServe(":"+apiPort, router); err != nil {
-		log.Fatalf("Fatal: could not start server: %v", err)
+func serverConfig(cfg config.ServerConfig) server.Config {
+	return server.Config{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		DrainDelay:        cfg.DrainDelay,
+		ShutdownTimeout:   cfg.ShutdownTimeout,
 	}
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/NicolasNSC/showcase-service-fiap/db/migrations"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/config"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/database"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// storage reúne os repositórios escolhidos por DB_DRIVER e pelo esquema de DATABASE_URL. Com
+// DB_DRIVER=memory não há banco e db fica nil; pool só existe com DB_DRIVER=pgx, e nesse caso db
+// pega as conexões dele. tx abre transações no banco usado por sales.
+type storage struct {
+	db        *sql.DB
+	pool      *pgxpool.Pool
+	pending   func(ctx context.Context, db *sql.DB) ([]migrations.Migration, error)
+	sales     repository.SaleRepository
+	tx        repository.TxManager
+	analytics repository.AnalyticsRepository
+	apiKeys   repository.APIKeyRepository
+	close     func() error
+}
+
+func setupStorage(cfg config.DatabaseConfig) *storage {
+	if cfg.Driver == config.DatabaseDriverMemory {
+		slog.Warn("using in-memory storage, data is lost when the process stops")
+		sales := repository.NewMemorySaleRepository()
+		return &storage{
+			sales:     sales,
+			tx:        repository.NewMemoryTxManager(),
+			analytics: repository.NewMemoryAnalyticsRepository(sales),
+			apiKeys:   repository.NewMemoryAPIKeyRepository(),
+			close:     func() error { return nil },
+		}
+	}
+
+	if dsn, ok := cfg.SQLiteDSN(); ok {
+		db := setupSQLite(cfg, dsn)
+		return &storage{
+			db:        db,
+			pending:   migrations.PendingSQLite,
+			sales:     repository.NewSQLiteSaleRepository(db),
+			tx:        repository.NewSQLTxManager(db),
+			analytics: repository.NewSQLiteAnalyticsRepository(db),
+			apiKeys:   repository.NewSQLiteAPIKeyRepository(db),
+			close:     db.Close,
+		}
+	}
+
+	if cfg.Driver == config.DatabaseDriverPgx {
+		pool, db := setupPgxDatabase(cfg)
+		return &storage{
+			db:        db,
+			pool:      pool,
+			pending:   migrations.Pending,
+			sales:     repository.NewPgxSaleRepository(pool),
+			tx:        repository.NewPgxTxManager(pool),
+			analytics: repository.NewPostgresAnalyticsRepository(db),
+			apiKeys:   repository.NewPostgresAPIKeyRepository(db),
+			close: func() error {
+				err := db.Close()
+				pool.Close()
+				return err
+			},
+		}
+	}
+
+	db := setupDatabase(cfg)
+	return &storage{
+		db:        db,
+		pending:   migrations.Pending,
+		sales:     repository.NewPostgresSaleRepository(db),
+		tx:        repository.NewSQLTxManager(db),
+		analytics: repository.NewPostgresAnalyticsRepository(db),
+		apiKeys:   repository.NewPostgresAPIKeyRepository(db),
+		close:     db.Close,
+	}
+}
+
+// setupDatabase abre o pool com os limites configurados e espera o Postgres ficar disponível
+// por até DB_STARTUP_TIMEOUT antes de aplicar as migrações.
+func setupDatabase(cfg config.DatabaseConfig) *sql.DB {
+	db, err := database.Open(context.Background(), databaseConfig(cfg))
+	if err != nil {
+		fatal("could not connect to database", err)
+	}
+	if err = migrations.Apply(context.Background(), db); err != nil {
+		fatal("could not apply migrations", err)
+	}
+	return db
+}
+
+// setupPgxDatabase abre o pool nativo do pgx e deriva dele o *sql.DB dos demais repositórios e
+// das migrações. Os dois dividem as mesmas conexões, então DB_MAX_OPEN_CONNS vale para o
+// processo inteiro.
+func setupPgxDatabase(cfg config.DatabaseConfig) (*pgxpool.Pool, *sql.DB) {
+	pool, err := database.OpenPool(context.Background(), databaseConfig(cfg))
+	if err != nil {
+		fatal("could not connect to database", err)
+	}
+	db := stdlib.OpenDBFromPool(pool)
+	if err = migrations.Apply(context.Background(), db); err != nil {
+		fatal("could not apply migrations", err)
+	}
+	return pool, db
+}
+
+// setupSQLite abre o arquivo SQLite e aplica as migrações próprias dele.
+func setupSQLite(cfg config.DatabaseConfig, dsn string) *sql.DB {
+	dbConfig := databaseConfig(cfg)
+	dbConfig.DSN = dsn
+
+	db, err := database.OpenSQLite(context.Background(), dbConfig)
+	if err != nil {
+		fatal("could not open sqlite database", err)
+	}
+	if err = migrations.ApplySQLite(context.Background(), db); err != nil {
+		fatal("could not apply migrations", err)
+	}
+	return db
+}
+
+func databaseConfig(cfg config.DatabaseConfig) database.Config {
+	return database.Config{
+		DSN:             cfg.DSN(),
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
+		StartupTimeout:  cfg.StartupTimeout,
+	}
+}
+
+// migrationsCheck falha enquanto houver migração embutida que não foi aplicada no banco.
+func migrationsCheck(store *storage) health.Check {
+	return func(ctx context.Context) error {
+		pending, err := store.pending(ctx, store.db)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migration(s), first is %04d_%s", len(pending), pending[0].Version, pending[0].Name)
+		}
+		return nil
+	}
+}
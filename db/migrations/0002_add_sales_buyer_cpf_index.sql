@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_sales_buyer_cpf ON sales (buyer_cpf) WHERE buyer_cpf IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,214 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// advisoryLockID evita que duas instâncias subindo juntas apliquem as mesmas migrações.
+const advisoryLockID = 7301150431
+
+//go:embed *.sql
+var files embed.FS
+
+// sqliteFiles espelha o mesmo esquema para o SQLite, com as mesmas versões e nomes.
+//
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func LoadSQLite() ([]Migration, error) {
+	sub, err := fs.Sub(sqliteFiles, "sqlite")
+	if err != nil {
+		return nil, err
+	}
+	return load(sub)
+}
+
+func load(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		version, name, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), "_")
+		number, err := strconv.Atoi(version)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, Migration{Version: number, Name: name, SQL: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func Apply(ctx context.Context, db *sql.DB) error {
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+	          version INTEGER PRIMARY KEY, 
+	          name VARCHAR(255) NOT NULL, 
+	          applied_at TIMESTAMPTZ NOT NULL DEFAULT now())`)
+	if err != nil {
+		return err
+	}
+
+	return applyPending(ctx, conn, migrations)
+}
+
+// ApplySQLite aplica as migrações do SQLite. Não há lock consultivo: o SQLite serializa as
+// escritas e o banco pertence a uma única instância.
+func ApplySQLite(ctx context.Context, db *sql.DB) error {
+	migrations, err := LoadSQLite()
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+	          version INTEGER PRIMARY KEY,
+	          name TEXT NOT NULL,
+	          applied_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')))`)
+	if err != nil {
+		return err
+	}
+
+	return applyPending(ctx, conn, migrations)
+}
+
+func applyPending(ctx context.Context, conn *sql.Conn, migrations []Migration) error {
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		if err := apply(ctx, conn, migration); err != nil {
+			return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		slog.InfoContext(ctx, "applied migration", "version", migration.Version, "name", migration.Name)
+	}
+
+	return nil
+}
+
+// Pending devolve as migrações embutidas que ainda não foram registradas em schema_migrations.
+func Pending(ctx context.Context, db *sql.DB) ([]Migration, error) {
+	return pending(ctx, db, Load)
+}
+
+// PendingSQLite é o Pending das migrações do SQLite.
+func PendingSQLite(ctx context.Context, db *sql.DB) ([]Migration, error) {
+	return pending(ctx, db, LoadSQLite)
+}
+
+func pending(ctx context.Context, db *sql.DB, load func() ([]Migration, error)) ([]Migration, error) {
+	migrations, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func appliedVersions(ctx context.Context, conn queryer) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,183 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/db/migrations"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/database"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationsSuite struct {
+	suite.Suite
+}
+
+func Test_MigrationsSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MigrationsSuite))
+}
+
+func (suite *MigrationsSuite) Test_Load() {
+	list, err := migrations.Load()
+	suite.NoError(err)
+	suite.GreaterOrEqual(len(list), 2)
+
+	for i, migration := range list {
+		suite.Equal(i+1, migration.Version)
+		suite.NotEmpty(migration.Name)
+		suite.NotEmpty(migration.SQL)
+	}
+	suite.Equal("create_sales_table", list[0].Name)
+	suite.Contains(list[0].SQL, "CREATE TABLE IF NOT EXISTS sales")
+}
+
+func (suite *MigrationsSuite) Test_LoadSQLite() {
+	postgres, err := migrations.Load()
+	suite.Require().NoError(err)
+
+	sqlite, err := migrations.LoadSQLite()
+	suite.NoError(err)
+	suite.Require().Len(sqlite, len(postgres))
+	for i, migration := range sqlite {
+		suite.Equal(postgres[i].Version, migration.Version)
+		suite.Equal(postgres[i].Name, migration.Name)
+		suite.NotEqual(postgres[i].SQL, migration.SQL)
+	}
+}
+
+func (suite *MigrationsSuite) Test_Apply() {
+	list, err := migrations.Load()
+	suite.Require().NoError(err)
+
+	expectSetup := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	suite.T().Run("should apply only pending migrations in order", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		expectSetup(mock)
+		mock.ExpectQuery(`SELECT version FROM schema_migrations`).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+		for _, migration := range list[1:] {
+			mock.ExpectBegin()
+			mock.ExpectExec(`.+`).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`INSERT INTO schema_migrations \(version, name\) VALUES \(\$1, \$2\)`).
+				WithArgs(migration.Version, migration.Name).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}
+		mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = migrations.Apply(context.Background(), db)
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should rollback and stop when a migration fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		expectSetup(mock)
+		mock.ExpectQuery(`SELECT version FROM schema_migrations`).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectBegin()
+		mock.ExpectExec(`CREATE TABLE IF NOT EXISTS sales`).WillReturnError(errors.New("syntax error"))
+		mock.ExpectRollback()
+		mock.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = migrations.Apply(context.Background(), db)
+		suite.EqualError(err, "migration 0001_create_sales_table: syntax error")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when the lock cannot be taken", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		mock.ExpectExec(`SELECT pg_advisory_lock`).WillReturnError(errors.New("db error"))
+
+		err = migrations.Apply(context.Background(), db)
+		suite.EqualError(err, "db error")
+	})
+}
+
+func (suite *MigrationsSuite) Test_Pending() {
+	list, err := migrations.Load()
+	suite.Require().NoError(err)
+
+	suite.T().Run("should return the migrations missing from schema_migrations", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"version"})
+		for _, migration := range list[:len(list)-1] {
+			rows.AddRow(migration.Version)
+		}
+		mock.ExpectQuery(`SELECT version FROM schema_migrations`).WillReturnRows(rows)
+
+		pending, err := migrations.Pending(context.Background(), db)
+		suite.NoError(err)
+		suite.Equal([]migrations.Migration{list[len(list)-1]}, pending)
+	})
+
+	suite.T().Run("should return nothing when every migration is applied", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"version"})
+		for _, migration := range list {
+			rows.AddRow(migration.Version)
+		}
+		mock.ExpectQuery(`SELECT version FROM schema_migrations`).WillReturnRows(rows)
+
+		pending, err := migrations.Pending(context.Background(), db)
+		suite.NoError(err)
+		suite.Empty(pending)
+	})
+
+	suite.T().Run("should return error when schema_migrations cannot be read", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		mock.ExpectQuery(`SELECT version FROM schema_migrations`).WillReturnError(errors.New("relation does not exist"))
+
+		_, err = migrations.Pending(context.Background(), db)
+		suite.Error(err)
+	})
+}
+
+func (suite *MigrationsSuite) Test_ApplySQLite() {
+	list, err := migrations.LoadSQLite()
+	suite.Require().NoError(err)
+
+	ctx := context.Background()
+	db, err := database.OpenSQLite(ctx, database.Config{DSN: "file:" + filepath.Join(suite.T().TempDir(), "showcase.db")})
+	suite.Require().NoError(err)
+	defer db.Close()
+
+	suite.T().Run("should apply the migrations once", func(t *testing.T) {
+		suite.NoError(migrations.ApplySQLite(ctx, db))
+		suite.NoError(migrations.ApplySQLite(ctx, db))
+
+		var applied int
+		suite.NoError(db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&applied))
+		suite.Equal(len(list), applied)
+
+		pending, err := migrations.PendingSQLite(ctx, db)
+		suite.NoError(err)
+		suite.Empty(pending)
+	})
+}
@@ -0,0 +1,15 @@
+-- Datas ficam em TEXT, em UTC e com microssegundos fixos (2006-01-02T15:04:05.000000Z), para que a
+-- ordem do texto seja a ordem cronológica.
+CREATE TABLE IF NOT EXISTS sales (
+    id TEXT PRIMARY KEY CHECK (length(id) <= 36),
+    vehicle_id TEXT NOT NULL CHECK (length(vehicle_id) <= 36),
+    brand TEXT NOT NULL CHECK (length(brand) <= 100),
+    model TEXT NOT NULL CHECK (length(model) <= 100),
+    price REAL NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('AVAILABLE', 'PENDING_PAYMENT', 'SOLD', 'CANCELED')),
+    payment_id TEXT CHECK (length(payment_id) <= 36),
+    buyer_cpf TEXT CHECK (length(buyer_cpf) <= 14),
+    sale_date TEXT CHECK (sale_date LIKE '____-__-__T__:__:__.______Z'),
+    created_at TEXT NOT NULL CHECK (created_at LIKE '____-__-__T__:__:__.______Z'),
+    updated_at TEXT NOT NULL CHECK (updated_at LIKE '____-__-__T__:__:__.______Z')
+) STRICT;
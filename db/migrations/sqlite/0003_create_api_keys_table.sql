@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id TEXT PRIMARY KEY CHECK (length(id) <= 36),
+    name TEXT NOT NULL CHECK (length(name) <= 100),
+    prefix TEXT NOT NULL CHECK (length(prefix) <= 16),
+    key_hash TEXT NOT NULL UNIQUE CHECK (length(key_hash) <= 64),
+    scopes TEXT NOT NULL,
+    expires_at TEXT CHECK (expires_at LIKE '____-__-__T__:__:__.______Z'),
+    revoked_at TEXT CHECK (revoked_at LIKE '____-__-__T__:__:__.______Z'),
+    created_at TEXT NOT NULL CHECK (created_at LIKE '____-__-__T__:__:__.______Z')
+) STRICT;
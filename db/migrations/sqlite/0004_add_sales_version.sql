@@ -0,0 +1,2 @@
+-- version controla a concorrência otimista: cada UPDATE exige a versão lida e a incrementa.
+ALTER TABLE sales ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
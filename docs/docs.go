@@ -15,8 +15,197 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/analytics/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns units sold, revenue, average ticket, average time from listing to sale and conversion from reservation to sale for sales dated within [from, to], optionally broken down by period and by brand or brand/model.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Sales analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First sale date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last sale date, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "Time bucket",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "brand",
+                            "model"
+                        ],
+                        "type": "string",
+                        "description": "Breakdown",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputSalesAnalyticsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/purchases": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every sale reserved or bought with the given CPF, newest first. The CPF goes in the body so it never shows up in URLs or access logs, and the response only carries the masked CPF. Requires the admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Support"
+                ],
+                "summary": "List a buyer's purchase history",
+                "parameters": [
+                    {
+                        "description": "Buyer CPF and pagination",
+                        "name": "lookup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputBuyerSalesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputBuyerSalesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or CPF",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 while the process is able to serve HTTP. It does not check dependencies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.ComponentStatus"
+                        }
+                    }
+                }
+            }
+        },
         "/listings": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
                 "description": "Creates a new sale listing when notified by the catalog-service. This is an internal endpoint.",
                 "consumes": [
                     "application/json"
@@ -48,6 +237,112 @@ const docTemplate = `{
                     },
                     "400": {
                         "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/listings/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Imports many listings at once from a CSV file (header: vehicle_id,brand,model,price) or NDJSON. Rows are validated one by one and inserted in batches. Use dry_run to only validate and atomic to insert all rows or none. Bodies are limited to 32 MiB. This is an internal endpoint.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "Bulk import sale listings",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only validate rows, nothing is inserted",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Insert every row in a single transaction or none at all",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputBulkImportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or query",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
                         "schema": {
                             "type": "string"
                         }
@@ -62,7 +357,80 @@ const docTemplate = `{
             }
         },
         "/listings/vehicle/{vehicle_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a listing by vehicle ID. The ETag header identifies the version that was read; send it back in If-Match when patching the listing. This is an internal endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "Get a sale listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "vehicle_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListingDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the listing"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
                 "description": "Updates a sale listing's data when notified by the catalog-service. This is an internal endpoint.",
                 "consumes": [
                     "application/json"
@@ -99,12 +467,265 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "404": {
                         "description": "Listing not found",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "409": {
+                        "description": "Listing kept changing concurrently",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7386) to brand, model and price; fields left out of the patch keep their values and null removes a field, which fails validation for required fields. The merged listing goes through the same rules as a full update. With If-Match, the patch is only applied if the listing still has that ETag. This is an internal endpoint.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "Partially update a sale listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "vehicle_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the listing version being patched",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputUpdateListingDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListingDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the patched listing"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch or merged listing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Listing not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Listing kept changing concurrently",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Listing was modified since it was read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the database connection, pending migrations and background workers, returning the status of each component. Returns 503 when any component is down or the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the sold vehicles whose sale date is within [from, to] as CSV, or as XLSX when the Accept header asks for it. The buyer CPF is masked unless unmask_cpf is true.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Export sold vehicles report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First sale date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last sale date, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated columns: sale_id, vehicle_id, brand, model, price, status, sale_date, payment_id, buyer_cpf, created_at",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show the full buyer CPF",
+                        "name": "unmask_cpf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -178,12 +799,48 @@ const docTemplate = `{
                 }
             }
         },
+        "/sales/stream": {
+            "get": {
+                "description": "Server-Sent Events stream with listing.created, listing.updated, listing.reserved, listing.sold and listing.canceled events. Send the Last-Event-ID header to resume after the last received event. Comment lines are sent periodically as heartbeats.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Sales"
+                ],
+                "summary": "Stream inventory changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "500": {
+                        "description": "Streaming unsupported",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/sales/{id}/purchase": {
             "post": {
-                "description": "Initiates the purchase process for a specific sale listing.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Initiates the purchase process for a specific sale listing. The buyer is identified by the cpf claim of the bearer token; any CPF sent in the body is ignored.",
                 "produces": [
                     "application/json"
                 ],
@@ -198,15 +855,6 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Buyer's CPF",
-                        "name": "purchase",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.InputPurchaseDTO"
-                        }
                     }
                 ],
                 "responses": {
@@ -217,7 +865,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or ID",
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Token does not identify a buyer",
                         "schema": {
                             "type": "string"
                         }
@@ -245,6 +905,14 @@ const docTemplate = `{
         },
         "/webhooks/payments": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
                 "description": "Receives payment status notifications from an external payment gateway.",
                 "consumes": [
                     "application/json"
@@ -275,11 +943,47 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or payment status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Sale not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Sale is not in pending payment status",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputErrorDTO"
+                        }
+                    },
                     "500": {
                         "description": "Failed to process webhook",
                         "schema": {
@@ -291,49 +995,233 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "domain.SaleStatus": {
+            "type": "string",
+            "enum": [
+                "AVAILABLE",
+                "PENDING_PAYMENT",
+                "SOLD",
+                "CANCELED"
+            ],
+            "x-enum-varnames": [
+                "StatusAvailable",
+                "StatusPendingPayment",
+                "StatusSold",
+                "StatusCanceled"
+            ]
+        },
+        "dto.FieldErrorDTO": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InputBuyerSalesDTO": {
+            "type": "object",
+            "required": [
+                "buyer_cpf"
+            ],
+            "properties": {
+                "buyer_cpf": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "page_size": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "dto.InputCreateListingDTO": {
             "type": "object",
+            "required": [
+                "brand",
+                "model",
+                "price",
+                "vehicle_id"
+            ],
             "properties": {
                 "brand": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "model": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "price": {
-                    "type": "number"
+                    "type": "number",
+                    "maximum": 99999999.99
                 },
                 "vehicle_id": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 36
                 }
             }
         },
-        "dto.InputPurchaseDTO": {
+        "dto.InputUpdateListingDTO": {
             "type": "object",
+            "required": [
+                "brand",
+                "model",
+                "price"
+            ],
             "properties": {
-                "buyer_cpf": {
+                "brand": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "price": {
+                    "type": "number",
+                    "maximum": 99999999.99
+                }
+            }
+        },
+        "dto.InputWebhookDTO": {
+            "type": "object",
+            "required": [
+                "payment_id",
+                "status"
+            ],
+            "properties": {
+                "payment_id": {
+                    "type": "string",
+                    "maxLength": 36
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "APPROVED",
+                        "CANCELED",
+                        "EFETUADO",
+                        "CANCELADO"
+                    ]
+                }
+            }
+        },
+        "dto.OutputBulkImportDTO": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputBulkImportRowDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputBulkImportRowDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "sale_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "vehicle_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.InputUpdateListingDTO": {
+        "dto.OutputBuyerSaleItemDTO": {
             "type": "object",
             "properties": {
                 "brand": {
                     "type": "string"
                 },
+                "buyer_cpf": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "model": {
                     "type": "string"
                 },
+                "payment_id": {
+                    "type": "string"
+                },
                 "price": {
                     "type": "number"
+                },
+                "sale_date": {
+                    "type": "string"
+                },
+                "sale_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "string"
                 }
             }
         },
-        "dto.InputWebhookDTO": {
+        "dto.OutputBuyerSalesDTO": {
             "type": "object",
             "properties": {
-                "payment_id": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputBuyerSaleItemDTO"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputCreateListingDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "sale_id": {
                     "type": "string"
                 },
                 "status": {
@@ -341,17 +1229,43 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.OutputCreateListingDTO": {
+        "dto.OutputErrorDTO": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputListingDTO": {
+            "type": "object",
+            "properties": {
+                "brand": {
                     "type": "string"
                 },
+                "model": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
                 "sale_id": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "string"
                 }
             }
         },
@@ -382,6 +1296,150 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.OutputSalesAggregateDTO": {
+            "type": "object",
+            "properties": {
+                "average_hours_to_sale": {
+                    "type": "number"
+                },
+                "average_ticket": {
+                    "type": "number"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "reservations": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "units_sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputSalesAnalyticsDTO": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputSalesAggregateDTO"
+                    }
+                },
+                "period": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/dto.OutputSalesAggregateDTO"
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sale_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SaleStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/events.Type"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "events.Type": {
+            "type": "string",
+            "enum": [
+                "listing.created",
+                "listing.updated",
+                "listing.reserved",
+                "listing.sold",
+                "listing.canceled"
+            ],
+            "x-enum-varnames": [
+                "TypeListingCreated",
+                "TypeListingUpdated",
+                "TypeListingReserved",
+                "TypeListingSold",
+                "TypeListingCanceled"
+            ]
+        },
+        "health.ComponentStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.ComponentStatus"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "APIKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/app"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Backend abre um banco vazio para um teste.
+type Backend struct {
+	Name string
+	Open func(t *testing.T) app.Storage
+}
+
+// Backends devolve os bancos disponíveis: memória e SQLite sempre, e Postgres quando
+// TEST_DATABASE_URL está definida.
+func Backends() []Backend {
+	backends := []Backend{
+		{Name: "memory", Open: openMemory},
+		{Name: "sqlite", Open: openSQLite},
+	}
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		backends = append(backends, Backend{
+			Name: "postgres",
+			Open: func(t *testing.T) app.Storage { return openPostgres(t, url) },
+		})
+	}
+	return backends
+}
+
+func openMemory(*testing.T) app.Storage {
+	sales := repository.NewMemorySaleRepository()
+	return app.Storage{
+		Sales:     sales,
+		Tx:        repository.NewMemoryTxManager(),
+		Analytics: repository.NewMemoryAnalyticsRepository(sales),
+		APIKeys:   repository.NewMemoryAPIKeyRepository(),
+	}
+}
+
+func openSQLite(t *testing.T) app.Storage {
+	db := repositorytest.OpenSQLite(t)
+	return app.Storage{
+		Sales:     repository.NewSQLiteSaleRepository(db),
+		Tx:        repository.NewSQLTxManager(db),
+		Analytics: repository.NewSQLiteAnalyticsRepository(db),
+		APIKeys:   repository.NewSQLiteAPIKeyRepository(db),
+	}
+}
+
+func openPostgres(t *testing.T, baseURL string) app.Storage {
+	t.Helper()
+	db, err := sql.Open("pgx", repositorytest.PostgresSchema(t, baseURL))
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return app.Storage{
+		Sales:     repository.NewPostgresSaleRepository(db),
+		Tx:        repository.NewSQLTxManager(db),
+		Analytics: repository.NewPostgresAnalyticsRepository(db),
+		APIKeys:   repository.NewPostgresAPIKeyRepository(db),
+	}
+}
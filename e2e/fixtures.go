@@ -0,0 +1,28 @@
+package e2e
+
+import "github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+
+// CPFs válidos de compradores fictícios.
+const (
+	BuyerCPF      = "52998224725"
+	OtherBuyerCPF = "11144477735"
+)
+
+// Listing é o anúncio de um Toyota Corolla de R$ 95.000 para vehicleID.
+func Listing(vehicleID string) dto.InputCreateListingDTO {
+	return dto.InputCreateListingDTO{
+		VehicleID: vehicleID,
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     95000,
+	}
+}
+
+// SaleIDs extrai os IDs de uma listagem, para comparar com os IDs esperados.
+func SaleIDs(items []*dto.OutputSaleItemDTO) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.SaleID)
+	}
+	return ids
+}
@@ -0,0 +1,35 @@
+package e2e
+
+import (
+	"net/http"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+)
+
+// Status de pagamento enviados pelo gateway no webhook.
+const (
+	PaymentApproved = "APPROVED"
+	PaymentCanceled = "CANCELED"
+)
+
+// Gateway faz o papel do gateway de pagamento: confirma ou cancela pagamentos chamando o webhook
+// com a própria chave de API.
+type Gateway struct {
+	client *Client
+}
+
+func (g *Gateway) Approve(paymentID string) {
+	g.client.h.t.Helper()
+	g.client.expect(http.StatusNoContent, http.MethodPost, "/webhooks/payments", dto.InputWebhookDTO{PaymentID: paymentID, Status: PaymentApproved})
+}
+
+func (g *Gateway) Cancel(paymentID string) {
+	g.client.h.t.Helper()
+	g.client.expect(http.StatusNoContent, http.MethodPost, "/webhooks/payments", dto.InputWebhookDTO{PaymentID: paymentID, Status: PaymentCanceled})
+}
+
+// Notify envia o webhook sem conferir a resposta, para os cenários em que ele deve ser recusado.
+func (g *Gateway) Notify(paymentID, status string) *Response {
+	g.client.h.t.Helper()
+	return g.client.Do(http.MethodPost, "/webhooks/payments", dto.InputWebhookDTO{PaymentID: paymentID, Status: status})
+}
@@ -0,0 +1,296 @@
+// Package e2e sobe o serviço inteiro em processo, do roteador de handler.SetupRoutes até o
+// banco, atrás de um httptest.Server. Os testes falam com ele só por HTTP, como os clientes reais:
+// o catálogo e os compradores com JWT e o gateway de pagamento com chave de API.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/app"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/server"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenIssuer   = "https://auth.e2e.test"
+	tokenAudience = "showcase-service"
+	tokenKeyID    = "e2e-1"
+)
+
+// signingKey é gerada uma vez por processo; gerar uma chave RSA por teste deixaria a suíte lenta.
+var signingKey = sync.OnceValue(func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+})
+
+// Harness é uma instância do serviço com banco próprio. A lista de vendas disponíveis passa
+// por uma cache em memória, como em produção.
+type Harness struct {
+	Server  *httptest.Server
+	Storage app.Storage
+	Broker  *events.Broker
+	Gateway *Gateway
+
+	t *testing.T
+}
+
+// New sobe o serviço sobre um banco novo de backend. O servidor é fechado no fim de t.
+func New(t *testing.T, backend Backend) *Harness {
+	t.Helper()
+
+	keys, err := auth.ParseJWKS(jwksDocument(&signingKey().PublicKey))
+	require.NoError(t, err)
+
+	storage := backend.Open(t)
+	broker := events.NewBroker(16)
+	appMetrics := metrics.New()
+	workers := server.NewWorkers()
+	t.Cleanup(func() { _ = workers.Stop(context.Background()) })
+	application := app.New(storage, app.Options{
+		Verifier:          auth.NewVerifier(keys, tokenIssuer, tokenAudience),
+		Broker:            broker,
+		HeartbeatInterval: time.Minute,
+		Metrics:           appMetrics,
+		Readiness:         health.NewReadiness(time.Second),
+		SalesCache:        cache.NewLoader("available_sales", cache.NewLRU(16), time.Minute, appMetrics),
+		Workers:           workers,
+	})
+
+	server := httptest.NewServer(application.Router)
+	t.Cleanup(server.Close)
+
+	h := &Harness{
+		Server:  server,
+		Storage: storage,
+		Broker:  broker,
+		t:       t,
+	}
+
+	key, err := application.APIKeys.Create(context.Background(), dto.InputCreateAPIKeyDTO{
+		Name:   "e2e payment gateway",
+		Scopes: []string{domain.APIKeyScopePaymentGateway},
+	})
+	require.NoError(t, err)
+	h.Gateway = &Gateway{client: h.client(http.Header{"X-Api-Key": {key.Key}})}
+
+	return h
+}
+
+// Seed grava vendas direto no repositório, para cenários que não começam com um anúncio novo.
+// Save só grava os dados do anúncio; vendas já compradas são completadas com Update, como
+// acontece no fluxo real.
+func (h *Harness) Seed(sales ...*domain.Sale) {
+	h.t.Helper()
+	ctx := context.Background()
+	for _, sale := range sales {
+		require.NoError(h.t, h.Storage.Sales.Save(ctx, sale))
+		if sale.Status != domain.StatusAvailable {
+			require.NoError(h.t, h.Storage.Sales.Update(ctx, sale))
+		}
+	}
+}
+
+// Sale lê a venda direto do repositório, para conferir estados que a API não expõe.
+func (h *Harness) Sale(id string) *domain.Sale {
+	h.t.Helper()
+	sale, err := h.Storage.Sales.GetByID(context.Background(), id)
+	require.NoError(h.t, err)
+	return sale
+}
+
+// Anonymous faz requisições sem credenciais, como a vitrine pública.
+func (h *Harness) Anonymous() *Client {
+	return h.client(http.Header{})
+}
+
+func (h *Harness) Catalog() *Client {
+	return h.bearer(jwt.MapClaims{"sub": "catalog-service", "roles": []string{string(auth.RoleCatalogService)}})
+}
+
+func (h *Harness) Buyer(cpf string) *Client {
+	return h.bearer(jwt.MapClaims{"sub": "buyer-" + cpf, "roles": []string{string(auth.RoleBuyer)}, "cpf": cpf})
+}
+
+func (h *Harness) Admin() *Client {
+	return h.bearer(jwt.MapClaims{"sub": "admin", "roles": []string{string(auth.RoleAdmin)}})
+}
+
+func (h *Harness) bearer(claims jwt.MapClaims) *Client {
+	h.t.Helper()
+	claims["iss"] = tokenIssuer
+	claims["aud"] = tokenAudience
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = tokenKeyID
+	signed, err := token.SignedString(signingKey())
+	require.NoError(h.t, err)
+
+	return h.client(http.Header{"Authorization": {"Bearer " + signed}})
+}
+
+func (h *Harness) client(header http.Header) *Client {
+	return &Client{h: h, header: header}
+}
+
+// Client faz requisições ao serviço com as credenciais de um ator.
+type Client struct {
+	h      *Harness
+	header http.Header
+}
+
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	t *testing.T
+}
+
+// Decode lê o corpo JSON da resposta em v.
+func (r *Response) Decode(v any) {
+	r.t.Helper()
+	require.NoError(r.t, json.Unmarshal(r.Body, v), "body: %s", r.Body)
+}
+
+// Do envia body como JSON quando não é nil.
+func (c *Client) Do(method, path string, body any) *Response {
+	t := c.h.t
+	t.Helper()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	return c.send(method, path, "application/json", payload, nil)
+}
+
+// PatchListing envia patch como JSON Merge Patch, com If-Match quando ifMatch não é vazio.
+func (c *Client) PatchListing(vehicleID, patch, ifMatch string) *Response {
+	c.h.t.Helper()
+	header := http.Header{}
+	if ifMatch != "" {
+		header.Set("If-Match", ifMatch)
+	}
+	return c.send(http.MethodPatch, "/listings/vehicle/"+vehicleID, "application/merge-patch+json", []byte(patch), header)
+}
+
+func (c *Client) send(method, path, contentType string, payload []byte, header http.Header) *Response {
+	t := c.h.t
+	t.Helper()
+
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.h.Server.URL+path, reader)
+	require.NoError(t, err)
+	req.Header = c.header.Clone()
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.h.Server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body, t: t}
+}
+
+// CreateListing publica um anúncio e devolve o ID da venda.
+func (c *Client) CreateListing(listing dto.InputCreateListingDTO) string {
+	c.h.t.Helper()
+	resp := c.expect(http.StatusCreated, http.MethodPost, "/listings", listing)
+
+	var output dto.OutputCreateListingDTO
+	resp.Decode(&output)
+	return output.SaleID
+}
+
+// Purchase reserva a venda e devolve o ID do pagamento que o gateway vai confirmar.
+func (c *Client) Purchase(saleID string) string {
+	c.h.t.Helper()
+	resp := c.expect(http.StatusAccepted, http.MethodPost, "/sales/"+saleID+"/purchase", nil)
+
+	var output dto.OutputPurchaseDTO
+	resp.Decode(&output)
+	return output.PaymentID
+}
+
+func (c *Client) Available() []*dto.OutputSaleItemDTO {
+	c.h.t.Helper()
+	var output []*dto.OutputSaleItemDTO
+	c.expect(http.StatusOK, http.MethodGet, "/sales/available", nil).Decode(&output)
+	return output
+}
+
+func (c *Client) Sold() []*dto.OutputSaleItemDTO {
+	c.h.t.Helper()
+	var output []*dto.OutputSaleItemDTO
+	c.expect(http.StatusOK, http.MethodGet, "/sales/sold", nil).Decode(&output)
+	return output
+}
+
+// BuyerPurchases lista a primeira página das compras de cpf.
+func (c *Client) BuyerPurchases(cpf string) *dto.OutputBuyerSalesDTO {
+	c.h.t.Helper()
+	var output dto.OutputBuyerSalesDTO
+	c.expect(http.StatusOK, http.MethodPost, "/buyers/purchases", dto.InputBuyerSalesDTO{BuyerCPF: cpf}).Decode(&output)
+	return &output
+}
+
+func (c *Client) expect(status int, method, path string, body any) *Response {
+	c.h.t.Helper()
+	resp := c.Do(method, path, body)
+	require.Equal(c.h.t, status, resp.StatusCode, "%s %s: %s", method, path, resp.Body)
+	return resp
+}
+
+func jwksDocument(key *rsa.PublicKey) []byte {
+	encode := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.Bytes())
+	}
+	document, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": tokenKeyID,
+			"use": "sig",
+			"n":   encode(key.N),
+			"e":   encode(big.NewInt(int64(key.E))),
+		}},
+	})
+	return document
+}
@@ -0,0 +1,252 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/e2e"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain/domaintest"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type JourneySuite struct {
+	suite.Suite
+	backend e2e.Backend
+}
+
+func Test_JourneySuite(t *testing.T) {
+	t.Parallel()
+	for _, backend := range e2e.Backends() {
+		t.Run(backend.Name, func(t *testing.T) {
+			t.Parallel()
+			suite.Run(t, &JourneySuite{backend: backend})
+		})
+	}
+}
+
+func (suite *JourneySuite) Test_ApprovedPurchase() {
+	suite.T().Run("should move the listing from available to sold", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		require.Contains(t, e2e.SaleIDs(h.Anonymous().Available()), saleID)
+		require.Empty(t, h.Anonymous().Sold())
+
+		paymentID := h.Buyer(e2e.BuyerCPF).Purchase(saleID)
+		require.NotEmpty(t, paymentID)
+		require.NotContains(t, e2e.SaleIDs(h.Anonymous().Available()), saleID)
+		require.Equal(t, domain.StatusPendingPayment, h.Sale(saleID).Status)
+
+		h.Gateway.Approve(paymentID)
+
+		sold := h.Anonymous().Sold()
+		require.Len(t, sold, 1)
+		require.Equal(t, saleID, sold[0].SaleID)
+		require.Equal(t, "vehicle-1", sold[0].VehicleID)
+		require.Equal(t, 95000.0, sold[0].Price)
+		require.NotContains(t, e2e.SaleIDs(h.Anonymous().Available()), saleID)
+
+		sale := h.Sale(saleID)
+		require.Equal(t, domain.StatusSold, sale.Status)
+		require.Equal(t, paymentID, sale.PaymentID)
+		require.NotNil(t, sale.BuyerCPF)
+		require.Equal(t, e2e.BuyerCPF, *sale.BuyerCPF)
+		require.NotNil(t, sale.SaleDate)
+	})
+
+	suite.T().Run("should list the purchase for the buyer with a masked cpf", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		h.Gateway.Approve(h.Buyer(e2e.BuyerCPF).Purchase(saleID))
+
+		purchases := h.Admin().BuyerPurchases(e2e.BuyerCPF)
+		require.Equal(t, 1, purchases.Total)
+		require.Len(t, purchases.Items, 1)
+		require.Equal(t, saleID, purchases.Items[0].SaleID)
+		require.Equal(t, string(domain.StatusSold), purchases.Items[0].Status)
+		require.Equal(t, domain.MaskCPF(e2e.BuyerCPF), purchases.Items[0].BuyerCPF)
+
+		require.Zero(t, h.Admin().BuyerPurchases(e2e.OtherBuyerCPF).Total)
+	})
+
+	suite.T().Run("should keep earlier sales and list the new one alongside them", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		earlier := domaintest.Sale().Car("Honda", "Civic").Sold(e2e.OtherBuyerCPF, "payment-earlier", domaintest.Epoch.Add(time.Hour)).Build()
+		h.Seed(earlier)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		h.Gateway.Approve(h.Buyer(e2e.BuyerCPF).Purchase(saleID))
+
+		require.ElementsMatch(t, []string{earlier.ID, saleID}, e2e.SaleIDs(h.Anonymous().Sold()))
+	})
+}
+
+func (suite *JourneySuite) Test_CanceledPurchase() {
+	suite.T().Run("should cancel the sale and take it off both listings", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		paymentID := h.Buyer(e2e.BuyerCPF).Purchase(saleID)
+
+		h.Gateway.Cancel(paymentID)
+
+		require.NotContains(t, e2e.SaleIDs(h.Anonymous().Available()), saleID)
+		require.NotContains(t, e2e.SaleIDs(h.Anonymous().Sold()), saleID)
+
+		sale := h.Sale(saleID)
+		require.Equal(t, domain.StatusCanceled, sale.Status)
+		require.Equal(t, paymentID, sale.PaymentID)
+
+		purchases := h.Admin().BuyerPurchases(e2e.BuyerCPF)
+		require.Len(t, purchases.Items, 1)
+		require.Equal(t, string(domain.StatusCanceled), purchases.Items[0].Status)
+	})
+
+	suite.T().Run("should reject a late approval for a canceled payment", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		paymentID := h.Buyer(e2e.BuyerCPF).Purchase(saleID)
+		h.Gateway.Cancel(paymentID)
+
+		resp := h.Gateway.Notify(paymentID, e2e.PaymentApproved)
+
+		require.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, domain.StatusCanceled, h.Sale(saleID).Status)
+		require.Empty(t, h.Anonymous().Sold())
+	})
+}
+
+func (suite *JourneySuite) Test_PatchListing() {
+	suite.T().Run("should patch only the price and refuse a patch based on the old version", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+
+		read := h.Catalog().Do(http.MethodGet, "/listings/vehicle/vehicle-1", nil)
+		require.Equal(t, http.StatusOK, read.StatusCode, "%s", read.Body)
+		etag := read.Header.Get("ETag")
+		require.NotEmpty(t, etag)
+
+		patched := h.Catalog().PatchListing("vehicle-1", `{"price": 89900}`, etag)
+		require.Equal(t, http.StatusOK, patched.StatusCode, "%s", patched.Body)
+		require.NotEqual(t, etag, patched.Header.Get("ETag"))
+
+		sale := h.Sale(saleID)
+		require.Equal(t, 89900.0, sale.Price)
+		require.Equal(t, e2e.Listing("vehicle-1").Brand, sale.Brand)
+		require.Equal(t, e2e.Listing("vehicle-1").Model, sale.Model)
+
+		stale := h.Catalog().PatchListing("vehicle-1", `{"price": 1}`, etag)
+		require.Equal(t, http.StatusPreconditionFailed, stale.StatusCode, "%s", stale.Body)
+		require.Equal(t, 89900.0, h.Sale(saleID).Price)
+
+		reread := h.Catalog().Do(http.MethodGet, "/listings/vehicle/vehicle-1", nil)
+		require.Equal(t, patched.Header.Get("ETag"), reread.Header.Get("ETag"))
+	})
+}
+
+func (suite *JourneySuite) Test_AvailableCache() {
+	suite.T().Run("should show a price change right after the patch", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		require.Equal(t, 95000.0, h.Anonymous().Available()[0].Price)
+
+		read := h.Catalog().Do(http.MethodGet, "/listings/vehicle/vehicle-1", nil)
+		patched := h.Catalog().PatchListing("vehicle-1", `{"price": 89900}`, read.Header.Get("ETag"))
+		require.Equal(t, http.StatusOK, patched.StatusCode, "%s", patched.Body)
+
+		require.Equal(t, 89900.0, h.Anonymous().Available()[0].Price)
+	})
+
+	suite.T().Run("should drop the cached list on events from other instances", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		require.Len(t, h.Anonymous().Available(), 1)
+
+		// Simula outra instância: grava direto no banco e o evento chega pelo broker.
+		other := domaintest.Sale().Car("Honda", "Civic").Build()
+		h.Seed(other)
+		require.NoError(t, h.Broker.Publish(context.Background(), events.NewSaleEvent(events.TypeListingCreated, other)))
+
+		require.Eventually(t, func() bool {
+			return len(h.Anonymous().Available()) == 2
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+}
+
+func (suite *JourneySuite) Test_RejectedRequests() {
+	suite.T().Run("should not sell a listing twice", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		paymentID := h.Buyer(e2e.BuyerCPF).Purchase(saleID)
+
+		resp := h.Buyer(e2e.OtherBuyerCPF).Do(http.MethodPost, "/sales/"+saleID+"/purchase", nil)
+
+		require.NotEqual(t, http.StatusAccepted, resp.StatusCode)
+		sale := h.Sale(saleID)
+		require.Equal(t, paymentID, sale.PaymentID)
+		require.Equal(t, e2e.BuyerCPF, *sale.BuyerCPF)
+	})
+
+	suite.T().Run("should reserve a listing for only one of several buyers racing for it", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+
+		buyers := []string{e2e.BuyerCPF, e2e.OtherBuyerCPF, e2e.BuyerCPF, e2e.OtherBuyerCPF}
+		statuses := make([]int, len(buyers))
+		var wg sync.WaitGroup
+		for i, cpf := range buyers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				statuses[i] = h.Buyer(cpf).Do(http.MethodPost, "/sales/"+saleID+"/purchase", nil).StatusCode
+			}()
+		}
+		wg.Wait()
+
+		accepted := 0
+		for _, status := range statuses {
+			if status == http.StatusAccepted {
+				accepted++
+			}
+		}
+		require.Equal(t, 1, accepted, "statuses: %v", statuses)
+		require.Equal(t, domain.StatusPendingPayment, h.Sale(saleID).Status)
+		require.Equal(t, 2, h.Sale(saleID).Version)
+	})
+
+	suite.T().Run("should refuse a seeded reservation purchase and accept its webhook", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+		reserved := domaintest.Sale().Reserved(e2e.OtherBuyerCPF, "payment-1", domaintest.Epoch.Add(time.Hour)).Build()
+		h.Seed(reserved)
+
+		resp := h.Buyer(e2e.BuyerCPF).Do(http.MethodPost, "/sales/"+reserved.ID+"/purchase", nil)
+		require.NotEqual(t, http.StatusAccepted, resp.StatusCode)
+
+		h.Gateway.Approve("payment-1")
+		require.Equal(t, []string{reserved.ID}, e2e.SaleIDs(h.Anonymous().Sold()))
+	})
+
+	suite.T().Run("should only let each actor call its own routes", func(t *testing.T) {
+		h := e2e.New(t, suite.backend)
+
+		require.Equal(t, http.StatusUnauthorized, h.Anonymous().Do(http.MethodPost, "/listings", e2e.Listing("vehicle-1")).StatusCode)
+		require.Equal(t, http.StatusForbidden, h.Buyer(e2e.BuyerCPF).Do(http.MethodPost, "/listings", e2e.Listing("vehicle-1")).StatusCode)
+
+		saleID := h.Catalog().CreateListing(e2e.Listing("vehicle-1"))
+		require.Equal(t, http.StatusForbidden, h.Catalog().Do(http.MethodPost, "/sales/"+saleID+"/purchase", nil).StatusCode)
+
+		paymentID := h.Buyer(e2e.BuyerCPF).Purchase(saleID)
+		resp := h.Buyer(e2e.BuyerCPF).Do(http.MethodPost, "/webhooks/payments", map[string]string{"payment_id": paymentID, "status": e2e.PaymentApproved})
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+		require.Equal(t, domain.StatusPendingPayment, h.Sale(saleID).Status)
+	})
+}
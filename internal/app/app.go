@@ -0,0 +1,104 @@
+// Package app monta o serviço: casos de uso, handlers e o roteador HTTP sobre os repositórios
+// recebidos. O main serve o App com server.Server; os testes e2e o servem com httptest.
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	handler "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/server"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	"github.com/go-chi/chi"
+)
+
+// Storage são os repositórios usados pelo serviço. Tx abre transações no mesmo banco de Sales.
+type Storage struct {
+	Sales     repository.SaleRepository
+	Tx        repository.TxManager
+	Analytics repository.AnalyticsRepository
+	APIKeys   repository.APIKeyRepository
+}
+
+// Options reúne o que o App recebe pronto de fora. Com Publisher nil, os eventos vão direto
+// para o Broker desta instância. Com SalesCache, a lista de vendas disponíveis passa pela cache;
+// Workers, quando informado, roda a invalidação pelos eventos das outras instâncias.
+type Options struct {
+	Features          handler.Features
+	Verifier          auth.TokenVerifier
+	Broker            *events.Broker
+	Publisher         events.Publisher
+	HeartbeatInterval time.Duration
+	Metrics           *metrics.Metrics
+	Readiness         *health.Readiness
+	SalesCache        *cache.Loader
+	Workers           *server.Workers
+}
+
+type App struct {
+	Router  *chi.Mux
+	Stream  *handler.SaleStreamHandler
+	APIKeys usecase.APIKeyUseCaseInterface
+}
+
+func New(storage Storage, opts Options) *App {
+	var publisher events.Publisher = opts.Broker
+	if opts.Publisher != nil {
+		publisher = opts.Publisher
+	}
+
+	var sales repository.SaleRepository = storage.Sales
+	if opts.SalesCache != nil {
+		cached := repository.NewCachedSaleRepository(storage.Sales, opts.SalesCache)
+		sales = cached
+		publisher = invalidatingPublisher{next: publisher, sales: cached}
+		if opts.Workers != nil {
+			opts.Workers.Go("sales_cache_invalidation", func(ctx context.Context) {
+				invalidateOnEvents(ctx, opts.Broker, cached)
+			})
+		}
+	}
+
+	saleUseCase := usecase.NewSaleUseCase(sales, storage.Tx, publisher, opts.Metrics)
+	opts.Metrics.RegisterSalesByStatus(salesByStatus(storage.Sales))
+	apiKeys := usecase.NewAPIKeyUseCase(storage.APIKeys)
+
+	handlers := handler.Handlers{
+		Sale:      handler.NewSaleHandler(saleUseCase),
+		Analytics: handler.NewAnalyticsHandler(usecase.NewAnalyticsUseCase(storage.Analytics)),
+		Stream:    handler.NewSaleStreamHandler(opts.Broker, opts.HeartbeatInterval),
+		Health:    handler.NewHealthHandler(opts.Readiness),
+	}
+
+	router := chi.NewRouter()
+	handler.SetupRoutes(router, opts.Features, handlers, opts.Verifier, apiKeys, opts.Metrics)
+
+	return &App{
+		Router:  router,
+		Stream:  handlers.Stream,
+		APIKeys: apiKeys,
+	}
+}
+
+// salesByStatus adapta o repositório ao gauge de vendas, preenchendo com zero os status sem vendas.
+func salesByStatus(repo repository.SaleRepository) metrics.StatusCounter {
+	return func(ctx context.Context) (map[string]int, error) {
+		counts, err := repo.CountByStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]int, len(domain.SaleStatuses))
+		for _, status := range domain.SaleStatuses {
+			result[string(status)] = counts[status]
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+)
+
+// invalidatingPublisher descarta a cache de vendas antes de publicar o evento, para que esta
+// instância nunca sirva uma lista anterior à mudança que ela mesma acabou de gravar.
+type invalidatingPublisher struct {
+	next  events.Publisher
+	sales *repository.CachedSaleRepository
+}
+
+func (p invalidatingPublisher) Publish(ctx context.Context, batch ...events.Event) error {
+	invalidateSales(ctx, p.sales)
+	return p.next.Publish(ctx, batch...)
+}
+
+// invalidateOnEvents descarta a cache a cada evento recebido pelo broker, o que inclui as
+// mudanças feitas em outras instâncias. Um assinante lento é desconectado pelo broker e pode ter
+// perdido eventos; nesse caso a cache é descartada e a assinatura, refeita.
+func invalidateOnEvents(ctx context.Context, broker *events.Broker, sales *repository.CachedSaleRepository) {
+	for {
+		sub := broker.Subscribe("")
+		invalidateSales(ctx, sales)
+		if !drainEvents(ctx, sub, sales) {
+			return
+		}
+		slog.WarnContext(ctx, "sales cache invalidation fell behind the event stream, subscribing again")
+	}
+}
+
+// drainEvents devolve false quando ctx termina e true quando o broker encerra a assinatura.
+func drainEvents(ctx context.Context, sub *events.Subscription, sales *repository.CachedSaleRepository) bool {
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case _, ok := <-sub.Events():
+			if !ok {
+				return true
+			}
+			invalidateSales(ctx, sales)
+		}
+	}
+}
+
+// invalidateSales só registra a falha: a lista velha sai da cache de qualquer jeito quando o TTL
+// vence.
+func invalidateSales(ctx context.Context, sales *repository.CachedSaleRepository) {
+	if err := sales.Invalidate(ctx); err != nil {
+		slog.WarnContext(ctx, "could not invalidate sales cache", "error", err)
+	}
+}
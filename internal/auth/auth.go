@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+type Role string
+
+const (
+	RoleCatalogService Role = "catalog-service"
+	RoleBuyer          Role = "buyer"
+	RoleAdmin          Role = "admin"
+	RolePaymentGateway Role = "payment-gateway"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+// Principal é a identidade autenticada de quem fez a requisição.
+type Principal struct {
+	Subject string
+	Roles   []Role
+	CPF     string
+}
+
+func (p *Principal) HasAnyRole(roles ...Role) bool {
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+//go:generate mockgen -source=auth.go -destination=./mocks/auth_mock.go -package=mocks
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
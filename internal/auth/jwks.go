@@ -0,0 +1,294 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	jwksFetchTimeout   = 10 * time.Second
+	jwksMaxSize        = 1 << 20
+	jwksRefreshBackoff = time.Minute
+)
+
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet é um conjunto estático de chaves públicas indexado pelo kid.
+type KeySet struct {
+	keys map[string]crypto.PublicKey
+}
+
+// ParseJWKS lê um documento JWKS (RFC 7517). Chaves que não são de assinatura são ignoradas.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	set := &KeySet{keys: make(map[string]crypto.PublicKey, len(document.Keys))}
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", jwk.Kid, err)
+		}
+		set.keys[jwk.Kid] = key
+	}
+	if len(set.keys) == 0 {
+		return nil, errors.New("jwks has no signing keys")
+	}
+
+	return set, nil
+}
+
+func (s *KeySet) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+// RemoteKeySet busca o JWKS por HTTP e o recarrega periodicamente ou quando aparece um kid
+// desconhecido (rotação de chaves), limitado a uma busca por minuto. A busca roda fora do lock e
+// uma de cada vez: os kids já conhecidos continuam sendo respondidos enquanto ela não termina.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	backoff         time.Duration
+	group           singleflight.Group
+
+	mu        sync.RWMutex
+	set       *KeySet
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+func NewRemoteKeySet(url string, client *http.Client, refreshInterval time.Duration) *RemoteKeySet {
+	if client == nil {
+		client = &http.Client{Timeout: jwksFetchTimeout, Transport: telemetry.NewTransport(nil)}
+	}
+	return &RemoteKeySet{
+		url:             url,
+		client:          client,
+		refreshInterval: refreshInterval,
+		backoff:         jwksRefreshBackoff,
+	}
+}
+
+// Key responde com o conjunto em cache quando ele conhece kid; se o conjunto estiver vencido, a
+// recarga roda em segundo plano. Só um kid desconhecido espera pela busca.
+func (r *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	set, fetchedAt := r.current()
+	if set != nil {
+		if key, err := set.Key(ctx, kid); err == nil {
+			if time.Since(fetchedAt) >= r.refreshInterval && r.due() {
+				go r.refreshOnce(context.WithoutCancel(ctx))
+			}
+			return key, nil
+		}
+	}
+
+	if err := r.refreshOnce(ctx); err != nil && set == nil {
+		return nil, err
+	}
+	if set, _ = r.current(); set == nil {
+		return nil, ErrUnknownKey
+	}
+
+	return set.Key(ctx, kid)
+}
+
+func (r *RemoteKeySet) current() (*KeySet, time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.set, r.fetchedAt
+}
+
+func (r *RemoteKeySet) due() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return time.Since(r.triedAt) >= r.backoff
+}
+
+// refreshOnce busca o JWKS se a última tentativa foi há mais de backoff. Chamadas simultâneas
+// esperam pela mesma busca, que não é cancelada quando quem a iniciou desiste.
+func (r *RemoteKeySet) refreshOnce(ctx context.Context) error {
+	result := r.group.DoChan("jwks", func() (any, error) {
+		r.mu.Lock()
+		if time.Since(r.triedAt) < r.backoff {
+			r.mu.Unlock()
+			return nil, nil
+		}
+		r.triedAt = time.Now()
+		r.mu.Unlock()
+
+		return nil, r.refresh(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-result:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *RemoteKeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+	if err != nil {
+		return fmt.Errorf("could not fetch jwks: %w", err)
+	}
+
+	set, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.set = set
+	r.fetchedAt = time.Now()
+	return nil
+}
+
+// LoadKeySource aceita o caminho de um arquivo JWKS ou uma URL http(s). No caso da URL a primeira
+// busca é feita aqui, para que uma configuração errada falhe na inicialização.
+func LoadKeySource(ctx context.Context, location string, refreshInterval time.Duration) (KeySource, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		remote := NewRemoteKeySet(location, nil, refreshInterval)
+		remote.triedAt = time.Now()
+		if err := remote.refresh(ctx); err != nil {
+			return nil, err
+		}
+		return remote, nil
+	}
+
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("could not read jwks file: %w", err)
+	}
+	return ParseJWKS(data)
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoteKeySetSuite struct {
+	suite.Suite
+	key      *rsa.PrivateKey
+	document []byte
+}
+
+func Test_RemoteKeySetSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RemoteKeySetSuite))
+}
+
+func (suite *RemoteKeySetSuite) SetupSuite() {
+	var err error
+	suite.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+
+	encode := func(value *big.Int) string { return base64.RawURLEncoding.EncodeToString(value.Bytes()) }
+	suite.document, err = json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA", "kid": "rsa-1", "n": encode(suite.key.N), "e": encode(big.NewInt(int64(suite.key.E))),
+	}}})
+	suite.Require().NoError(err)
+}
+
+// blockingServer responde ao primeiro pedido na hora e segura os seguintes até release ser fechado.
+func (suite *RemoteKeySetSuite) blockingServer(t *testing.T) (server *httptest.Server, started <-chan struct{}, release chan struct{}) {
+	var calls atomic.Int32
+	startedCh := make(chan struct{}, 10)
+	release = make(chan struct{})
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			startedCh <- struct{}{}
+			<-release
+		}
+		w.Write(suite.document)
+	}))
+	t.Cleanup(server.Close)
+	return server, startedCh, release
+}
+
+func (suite *RemoteKeySetSuite) Test_Key() {
+	suite.T().Run("should serve a known kid while a refresh is in flight", func(t *testing.T) {
+		server, started, release := suite.blockingServer(t)
+		defer close(release)
+		remote := NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+		remote.backoff = 0
+		_, err := remote.Key(context.Background(), "rsa-1")
+		suite.Require().NoError(err)
+
+		go remote.Key(context.Background(), "rotated")
+		<-started
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := remote.Key(context.Background(), "rsa-1")
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			suite.NoError(err)
+		case <-time.After(time.Second):
+			suite.Fail("known kid waited for the jwks refresh")
+		}
+	})
+
+	suite.T().Run("should stop waiting for the refresh when ctx is canceled", func(t *testing.T) {
+		server, started, release := suite.blockingServer(t)
+		defer close(release)
+		remote := NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+		remote.backoff = 0
+		_, err := remote.Key(context.Background(), "rsa-1")
+		suite.Require().NoError(err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-started
+			cancel()
+		}()
+		_, err = remote.Key(ctx, "rotated")
+
+		suite.ErrorIs(err, ErrUnknownKey)
+	})
+
+	suite.T().Run("should refresh a stale set in the background", func(t *testing.T) {
+		server, started, release := suite.blockingServer(t)
+		defer close(release)
+		remote := NewRemoteKeySet(server.URL, server.Client(), time.Nanosecond)
+		remote.backoff = 0
+		_, err := remote.Key(context.Background(), "rsa-1")
+		suite.Require().NoError(err)
+
+		_, err = remote.Key(context.Background(), "rsa-1")
+		suite.NoError(err)
+
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			suite.Fail("stale set was not refreshed")
+		}
+	})
+}
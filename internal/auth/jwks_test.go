@@ -0,0 +1,223 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/stretchr/testify/suite"
+)
+
+func encodeInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": encodeInt(key.N), "e": encodeInt(big.NewInt(int64(key.E)))}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{"kty": "EC", "kid": kid, "crv": "P-256", "x": encodeInt(key.X), "y": encodeInt(key.Y)}
+}
+
+func ed25519JWK(kid string, key ed25519.PublicKey) map[string]string {
+	return map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": base64.RawURLEncoding.EncodeToString(key)}
+}
+
+func jwksDocument(keys ...map[string]string) []byte {
+	data, _ := json.Marshal(map[string]any{"keys": keys})
+	return data
+}
+
+type JWKSSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	edKey  ed25519.PublicKey
+}
+
+func (suite *JWKSSuite) SetupSuite() {
+	var err error
+	suite.ctx = context.Background()
+	suite.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+	suite.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+	suite.edKey, _, err = ed25519.GenerateKey(rand.Reader)
+	suite.Require().NoError(err)
+}
+
+func Test_JWKSSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(JWKSSuite))
+}
+
+func (suite *JWKSSuite) Test_ParseJWKS() {
+	suite.T().Run("should parse RSA, EC and Ed25519 keys", func(t *testing.T) {
+		set, err := auth.ParseJWKS(jwksDocument(
+			rsaJWK("rsa-1", &suite.rsaKey.PublicKey),
+			ecJWK("ec-1", &suite.ecKey.PublicKey),
+			ed25519JWK("ed-1", suite.edKey),
+		))
+		suite.NoError(err)
+
+		key, err := set.Key(suite.ctx, "rsa-1")
+		suite.NoError(err)
+		suite.True(suite.rsaKey.PublicKey.Equal(key))
+
+		key, err = set.Key(suite.ctx, "ec-1")
+		suite.NoError(err)
+		suite.True(suite.ecKey.PublicKey.Equal(key))
+
+		key, err = set.Key(suite.ctx, "ed-1")
+		suite.NoError(err)
+		suite.True(suite.edKey.Equal(key))
+	})
+
+	suite.T().Run("should return ErrUnknownKey for a missing kid", func(t *testing.T) {
+		set, err := auth.ParseJWKS(jwksDocument(rsaJWK("rsa-1", &suite.rsaKey.PublicKey), ecJWK("ec-1", &suite.ecKey.PublicKey)))
+		suite.NoError(err)
+
+		_, err = set.Key(suite.ctx, "other")
+		suite.ErrorIs(err, auth.ErrUnknownKey)
+
+		_, err = set.Key(suite.ctx, "")
+		suite.ErrorIs(err, auth.ErrUnknownKey)
+	})
+
+	suite.T().Run("should use the only key when the token has no kid", func(t *testing.T) {
+		set, err := auth.ParseJWKS(jwksDocument(rsaJWK("rsa-1", &suite.rsaKey.PublicKey)))
+		suite.NoError(err)
+
+		key, err := set.Key(suite.ctx, "")
+		suite.NoError(err)
+		suite.True(suite.rsaKey.PublicKey.Equal(key))
+	})
+
+	suite.T().Run("should skip encryption keys", func(t *testing.T) {
+		encryption := rsaJWK("enc-1", &suite.rsaKey.PublicKey)
+		encryption["use"] = "enc"
+
+		_, err := auth.ParseJWKS(jwksDocument(encryption))
+		suite.EqualError(err, "jwks has no signing keys")
+	})
+
+	suite.T().Run("should reject invalid documents and keys", func(t *testing.T) {
+		_, err := auth.ParseJWKS([]byte("{"))
+		suite.ErrorContains(err, "invalid jwks")
+
+		_, err = auth.ParseJWKS(jwksDocument(map[string]string{"kty": "oct", "kid": "hmac"}))
+		suite.ErrorContains(err, `unsupported key type "oct"`)
+
+		point := ecJWK("ec-1", &suite.ecKey.PublicKey)
+		point["y"] = point["x"]
+		_, err = auth.ParseJWKS(jwksDocument(point))
+		suite.ErrorContains(err, "point is not on curve")
+
+		_, err = auth.ParseJWKS(jwksDocument(map[string]string{"kty": "RSA", "kid": "rsa-1"}))
+		suite.ErrorContains(err, "missing key parameter")
+	})
+}
+
+func (suite *JWKSSuite) Test_RemoteKeySet() {
+	suite.T().Run("should fetch once and serve known keys from cache", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Write(jwksDocument(rsaJWK("rsa-1", &suite.rsaKey.PublicKey)))
+		}))
+		defer server.Close()
+
+		remote := auth.NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+		for range 3 {
+			key, err := remote.Key(suite.ctx, "rsa-1")
+			suite.NoError(err)
+			suite.True(suite.rsaKey.PublicKey.Equal(key))
+		}
+		suite.Equal(int32(1), calls.Load())
+	})
+
+	suite.T().Run("should not refetch on every unknown kid", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Write(jwksDocument(rsaJWK("rsa-1", &suite.rsaKey.PublicKey)))
+		}))
+		defer server.Close()
+
+		remote := auth.NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+		for range 3 {
+			_, err := remote.Key(suite.ctx, "rotated")
+			suite.ErrorIs(err, auth.ErrUnknownKey)
+		}
+		suite.Equal(int32(1), calls.Load())
+	})
+
+	suite.T().Run("should return error when the endpoint fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		remote := auth.NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+		_, err := remote.Key(suite.ctx, "rsa-1")
+		suite.ErrorContains(err, "unexpected status 503")
+	})
+}
+
+func (suite *JWKSSuite) Test_LoadKeySource() {
+	suite.T().Run("should load keys from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		suite.Require().NoError(os.WriteFile(path, jwksDocument(ecJWK("ec-1", &suite.ecKey.PublicKey)), 0o600))
+
+		keys, err := auth.LoadKeySource(suite.ctx, path, time.Hour)
+		suite.NoError(err)
+
+		key, err := keys.Key(suite.ctx, "ec-1")
+		suite.NoError(err)
+		suite.True(suite.ecKey.PublicKey.Equal(key))
+	})
+
+	suite.T().Run("should load keys from a URL", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(jwksDocument(ed25519JWK("ed-1", suite.edKey)))
+		}))
+		defer server.Close()
+
+		keys, err := auth.LoadKeySource(suite.ctx, server.URL, time.Hour)
+		suite.NoError(err)
+
+		key, err := keys.Key(suite.ctx, "ed-1")
+		suite.NoError(err)
+		suite.True(suite.edKey.Equal(key))
+	})
+
+	suite.T().Run("should return error for a missing file", func(t *testing.T) {
+		_, err := auth.LoadKeySource(suite.ctx, filepath.Join(t.TempDir(), "missing.json"), time.Hour)
+		suite.ErrorContains(err, "could not read jwks file")
+	})
+
+	suite.T().Run("should return error for an unreachable URL", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		_, err := auth.LoadKeySource(suite.ctx, server.URL, time.Hour)
+		suite.ErrorContains(err, "could not fetch jwks")
+	})
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const clockSkew = 30 * time.Second
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+	CPF   string   `json:"cpf"`
+}
+
+type jwtVerifier struct {
+	keys   KeySource
+	parser *jwt.Parser
+}
+
+// NewVerifier valida tokens assinados por uma das chaves de keys, emitidos por issuer e,
+// quando audience não é vazio, destinados a essa audiência. O token deve ter expiração.
+func NewVerifier(keys KeySource, issuer, audience string) TokenVerifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &jwtVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	var parsed claims
+	_, err := v.parser.ParseWithClaims(token, &parsed, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	principal := &Principal{
+		Subject: parsed.Subject,
+		CPF:     parsed.CPF,
+		Roles:   make([]Role, 0, len(parsed.Roles)),
+	}
+	for _, role := range parsed.Roles {
+		principal.Roles = append(principal.Roles, Role(role))
+	}
+
+	return principal, nil
+}
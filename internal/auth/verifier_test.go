@@ -0,0 +1,155 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+const testIssuer = "https://auth.example.com"
+
+type VerifierSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	rsaKey   *rsa.PrivateKey
+	edKey    ed25519.PrivateKey
+	verifier auth.TokenVerifier
+}
+
+func (suite *VerifierSuite) SetupSuite() {
+	var err error
+	suite.ctx = context.Background()
+	suite.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	suite.Require().NoError(err)
+	suite.edKey = edKey
+
+	keys, err := auth.ParseJWKS(jwksDocument(rsaJWK("rsa-1", &suite.rsaKey.PublicKey), ed25519JWK("ed-1", edPublic)))
+	suite.Require().NoError(err)
+	suite.verifier = auth.NewVerifier(keys, testIssuer, "showcase-service")
+}
+
+func Test_VerifierSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VerifierSuite))
+}
+
+func (suite *VerifierSuite) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   testIssuer,
+		"aud":   "showcase-service",
+		"sub":   "user-1",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"buyer"},
+		"cpf":   "12345678900",
+	}
+}
+
+func (suite *VerifierSuite) sign(method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	suite.Require().NoError(err)
+	return signed
+}
+
+func (suite *VerifierSuite) Test_Verify() {
+	suite.T().Run("should return the principal of a valid RS256 token", func(t *testing.T) {
+		principal, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", suite.rsaKey, suite.claims()))
+		suite.NoError(err)
+		suite.Equal("user-1", principal.Subject)
+		suite.Equal([]auth.Role{auth.RoleBuyer}, principal.Roles)
+		suite.Equal("12345678900", principal.CPF)
+	})
+
+	suite.T().Run("should accept EdDSA tokens", func(t *testing.T) {
+		principal, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodEdDSA, "ed-1", suite.edKey, suite.claims()))
+		suite.NoError(err)
+		suite.Equal("user-1", principal.Subject)
+	})
+
+	suite.T().Run("should reject expired tokens", func(t *testing.T) {
+		claims := suite.claims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", suite.rsaKey, claims))
+		suite.ErrorIs(err, auth.ErrInvalidToken)
+		suite.ErrorIs(err, jwt.ErrTokenExpired)
+	})
+
+	suite.T().Run("should reject tokens without expiration", func(t *testing.T) {
+		claims := suite.claims()
+		delete(claims, "exp")
+
+		_, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", suite.rsaKey, claims))
+		suite.ErrorIs(err, auth.ErrInvalidToken)
+	})
+
+	suite.T().Run("should reject tokens from another issuer", func(t *testing.T) {
+		claims := suite.claims()
+		claims["iss"] = "https://evil.example.com"
+
+		_, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", suite.rsaKey, claims))
+		suite.ErrorIs(err, jwt.ErrTokenInvalidIssuer)
+	})
+
+	suite.T().Run("should reject tokens for another audience", func(t *testing.T) {
+		claims := suite.claims()
+		claims["aud"] = "other-service"
+
+		_, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", suite.rsaKey, claims))
+		suite.ErrorIs(err, jwt.ErrTokenInvalidAudience)
+	})
+
+	suite.T().Run("should reject tokens signed by an unknown key", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		suite.Require().NoError(err)
+
+		_, err = suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-1", other, suite.claims()))
+		suite.ErrorIs(err, auth.ErrInvalidToken)
+
+		_, err = suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodRS256, "rsa-2", other, suite.claims()))
+		suite.ErrorIs(err, auth.ErrUnknownKey)
+	})
+
+	suite.T().Run("should reject symmetric and unsigned tokens", func(t *testing.T) {
+		_, err := suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodHS256, "rsa-1", []byte("secret"), suite.claims()))
+		suite.ErrorIs(err, jwt.ErrTokenSignatureInvalid)
+
+		_, err = suite.verifier.Verify(suite.ctx, suite.sign(jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, suite.claims()))
+		suite.ErrorIs(err, auth.ErrInvalidToken)
+	})
+
+	suite.T().Run("should reject malformed tokens", func(t *testing.T) {
+		_, err := suite.verifier.Verify(suite.ctx, "not-a-token")
+		suite.ErrorIs(err, jwt.ErrTokenMalformed)
+	})
+}
+
+func (suite *VerifierSuite) Test_Principal() {
+	suite.T().Run("should match any of the given roles", func(t *testing.T) {
+		principal := &auth.Principal{Roles: []auth.Role{auth.RoleCatalogService}}
+		suite.True(principal.HasAnyRole(auth.RoleAdmin, auth.RoleCatalogService))
+		suite.False(principal.HasAnyRole(auth.RoleAdmin))
+		suite.False(principal.HasAnyRole())
+	})
+
+	suite.T().Run("should carry the principal in the context", func(t *testing.T) {
+		_, ok := auth.PrincipalFromContext(suite.ctx)
+		suite.False(ok)
+
+		principal := &auth.Principal{Subject: "user-1"}
+		found, ok := auth.PrincipalFromContext(auth.WithPrincipal(suite.ctx, principal))
+		suite.True(ok)
+		suite.Same(principal, found)
+	})
+}
@@ -0,0 +1,16 @@
+// Package cache guarda respostas caras de montar por um tempo limitado. Há uma implementação em
+// memória (LRU) e uma que fala o protocolo do Redis; o Loader fica na frente de qualquer uma delas
+// e evita que várias requisições recalculem o mesmo valor ao mesmo tempo.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache guarda valores opacos por chave. Get devolve false quando a chave não existe ou expirou.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
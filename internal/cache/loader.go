@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader lê pela cache e, quando a chave falta, chama load uma vez só por chave, por mais
+// requisições que cheguem juntas. Uma falha da cache não derruba a leitura: o erro é registrado
+// e o valor vem de load.
+type Loader struct {
+	name     string
+	cache    Cache
+	ttl      time.Duration
+	recorder metrics.CacheRecorder
+	group    singleflight.Group
+
+	// mu protege só generation, que impede que um load iniciado antes de um Invalidate grave o
+	// valor velho depois dele. Nenhuma chamada à cache acontece com mu travado.
+	mu         sync.Mutex
+	generation uint64
+}
+
+// NewLoader cria um Loader; name identifica a cache nos logs e nas métricas.
+func NewLoader(name string, cache Cache, ttl time.Duration, recorder metrics.CacheRecorder) *Loader {
+	return &Loader{
+		name:     name,
+		cache:    cache,
+		ttl:      ttl,
+		recorder: recorder,
+	}
+}
+
+func (l *Loader) Load(ctx context.Context, key string, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	value, ok, err := l.cache.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "could not read from cache", "cache", l.name, "key", key, "error", err)
+	}
+	if ok {
+		l.recorder.CacheLookup(l.name, metrics.CacheHit)
+		return value, nil
+	}
+	l.recorder.CacheLookup(l.name, metrics.CacheMiss)
+
+	// O load compartilhado não é cancelado quando a requisição que o iniciou desiste; as outras
+	// que esperam por ele ainda precisam do valor.
+	result := l.group.DoChan(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		generation := l.currentGeneration()
+
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		l.store(ctx, key, value, generation)
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	}
+}
+
+// Invalidate remove as chaves; um load em andamento não grava mais o valor que leu antes disso.
+func (l *Loader) Invalidate(ctx context.Context, keys ...string) error {
+	l.mu.Lock()
+	l.generation++
+	l.mu.Unlock()
+
+	for _, key := range keys {
+		l.group.Forget(key)
+	}
+	return l.cache.Delete(ctx, keys...)
+}
+
+func (l *Loader) currentGeneration() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.generation
+}
+
+// store grava o valor se nenhum Invalidate aconteceu desde o início do load. Um Invalidate que
+// chegue durante o Set pode apagar a chave antes de ela ser gravada, por isso a geração é
+// conferida de novo depois e a chave é removida se tiver mudado.
+func (l *Loader) store(ctx context.Context, key string, value []byte, generation uint64) {
+	if l.currentGeneration() != generation {
+		return
+	}
+	if err := l.cache.Set(ctx, key, value, l.ttl); err != nil {
+		slog.WarnContext(ctx, "could not write to cache", "cache", l.name, "key", key, "error", err)
+		return
+	}
+	if l.currentGeneration() != generation {
+		if err := l.cache.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "could not delete from cache", "cache", l.name, "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type LoaderSuite struct {
+	suite.Suite
+	ctx      context.Context
+	recorder *mocks.MockCacheRecorder
+}
+
+func Test_LoaderSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LoaderSuite))
+}
+
+func (suite *LoaderSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.recorder = mocks.NewMockCacheRecorder(gomock.NewController(suite.T()))
+}
+
+// failingCache simula uma cache fora do ar.
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache down")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("cache down")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("cache down")
+}
+
+// blockingCache segura o Set até release ser fechado, para simular uma cache lenta.
+type blockingCache struct {
+	*cache.LRU
+	setting chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	close(c.setting)
+	<-c.release
+	return c.LRU.Set(ctx, key, value, ttl)
+}
+
+func (suite *LoaderSuite) Test_Load() {
+	suite.T().Run("should load on a miss and serve the next read from the cache", func(t *testing.T) {
+		loader := cache.NewLoader("test", cache.NewLRU(10), time.Minute, suite.recorder)
+		loads := 0
+		load := func(ctx context.Context) ([]byte, error) {
+			loads++
+			return []byte("value"), nil
+		}
+
+		gomock.InOrder(
+			suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss),
+			suite.recorder.EXPECT().CacheLookup("test", metrics.CacheHit),
+		)
+
+		for range 2 {
+			value, err := loader.Load(suite.ctx, "key", load)
+			suite.NoError(err)
+			suite.Equal("value", string(value))
+		}
+		suite.Equal(1, loads)
+	})
+
+	suite.T().Run("should not cache a failed load", func(t *testing.T) {
+		loader := cache.NewLoader("test", cache.NewLRU(10), time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss).Times(2)
+
+		_, err := loader.Load(suite.ctx, "key", func(ctx context.Context) ([]byte, error) {
+			return nil, errors.New("db error")
+		})
+		suite.EqualError(err, "db error")
+
+		value, err := loader.Load(suite.ctx, "key", func(ctx context.Context) ([]byte, error) {
+			return []byte("value"), nil
+		})
+		suite.NoError(err)
+		suite.Equal("value", string(value))
+	})
+
+	suite.T().Run("should fall back to load when the cache fails", func(t *testing.T) {
+		loader := cache.NewLoader("test", failingCache{}, time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss)
+
+		value, err := loader.Load(suite.ctx, "key", func(ctx context.Context) ([]byte, error) {
+			return []byte("value"), nil
+		})
+		suite.NoError(err)
+		suite.Equal("value", string(value))
+	})
+
+	suite.T().Run("should load once for concurrent misses on the same key", func(t *testing.T) {
+		recorder := mocks.NewMockCacheRecorder(gomock.NewController(t))
+		loader := cache.NewLoader("test", cache.NewLRU(10), time.Minute, recorder)
+		recorder.EXPECT().CacheLookup("test", gomock.Any()).Times(20)
+
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func(ctx context.Context) ([]byte, error) {
+			loads.Add(1)
+			<-release
+			return []byte("value"), nil
+		}
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := loader.Load(suite.ctx, "key", load)
+				suite.NoError(err)
+				suite.Equal("value", string(value))
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		suite.Equal(int32(1), loads.Load())
+	})
+
+	suite.T().Run("should keep loading for the others when the first caller gives up", func(t *testing.T) {
+		loader := cache.NewLoader("test", cache.NewLRU(10), time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss).Times(2)
+
+		release := make(chan struct{})
+		load := func(ctx context.Context) ([]byte, error) {
+			<-release
+			return []byte("value"), ctx.Err()
+		}
+
+		ctx, cancel := context.WithCancel(suite.ctx)
+		first := make(chan error)
+		go func() {
+			_, err := loader.Load(ctx, "key", load)
+			first <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		second := make(chan []byte)
+		go func() {
+			value, _ := loader.Load(suite.ctx, "key", load)
+			second <- value
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		cancel()
+		suite.ErrorIs(<-first, context.Canceled)
+		close(release)
+		suite.Equal("value", string(<-second))
+	})
+}
+
+func (suite *LoaderSuite) Test_Invalidate() {
+	suite.T().Run("should load again after an invalidation", func(t *testing.T) {
+		loader := cache.NewLoader("test", cache.NewLRU(10), time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss).Times(2)
+
+		version := "v1"
+		load := func(ctx context.Context) ([]byte, error) { return []byte(version), nil }
+
+		_, err := loader.Load(suite.ctx, "key", load)
+		suite.NoError(err)
+		version = "v2"
+		suite.NoError(loader.Invalidate(suite.ctx, "key"))
+
+		value, err := loader.Load(suite.ctx, "key", load)
+		suite.NoError(err)
+		suite.Equal("v2", string(value))
+	})
+
+	suite.T().Run("should not cache a value read before an invalidation", func(t *testing.T) {
+		lru := cache.NewLRU(10)
+		loader := cache.NewLoader("test", lru, time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss)
+
+		value, err := loader.Load(suite.ctx, "key", func(ctx context.Context) ([]byte, error) {
+			// Uma escrita chega enquanto a leitura está em andamento.
+			suite.NoError(loader.Invalidate(ctx, "key"))
+			return []byte("stale"), nil
+		})
+		suite.NoError(err)
+		suite.Equal("stale", string(value))
+
+		_, ok, _ := lru.Get(suite.ctx, "key")
+		suite.False(ok)
+	})
+
+	suite.T().Run("should not wait for a slow write and drop the value it wrote", func(t *testing.T) {
+		slow := &blockingCache{LRU: cache.NewLRU(10), setting: make(chan struct{}), release: make(chan struct{})}
+		loader := cache.NewLoader("test", slow, time.Minute, suite.recorder)
+		suite.recorder.EXPECT().CacheLookup("test", metrics.CacheMiss)
+
+		loaded := make(chan []byte)
+		go func() {
+			value, _ := loader.Load(suite.ctx, "key", func(ctx context.Context) ([]byte, error) {
+				return []byte("stale"), nil
+			})
+			loaded <- value
+		}()
+		<-slow.setting
+
+		// O Invalidate termina mesmo com o Set ainda preso.
+		suite.NoError(loader.Invalidate(suite.ctx, "key"))
+		close(slow.release)
+		suite.Equal("stale", string(<-loaded))
+
+		_, ok, _ := slow.Get(suite.ctx, "key")
+		suite.False(ok)
+	})
+}
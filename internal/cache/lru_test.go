@@ -0,0 +1,97 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/stretchr/testify/suite"
+)
+
+type LRUSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func Test_LRUSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LRUSuite))
+}
+
+func (suite *LRUSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func (suite *LRUSuite) Test_GetSet() {
+	suite.T().Run("should return what was stored until it is deleted", func(t *testing.T) {
+		lru := cache.NewLRU(10)
+		suite.NoError(lru.Set(suite.ctx, "key", []byte("value"), time.Minute))
+
+		value, ok, err := lru.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.True(ok)
+		suite.Equal("value", string(value))
+
+		suite.NoError(lru.Delete(suite.ctx, "key", "missing"))
+		_, ok, err = lru.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.False(ok)
+	})
+
+	suite.T().Run("should not share the stored bytes with the caller", func(t *testing.T) {
+		lru := cache.NewLRU(10)
+		stored := []byte("value")
+		suite.NoError(lru.Set(suite.ctx, "key", stored, time.Minute))
+		stored[0] = 'X'
+
+		value, _, _ := lru.Get(suite.ctx, "key")
+		value[1] = 'X'
+
+		again, _, _ := lru.Get(suite.ctx, "key")
+		suite.Equal("value", string(again))
+	})
+
+	suite.T().Run("should expire an entry after its ttl", func(t *testing.T) {
+		lru := cache.NewLRU(10)
+		suite.NoError(lru.Set(suite.ctx, "short", []byte("value"), 20*time.Millisecond))
+		suite.NoError(lru.Set(suite.ctx, "long", []byte("value"), time.Minute))
+
+		time.Sleep(40 * time.Millisecond)
+
+		_, ok, _ := lru.Get(suite.ctx, "short")
+		suite.False(ok)
+		_, ok, _ = lru.Get(suite.ctx, "long")
+		suite.True(ok)
+		suite.Equal(1, lru.Len())
+	})
+}
+
+func (suite *LRUSuite) Test_Eviction() {
+	suite.T().Run("should evict the least recently used entry", func(t *testing.T) {
+		lru := cache.NewLRU(2)
+		suite.NoError(lru.Set(suite.ctx, "a", []byte("1"), time.Minute))
+		suite.NoError(lru.Set(suite.ctx, "b", []byte("2"), time.Minute))
+		_, _, _ = lru.Get(suite.ctx, "a")
+
+		suite.NoError(lru.Set(suite.ctx, "c", []byte("3"), time.Minute))
+
+		_, ok, _ := lru.Get(suite.ctx, "b")
+		suite.False(ok)
+		_, ok, _ = lru.Get(suite.ctx, "a")
+		suite.True(ok)
+		_, ok, _ = lru.Get(suite.ctx, "c")
+		suite.True(ok)
+		suite.Equal(2, lru.Len())
+	})
+
+	suite.T().Run("should replace an existing key without growing", func(t *testing.T) {
+		lru := cache.NewLRU(2)
+		suite.NoError(lru.Set(suite.ctx, "a", []byte("1"), time.Minute))
+		suite.NoError(lru.Set(suite.ctx, "a", []byte("2"), time.Minute))
+
+		value, _, _ := lru.Get(suite.ctx, "a")
+		suite.Equal("2", string(value))
+		suite.Equal(1, lru.Len())
+	})
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig descreve a conexão com um servidor que fala o protocolo do Redis, como o próprio
+// Redis, o Valkey ou o KeyDB.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+	// Timeout limita a conexão e cada comando quando o ctx não tem um prazo menor.
+	Timeout  time.Duration
+	PoolSize int
+}
+
+// Redis adapta o cliente go-redis à interface Cache. O pool, a reconexão e o protocolo ficam com
+// a biblioteca.
+type Redis struct {
+	client *redis.Client
+}
+
+func NewRedis(cfg RedisConfig) *Redis {
+	return &Redis{
+		client: redis.NewClient(&redis.Options{
+			Addr:                  cfg.Addr,
+			Password:              cfg.Password,
+			DB:                    cfg.DB,
+			DialTimeout:           cfg.Timeout,
+			ReadTimeout:           cfg.Timeout,
+			WriteTimeout:          cfg.Timeout,
+			ContextTimeoutEnabled: true,
+			PoolSize:              max(cfg.PoolSize, 1),
+			DisableIdentity:       true,
+		}),
+	}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set grava com expiração em milissegundos; um ttl zero deixaria a chave sem expiração.
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, max(ttl, time.Millisecond)).Err()
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
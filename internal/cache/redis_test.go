@@ -0,0 +1,135 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func Test_RedisSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RedisSuite))
+}
+
+func (suite *RedisSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func (suite *RedisSuite) newClient(server *miniredis.Miniredis, password string, db int) *cache.Redis {
+	client := cache.NewRedis(cache.RedisConfig{Addr: server.Addr(), Password: password, DB: db, Timeout: time.Second, PoolSize: 2})
+	suite.T().Cleanup(func() { client.Close() })
+	return client
+}
+
+func (suite *RedisSuite) Test_Commands() {
+	suite.T().Run("should store, read and delete values", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := suite.newClient(server, "", 0)
+
+		_, ok, err := client.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.False(ok)
+
+		suite.NoError(client.Set(suite.ctx, "key", []byte("line 1\r\nline 2"), time.Minute))
+		value, ok, err := client.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.True(ok)
+		suite.Equal("line 1\r\nline 2", string(value))
+
+		suite.NoError(client.Delete(suite.ctx, "key"))
+		_, ok, err = client.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.False(ok)
+	})
+
+	suite.T().Run("should let the server expire the value", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := suite.newClient(server, "", 0)
+
+		suite.NoError(client.Set(suite.ctx, "key", []byte("value"), 20*time.Millisecond))
+		suite.Equal(20*time.Millisecond, server.TTL("key"))
+		server.FastForward(40 * time.Millisecond)
+
+		_, ok, err := client.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.False(ok)
+	})
+
+	suite.T().Run("should reuse pooled connections", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		server.RequireAuth("secret")
+		client := suite.newClient(server, "secret", 0)
+
+		for range 5 {
+			_, _, err := client.Get(suite.ctx, "key")
+			suite.NoError(err)
+		}
+		suite.Equal(1, server.TotalConnectionCount())
+	})
+}
+
+func (suite *RedisSuite) Test_Connection() {
+	suite.T().Run("should authenticate and select the configured database", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		server.RequireAuth("secret")
+		client := suite.newClient(server, "secret", 3)
+
+		suite.NoError(client.Set(suite.ctx, "key", []byte("value"), time.Minute))
+
+		value, err := server.DB(3).Get("key")
+		suite.NoError(err)
+		suite.Equal("value", value)
+		suite.False(server.DB(0).Exists("key"))
+	})
+
+	suite.T().Run("should return the server error for a wrong password", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		server.RequireAuth("secret")
+		client := suite.newClient(server, "wrong", 0)
+
+		_, _, err := client.Get(suite.ctx, "key")
+		suite.ErrorContains(err, "WRONGPASS")
+	})
+
+	suite.T().Run("should reconnect after the server drops the connection", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := suite.newClient(server, "", 0)
+		suite.NoError(client.Set(suite.ctx, "key", []byte("value"), time.Minute))
+
+		server.Close()
+		suite.Require().NoError(server.Restart())
+
+		value, ok, err := client.Get(suite.ctx, "key")
+		suite.NoError(err)
+		suite.True(ok)
+		suite.Equal("value", string(value))
+	})
+
+	suite.T().Run("should fail when the server is down", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := suite.newClient(server, "", 0)
+		server.Close()
+
+		_, _, err := client.Get(suite.ctx, "key")
+		suite.Error(err)
+	})
+
+	suite.T().Run("should give up when the context is canceled", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := suite.newClient(server, "", 0)
+		ctx, cancel := context.WithCancel(suite.ctx)
+		cancel()
+
+		_, _, err := client.Get(ctx, "key")
+		suite.ErrorIs(err, context.Canceled)
+	})
+}
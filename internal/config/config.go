@@ -0,0 +1,278 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+// Config é a configuração efetiva da aplicação. Cada campo pode vir do arquivo YAML (tag yaml)
+// e ser sobrescrito pela variável de ambiente da tag env.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Events   EventsConfig   `yaml:"events"`
+	Cache    CacheConfig    `yaml:"cache"`
+	Features FeaturesConfig `yaml:"features"`
+}
+
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"API_PORT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	DrainDelay        time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// DatabaseConfig descreve a conexão com o Postgres. Quando URL é informada, ela substitui os
+// parâmetros de conexão (host, usuário, TLS); os limites do pool continuam valendo. Uma URL
+// sqlite: ou file: troca o Postgres por um arquivo SQLite (veja SQLiteDSN).
+type DatabaseConfig struct {
+	Driver          string        `yaml:"driver" env:"DB_DRIVER"`
+	URL             string        `yaml:"url" env:"DATABASE_URL"`
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE"`
+	SSLRootCert     string        `yaml:"sslrootcert" env:"DB_SSLROOTCERT"`
+	SSLCert         string        `yaml:"sslcert" env:"DB_SSLCERT"`
+	SSLKey          string        `yaml:"sslkey" env:"DB_SSLKEY"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+	StartupTimeout  time.Duration `yaml:"startup_timeout" env:"DB_STARTUP_TIMEOUT"`
+	StatsInterval   time.Duration `yaml:"stats_interval" env:"DB_STATS_INTERVAL"`
+}
+
+type AuthConfig struct {
+	Issuer              string        `yaml:"issuer" env:"AUTH_ISSUER"`
+	Audience            string        `yaml:"audience" env:"AUTH_AUDIENCE"`
+	JWKS                string        `yaml:"jwks" env:"AUTH_JWKS"`
+	JWKSRefreshInterval time.Duration `yaml:"jwks_refresh_interval" env:"AUTH_JWKS_REFRESH_INTERVAL"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	ServiceName string  `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG"`
+}
+
+type EventsConfig struct {
+	Broker            string        `yaml:"broker" env:"EVENTS_BROKER"`
+	HistorySize       int           `yaml:"history_size" env:"EVENTS_HISTORY_SIZE"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"SSE_HEARTBEAT_INTERVAL"`
+}
+
+// CacheConfig escolhe a cache da lista de vendas disponíveis: memory (LRU por instância), redis
+// (compartilhada entre as instâncias) ou none.
+type CacheConfig struct {
+	Driver        string        `yaml:"driver" env:"CACHE_DRIVER"`
+	TTL           time.Duration `yaml:"ttl" env:"CACHE_TTL"`
+	Size          int           `yaml:"size" env:"CACHE_SIZE"`
+	RedisAddr     string        `yaml:"redis_addr" env:"REDIS_ADDR"`
+	RedisPassword string        `yaml:"redis_password" env:"REDIS_PASSWORD"`
+	RedisDB       int           `yaml:"redis_db" env:"REDIS_DB"`
+	RedisTimeout  time.Duration `yaml:"redis_timeout" env:"REDIS_TIMEOUT"`
+}
+
+// FeaturesConfig liga ou desliga rotas opcionais.
+type FeaturesConfig struct {
+	Swagger     bool `yaml:"swagger" env:"FEATURE_SWAGGER"`
+	Metrics     bool `yaml:"metrics" env:"FEATURE_METRICS"`
+	EventStream bool `yaml:"event_stream" env:"FEATURE_EVENT_STREAM"`
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:              8081,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          DatabaseDriverSQL,
+			Port:            5432,
+			SSLMode:         "disable",
+			ConnectTimeout:  5 * time.Second,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			StartupTimeout:  30 * time.Second,
+			StatsInterval:   time.Minute,
+		},
+		Auth: AuthConfig{
+			JWKSRefreshInterval: 15 * time.Minute,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "showcase-service-fiap",
+			SampleRatio: 1,
+		},
+		Events: EventsConfig{
+			Broker:            EventsBrokerPostgres,
+			HistorySize:       1000,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		Cache: CacheConfig{
+			Driver:       CacheDriverMemory,
+			TTL:          30 * time.Second,
+			Size:         128,
+			RedisTimeout: time.Second,
+		},
+		Features: FeaturesConfig{
+			Swagger:     true,
+			Metrics:     true,
+			EventStream: true,
+		},
+	}
+}
+
+// Load monta a configuração a partir dos valores padrão, do arquivo YAML em path (opcional) e
+// das variáveis de ambiente obtidas por lookup, nessa ordem de precedência crescente. Todos os
+// problemas encontrados são devolvidos juntos.
+func Load(path string, lookup func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	if err := errors.Join(applyEnv(&cfg, lookup), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// DSN devolve a URL, quando informada, ou monta a string de conexão no formato chave=valor
+// aceito pelo pgx.
+func (d DatabaseConfig) DSN() string {
+	if d.URL != "" {
+		return d.URL
+	}
+
+	type param struct{ key, value string }
+	params := []param{
+		{"host", d.Host},
+		{"port", strconv.Itoa(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+	}
+	for _, optional := range []param{{"sslrootcert", d.SSLRootCert}, {"sslcert", d.SSLCert}, {"sslkey", d.SSLKey}} {
+		if optional.value != "" {
+			params = append(params, optional)
+		}
+	}
+	if d.ConnectTimeout > 0 {
+		params = append(params, param{"connect_timeout", strconv.Itoa(int(d.ConnectTimeout.Seconds()))})
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		parts = append(parts, param.key+"="+quoteDSNValue(param.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// SQLiteDSN devolve o DSN do driver SQLite quando URL usa o esquema sqlite: ou file:. Aceita
+// sqlite:showcase.db, sqlite://showcase.db (caminho relativo) e sqlite:///var/lib/showcase.db; uma
+// URI file: segue como veio. O segundo retorno é false para o Postgres.
+func (d DatabaseConfig) SQLiteDSN() (string, bool) {
+	u, err := url.Parse(d.URL)
+	if d.URL == "" || err != nil {
+		return "", false
+	}
+
+	switch u.Scheme {
+	case "file":
+		return d.URL, true
+	case "sqlite":
+		dsn := "file:" + sqlitePath(u)
+		if u.RawQuery != "" {
+			dsn += "?" + u.RawQuery
+		}
+		return dsn, true
+	}
+	return "", false
+}
+
+func sqlitePath(u *url.URL) string {
+	if u.Opaque != "" {
+		return u.Opaque
+	}
+	return u.Host + u.Path
+}
+
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// Redacted devolve uma cópia sem os segredos, para exibição.
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	c.Database.URL = redactURL(c.Database.URL)
+	if c.Cache.RedisPassword != "" {
+		c.Cache.RedisPassword = redacted
+	}
+	return c
+}
+
+// redactURL mascara a senha da URL de conexão. Uma URL que não pode ser interpretada é
+// mascarada inteira.
+func redactURL(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
+
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	EventsBrokerPostgres = "postgres"
+	EventsBrokerMemory   = "memory"
+
+	// DatabaseDriverSQL usa database/sql com o adaptador stdlib do pgx; DatabaseDriverPgx usa o
+	// pool nativo (pgxpool) no repositório de vendas; DatabaseDriverMemory dispensa o Postgres e
+	// guarda tudo em memória.
+	DatabaseDriverSQL    = "sql"
+	DatabaseDriverPgx    = "pgx"
+	DatabaseDriverMemory = "memory"
+
+	CacheDriverNone   = "none"
+	CacheDriverMemory = "memory"
+	CacheDriverRedis  = "redis"
+)
+
+var (
+	sslModes      = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels     = []string{"debug", "info", "warn", "error"}
+	logFormats    = []string{"json", "text"}
+	exporters     = []string{"none", "stdout", "otlp"}
+	eventsBrokers = []string{EventsBrokerPostgres, EventsBrokerMemory}
+	dbDrivers     = []string{DatabaseDriverSQL, DatabaseDriverPgx, DatabaseDriverMemory}
+	cacheDrivers  = []string{CacheDriverNone, CacheDriverMemory, CacheDriverRedis}
+)
+
+// Validate confere a configuração inteira e devolve todos os erros de uma vez.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	oneOf := func(name, value string, allowed []string) {
+		check(slices.Contains(allowed, value), "%s must be one of %v, got %q", name, allowed, value)
+	}
+	nonNegative := func(name string, value time.Duration) {
+		check(value >= 0, "%s must not be negative", name)
+	}
+	readable := func(name, path string) {
+		if path == "" {
+			return
+		}
+		_, err := os.Stat(path)
+		check(err == nil, "%s: %v", name, err)
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "API_PORT must be between 1 and 65535, got %d", c.Server.Port)
+	nonNegative("HTTP_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout)
+	nonNegative("HTTP_READ_TIMEOUT", c.Server.ReadTimeout)
+	nonNegative("HTTP_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	nonNegative("HTTP_IDLE_TIMEOUT", c.Server.IdleTimeout)
+	nonNegative("SHUTDOWN_DRAIN_DELAY", c.Server.DrainDelay)
+	check(c.Server.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be positive")
+
+	oneOf("DB_DRIVER", c.Database.Driver, dbDrivers)
+	if c.Database.Driver == DatabaseDriverMemory {
+		check(c.Events.Broker == EventsBrokerMemory, "DB_DRIVER=memory requires EVENTS_BROKER=memory")
+	} else if c.Database.URL != "" {
+		u, err := url.Parse(c.Database.URL)
+		if err == nil && (u.Scheme == "sqlite" || u.Scheme == "file") {
+			// O SQLite não tem LISTEN/NOTIFY nem o pool nativo do pgx.
+			check(sqlitePath(u) != "", "DATABASE_URL must include the sqlite database path")
+			check(c.Database.Driver == DatabaseDriverSQL, "DB_DRIVER=%s requires a postgres DATABASE_URL", c.Database.Driver)
+			check(c.Events.Broker == EventsBrokerMemory, "a sqlite DATABASE_URL requires EVENTS_BROKER=memory")
+		} else {
+			check(err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql"), "DATABASE_URL must be a postgres://, sqlite: or file: URL")
+		}
+	} else {
+		check(c.Database.Host != "", "DB_HOST is required")
+		check(c.Database.Port > 0 && c.Database.Port <= 65535, "DB_PORT must be between 1 and 65535, got %d", c.Database.Port)
+		check(c.Database.User != "", "DB_USER is required")
+		check(c.Database.Name != "", "DB_NAME is required")
+		oneOf("DB_SSLMODE", c.Database.SSLMode, sslModes)
+		check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""), "DB_SSLCERT and DB_SSLKEY must be set together")
+		readable("DB_SSLROOTCERT", c.Database.SSLRootCert)
+		readable("DB_SSLCERT", c.Database.SSLCert)
+		readable("DB_SSLKEY", c.Database.SSLKey)
+	}
+	nonNegative("DB_CONNECT_TIMEOUT", c.Database.ConnectTimeout)
+	check(c.Database.MaxOpenConns >= 0, "DB_MAX_OPEN_CONNS must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	nonNegative("DB_CONN_MAX_LIFETIME", c.Database.ConnMaxLifetime)
+	nonNegative("DB_CONN_MAX_IDLE_TIME", c.Database.ConnMaxIdleTime)
+	nonNegative("DB_STARTUP_TIMEOUT", c.Database.StartupTimeout)
+	nonNegative("DB_STATS_INTERVAL", c.Database.StatsInterval)
+
+	check(c.Auth.Issuer != "", "AUTH_ISSUER is required")
+	check(c.Auth.JWKS != "", "AUTH_JWKS is required")
+	check(c.Auth.JWKSRefreshInterval > 0, "AUTH_JWKS_REFRESH_INTERVAL must be positive")
+
+	oneOf("LOG_LEVEL", c.Log.Level, logLevels)
+	oneOf("LOG_FORMAT", c.Log.Format, logFormats)
+
+	oneOf("OTEL_TRACES_EXPORTER", c.Tracing.Exporter, exporters)
+	check(c.Tracing.ServiceName != "", "OTEL_SERVICE_NAME must not be empty")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "OTEL_TRACES_SAMPLER_ARG must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	oneOf("EVENTS_BROKER", c.Events.Broker, eventsBrokers)
+	check(c.Events.HistorySize > 0, "EVENTS_HISTORY_SIZE must be positive")
+	check(c.Events.HeartbeatInterval > 0, "SSE_HEARTBEAT_INTERVAL must be positive")
+
+	oneOf("CACHE_DRIVER", c.Cache.Driver, cacheDrivers)
+	if c.Cache.Driver != CacheDriverNone {
+		check(c.Cache.TTL > 0, "CACHE_TTL must be positive")
+	}
+	if c.Cache.Driver == CacheDriverMemory {
+		check(c.Cache.Size > 0, "CACHE_SIZE must be positive")
+	}
+	if c.Cache.Driver == CacheDriverRedis {
+		check(c.Cache.RedisAddr != "", "REDIS_ADDR is required when CACHE_DRIVER=redis")
+		check(c.Cache.RedisDB >= 0, "REDIS_DB must not be negative")
+		check(c.Cache.RedisTimeout > 0, "REDIS_TIMEOUT must be positive")
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+type Config struct {
+	DSN             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// StartupTimeout é o tempo máximo de espera pelo banco na inicialização. Zero tenta uma vez só.
+	StartupTimeout time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Open abre o pool, aplica os limites de conexão e espera o banco responder.
+func Open(ctx context.Context, cfg Config) (*sql.DB, error) {
+	db, err := sql.Open("pgx", cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	Configure(db, cfg)
+
+	if err := WaitReady(ctx, db, cfg); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func Configure(db *sql.DB, cfg Config) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// OpenPool abre um pool nativo do pgx com os mesmos limites e a mesma espera de Open.
+func OpenPool(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxOpenConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.ConnMaxIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := waitReady(ctx, pool.Ping, cfg); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+// sqlitePragmas valem para toda conexão aberta pelo pool: WAL deixa leituras correrem junto com
+// a escrita, busy_timeout espera o lock em vez de falhar e _txlock=immediate pega o lock de
+// escrita já no BEGIN, evitando o SQLITE_BUSY de quem promove uma leitura a escrita.
+const sqlitePragmas = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate"
+
+// OpenSQLite abre um banco SQLite com o driver em Go puro. cfg.DSN é o caminho do arquivo ou uma
+// URI file:, como devolvida por config.DatabaseConfig.SQLiteDSN.
+func OpenSQLite(ctx context.Context, cfg Config) (*sql.DB, error) {
+	separator := "?"
+	if strings.Contains(cfg.DSN, "?") {
+		separator = "&"
+	}
+
+	db, err := sql.Open("sqlite", cfg.DSN+separator+sqlitePragmas)
+	if err != nil {
+		return nil, err
+	}
+	Configure(db, cfg)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// WaitReady faz ping no banco com backoff exponencial até ele responder ou StartupTimeout
+// expirar. Serve para subir junto com o Postgres sem depender da ordem dos containers.
+func WaitReady(ctx context.Context, db *sql.DB, cfg Config) error {
+	return waitReady(ctx, db.PingContext, cfg)
+}
+
+func waitReady(ctx context.Context, ping func(ctx context.Context) error, cfg Config) error {
+	initial, maxBackoff := cfg.InitialBackoff, cfg.MaxBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	deadline := time.Now().Add(cfg.StartupTimeout)
+	backoff := initial
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("database not ready after %d attempts: %w", attempt, err)
+		}
+
+		slog.WarnContext(ctx, "database not ready, retrying", "attempt", attempt, "retry_in", backoff, "error", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return fmt.Errorf("database not ready after %d attempts: %w", attempt, ctx.Err())
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// LogStats registra as estatísticas do pool a cada interval, até ctx ser cancelado.
+func LogStats(ctx context.Context, db *sql.DB, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func() {
+		stats := db.Stats()
+		log.InfoContext(ctx, "database pool stats",
+			"open", stats.OpenConnections,
+			"in_use", stats.InUse,
+			"idle", stats.Idle,
+			"max_open", stats.MaxOpenConnections,
+			"wait_count", stats.WaitCount,
+			"wait_duration", stats.WaitDuration,
+			"max_idle_closed", stats.MaxIdleClosed,
+			"max_lifetime_closed", stats.MaxLifetimeClosed,
+		)
+	})
+}
+
+// LogPoolStats faz o mesmo que LogStats para o pool nativo do pgx.
+func LogPoolStats(ctx context.Context, pool *pgxpool.Pool, interval time.Duration, log *slog.Logger) {
+	every(ctx, interval, func() {
+		stats := pool.Stat()
+		log.InfoContext(ctx, "pgx pool stats",
+			"total", stats.TotalConns(),
+			"acquired", stats.AcquiredConns(),
+			"idle", stats.IdleConns(),
+			"max_conns", stats.MaxConns(),
+			"acquire_count", stats.AcquireCount(),
+			"empty_acquire_count", stats.EmptyAcquireCount(),
+			"acquire_duration", stats.AcquireDuration(),
+			"max_lifetime_destroyed", stats.MaxLifetimeDestroyCount(),
+			"max_idle_destroyed", stats.MaxIdleDestroyCount(),
+		)
+	})
+}
+
+func every(ctx context.Context, interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/database"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseSuite struct {
+	suite.Suite
+}
+
+func Test_DatabaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DatabaseSuite))
+}
+
+func (suite *DatabaseSuite) Test_WaitReady() {
+	cfg := database.Config{
+		StartupTimeout: time.Second,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+
+	suite.T().Run("should retry until the database answers", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		suite.Require().NoError(err)
+		defer db.Close()
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		mock.ExpectPing().WillReturnError(errors.New("the database system is starting up"))
+		mock.ExpectPing()
+
+		err = database.WaitReady(context.Background(), db, cfg)
+
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should give up after the startup timeout", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		suite.Require().NoError(err)
+		defer db.Close()
+		for i := 0; i < 100; i++ {
+			mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		}
+
+		err = database.WaitReady(context.Background(), db, database.Config{
+			StartupTimeout: 20 * time.Millisecond,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+		})
+
+		suite.ErrorContains(err, "database not ready after")
+		suite.ErrorContains(err, "connection refused")
+	})
+
+	suite.T().Run("should try once without a startup timeout", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		suite.Require().NoError(err)
+		defer db.Close()
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+		err = database.WaitReady(context.Background(), db, database.Config{})
+
+		suite.ErrorContains(err, "after 1 attempts")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (suite *DatabaseSuite) Test_LogStats() {
+	suite.T().Run("should log pool stats until cancelled", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		suite.Require().NoError(err)
+		defer db.Close()
+		db.SetMaxOpenConns(7)
+
+		var buf bytes.Buffer
+		log := slog.New(slog.NewJSONHandler(&buf, nil))
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		database.LogStats(ctx, db, 5*time.Millisecond, log)
+
+		suite.Contains(buf.String(), `"msg":"database pool stats"`)
+		suite.Contains(buf.String(), `"max_open":7`)
+	})
+}
+
+func (suite *DatabaseSuite) Test_LogPoolStats() {
+	suite.T().Run("should log pgx pool stats until cancelled", func(t *testing.T) {
+		pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/showcase?pool_max_conns=7")
+		suite.Require().NoError(err)
+		defer pool.Close()
+
+		var buf bytes.Buffer
+		log := slog.New(slog.NewJSONHandler(&buf, nil))
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		database.LogPoolStats(ctx, pool, 5*time.Millisecond, log)
+
+		suite.Contains(buf.String(), `"msg":"pgx pool stats"`)
+		suite.Contains(buf.String(), `"max_conns":7`)
+	})
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	APIKeyScopeCatalogService = "catalog-service"
+	APIKeyScopePaymentGateway = "payment-gateway"
+
+	APIKeyPrefix       = "sk_"
+	apiKeyDisplayChars = 8
+)
+
+var APIKeyScopes = []string{APIKeyScopeCatalogService, APIKeyScopePaymentGateway}
+
+type APIKey struct {
+	ID        string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewAPIKey gera uma nova chave e devolve também o valor em texto puro, que não é guardado
+// e só pode ser mostrado neste momento.
+func NewAPIKey(name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	if name == "" {
+		return nil, "", errors.New("name cannot be empty")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, "", fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", errors.New("expiration must be in the future")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    key[:len(APIKeyPrefix)+apiKeyDisplayChars],
+		KeyHash:   HashAPIKey(key),
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, key, nil
+}
+
+// HashAPIKey é o valor guardado no banco e usado na busca da chave recebida.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
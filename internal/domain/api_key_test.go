@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey_AllScenarios(t *testing.T) {
+	t.Run("should create a new api key successfully", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		key, plain, err := domain.NewAPIKey("catalog", []string{"payment-gateway", "catalog-service", "catalog-service"}, &expiresAt)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, key.ID)
+		assert.Equal(t, "catalog", key.Name)
+		assert.Equal(t, []string{"catalog-service", "payment-gateway"}, key.Scopes)
+		assert.Equal(t, &expiresAt, key.ExpiresAt)
+		assert.True(t, strings.HasPrefix(plain, "sk_"))
+		assert.True(t, strings.HasPrefix(plain, key.Prefix))
+		assert.Len(t, key.Prefix, 11)
+		assert.Equal(t, domain.HashAPIKey(plain), key.KeyHash)
+		assert.NotZero(t, key.CreatedAt)
+	})
+
+	t.Run("should generate a different key each time", func(t *testing.T) {
+		_, first, _ := domain.NewAPIKey("catalog", []string{"catalog-service"}, nil)
+		_, second, _ := domain.NewAPIKey("catalog", []string{"catalog-service"}, nil)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("should return error for empty name", func(t *testing.T) {
+		key, _, err := domain.NewAPIKey("", []string{"catalog-service"}, nil)
+		assert.Nil(t, key)
+		assert.EqualError(t, err, "name cannot be empty")
+	})
+
+	t.Run("should return error without scopes", func(t *testing.T) {
+		_, _, err := domain.NewAPIKey("catalog", nil, nil)
+		assert.EqualError(t, err, "at least one scope is required")
+	})
+
+	t.Run("should return error for unknown scope", func(t *testing.T) {
+		_, _, err := domain.NewAPIKey("catalog", []string{"admin"}, nil)
+		assert.EqualError(t, err, `unknown scope "admin"`)
+	})
+
+	t.Run("should return error for past expiration", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		_, _, err := domain.NewAPIKey("catalog", []string{"catalog-service"}, &past)
+		assert.EqualError(t, err, "expiration must be in the future")
+	})
+}
+
+func TestHashAPIKey_AllScenarios(t *testing.T) {
+	t.Run("should return the hex sha256 of the key", func(t *testing.T) {
+		assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", domain.HashAPIKey("secret"))
+	})
+}
+
+func TestAPIKeyIsActive_AllScenarios(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	t.Run("should be active without expiry or revocation", func(t *testing.T) {
+		assert.True(t, (&domain.APIKey{}).IsActive(now))
+		assert.True(t, (&domain.APIKey{ExpiresAt: &future}).IsActive(now))
+	})
+
+	t.Run("should be inactive when expired or revoked", func(t *testing.T) {
+		assert.False(t, (&domain.APIKey{ExpiresAt: &past}).IsActive(now))
+		assert.False(t, (&domain.APIKey{RevokedAt: &past}).IsActive(now))
+	})
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+func NormalizeCPF(cpf string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, cpf)
+}
+
+func FormatCPF(cpf string) string {
+	digits := NormalizeCPF(cpf)
+	if len(digits) != 11 {
+		return cpf
+	}
+	return digits[0:3] + "." + digits[3:6] + "." + digits[6:9] + "-" + digits[9:11]
+}
+
+func MaskCPF(cpf string) string {
+	digits := NormalizeCPF(cpf)
+
+	if len(digits) != 11 {
+		if cpf == "" {
+			return ""
+		}
+		return "***"
+	}
+
+	return "***." + digits[3:6] + "." + digits[6:9] + "-**"
+}
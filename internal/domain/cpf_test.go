@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskCPF_AllScenarios(t *testing.T) {
+	t.Run("should mask a cpf with only digits", func(t *testing.T) {
+		assert.Equal(t, "***.456.789-**", domain.MaskCPF("12345678900"))
+	})
+
+	t.Run("should mask a formatted cpf", func(t *testing.T) {
+		assert.Equal(t, "***.456.789-**", domain.MaskCPF("123.456.789-00"))
+	})
+
+	t.Run("should fully mask an unexpected value", func(t *testing.T) {
+		assert.Equal(t, "***", domain.MaskCPF("buyer-456"))
+	})
+
+	t.Run("should keep an empty value empty", func(t *testing.T) {
+		assert.Equal(t, "", domain.MaskCPF(""))
+	})
+}
+
+func TestNormalizeCPF_AllScenarios(t *testing.T) {
+	t.Run("should keep only digits", func(t *testing.T) {
+		assert.Equal(t, "12345678900", domain.NormalizeCPF("123.456.789-00"))
+	})
+
+	t.Run("should return empty for a value without digits", func(t *testing.T) {
+		assert.Equal(t, "", domain.NormalizeCPF("abc"))
+	})
+}
+
+func TestFormatCPF_AllScenarios(t *testing.T) {
+	t.Run("should format a cpf with only digits", func(t *testing.T) {
+		assert.Equal(t, "123.456.789-00", domain.FormatCPF("12345678900"))
+	})
+
+	t.Run("should keep an unexpected value as is", func(t *testing.T) {
+		assert.Equal(t, "1234", domain.FormatCPF("1234"))
+	})
+}
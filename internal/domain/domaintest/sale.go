@@ -0,0 +1,99 @@
+// Package domaintest monta entidades do domínio para testes, com valores padrão legíveis.
+package domaintest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+// Epoch é a data de criação padrão das vendas montadas aqui.
+var Epoch = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var saleSeq atomic.Int64
+
+// SaleBuilder monta um domain.Sale. Sem ajustes, é um Toyota Corolla disponível com ID e veículo
+// únicos, criado em Epoch.
+type SaleBuilder struct {
+	sale domain.Sale
+}
+
+func Sale() *SaleBuilder {
+	n := saleSeq.Add(1)
+	return &SaleBuilder{sale: domain.Sale{
+		ID:        fmt.Sprintf("sale-%d", n),
+		VehicleID: fmt.Sprintf("vehicle-%d", n),
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     95000,
+		Status:    domain.StatusAvailable,
+		CreatedAt: Epoch,
+		UpdatedAt: Epoch,
+		Version:   1,
+	}}
+}
+
+func (b *SaleBuilder) ID(id string) *SaleBuilder {
+	b.sale.ID = id
+	return b
+}
+
+func (b *SaleBuilder) Vehicle(vehicleID string) *SaleBuilder {
+	b.sale.VehicleID = vehicleID
+	return b
+}
+
+func (b *SaleBuilder) Car(brand, model string) *SaleBuilder {
+	b.sale.Brand = brand
+	b.sale.Model = model
+	return b
+}
+
+func (b *SaleBuilder) Price(price float64) *SaleBuilder {
+	b.sale.Price = price
+	return b
+}
+
+func (b *SaleBuilder) CreatedAt(at time.Time) *SaleBuilder {
+	b.sale.CreatedAt = at
+	b.sale.UpdatedAt = at
+	return b
+}
+
+// Reserved deixa a venda aguardando o pagamento paymentID do comprador cpf, como depois de uma compra.
+func (b *SaleBuilder) Reserved(cpf, paymentID string, at time.Time) *SaleBuilder {
+	return b.purchased(domain.StatusPendingPayment, cpf, paymentID, at)
+}
+
+func (b *SaleBuilder) Sold(cpf, paymentID string, at time.Time) *SaleBuilder {
+	return b.purchased(domain.StatusSold, cpf, paymentID, at)
+}
+
+func (b *SaleBuilder) Canceled(cpf, paymentID string, at time.Time) *SaleBuilder {
+	return b.purchased(domain.StatusCanceled, cpf, paymentID, at)
+}
+
+func (b *SaleBuilder) purchased(status domain.SaleStatus, cpf, paymentID string, at time.Time) *SaleBuilder {
+	b.sale.Status = status
+	b.sale.BuyerCPF = &cpf
+	b.sale.PaymentID = paymentID
+	b.sale.SaleDate = &at
+	b.sale.UpdatedAt = at
+	return b
+}
+
+// Build devolve uma cópia; o builder pode ser reaproveitado sem que as vendas compartilhem ponteiros.
+func (b *SaleBuilder) Build() *domain.Sale {
+	sale := b.sale
+	if sale.BuyerCPF != nil {
+		cpf := *sale.BuyerCPF
+		sale.BuyerCPF = &cpf
+	}
+	if sale.SaleDate != nil {
+		saleDate := *sale.SaleDate
+		sale.SaleDate = &saleDate
+	}
+	return &sale
+}
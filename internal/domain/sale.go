@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,14 @@ const (
 	StatusCanceled       SaleStatus = "CANCELED"
 )
 
+var SaleStatuses = []SaleStatus{StatusAvailable, StatusPendingPayment, StatusSold, StatusCanceled}
+
+var (
+	ErrEmptyVehicleID    = errors.New("vehicle_id cannot be empty")
+	ErrInvalidPrice      = errors.New("price must be greater than zero")
+	ErrMissingBrandModel = errors.New("brand and model are required for listing")
+)
+
 type Sale struct {
 	ID        string     `json:"id"`
 	VehicleID string     `json:"vehicle_id"`
@@ -28,17 +37,15 @@ type Sale struct {
 	SaleDate  *time.Time `json:"sale_date,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int        `json:"version"`
 }
 
 func NewSale(vehicleID, brand, model string, price float64) (*Sale, error) {
 	if vehicleID == "" {
-		return nil, errors.New("vehicle_id cannot be empty")
+		return nil, ErrEmptyVehicleID
 	}
-	if price <= 0 {
-		return nil, errors.New("price must be greater than zero")
-	}
-	if brand == "" || model == "" {
-		return nil, errors.New("brand and model are required for listing")
+	if err := validateListing(brand, model, price); err != nil {
+		return nil, err
 	}
 
 	return &Sale{
@@ -50,5 +57,46 @@ func NewSale(vehicleID, brand, model string, price float64) (*Sale, error) {
 		Status:    StatusAvailable,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   1,
 	}, nil
 }
+
+// UpdateListing troca os dados do anúncio seguindo as mesmas regras de NewSale.
+func (s *Sale) UpdateListing(brand, model string, price float64, now time.Time) error {
+	if err := validateListing(brand, model, price); err != nil {
+		return err
+	}
+
+	s.Brand = brand
+	s.Model = model
+	s.Price = price
+	s.UpdatedAt = now
+	return nil
+}
+
+func validateListing(brand, model string, price float64) error {
+	if price <= 0 {
+		return ErrInvalidPrice
+	}
+	if brand == "" || model == "" {
+		return ErrMissingBrandModel
+	}
+	return nil
+}
+
+// LogValue permite registrar a venda inteira no log sem expor o CPF do comprador.
+func (s *Sale) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", s.ID),
+		slog.String("vehicle_id", s.VehicleID),
+		slog.String("status", string(s.Status)),
+		slog.Float64("price", s.Price),
+	}
+	if s.PaymentID != "" {
+		attrs = append(attrs, slog.String("payment_id", s.PaymentID))
+	}
+	if s.BuyerCPF != nil {
+		attrs = append(attrs, slog.String("buyer_cpf", MaskCPF(*s.BuyerCPF)))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -1,7 +1,10 @@
 package domain_test
 
 import (
+	"bytes"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -64,3 +67,42 @@ func TestNewSale_AllScenarios(t *testing.T) {
 		assert.Equal(t, "vehicle_id cannot be empty", err.Error())
 	})
 }
+
+func TestSale_UpdateListing(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should replace the listing data and the update time", func(t *testing.T) {
+		sale, _ := domain.NewSale("vehicle-uuid", "Fiat", "Toro", 150000)
+
+		err := sale.UpdateListing("Fiat", "Toro Volcano", 165000, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Toro Volcano", sale.Model)
+		assert.Equal(t, 165000.0, sale.Price)
+		assert.Equal(t, now, sale.UpdatedAt)
+	})
+
+	t.Run("should keep the sale untouched when the new data is invalid", func(t *testing.T) {
+		sale, _ := domain.NewSale("vehicle-uuid", "Fiat", "Toro", 150000)
+		before := *sale
+
+		assert.ErrorIs(t, sale.UpdateListing("", "Toro", 150000, now), domain.ErrMissingBrandModel)
+		assert.ErrorIs(t, sale.UpdateListing("Fiat", "Toro", 0, now), domain.ErrInvalidPrice)
+		assert.Equal(t, before, *sale)
+	})
+}
+
+func TestSaleLogValue_AllScenarios(t *testing.T) {
+	t.Run("should log the sale with a masked buyer cpf", func(t *testing.T) {
+		var buf bytes.Buffer
+		cpf := "12345678900"
+		sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Status: domain.StatusPendingPayment, Price: 100, PaymentID: "payment-1", BuyerCPF: &cpf}
+
+		slog.New(slog.NewTextHandler(&buf, nil)).Info("sale", "sale", sale)
+
+		assert.Contains(t, buf.String(), "sale.id=sale-1")
+		assert.Contains(t, buf.String(), "sale.payment_id=payment-1")
+		assert.Contains(t, buf.String(), "sale.buyer_cpf=***.456.789-**")
+		assert.NotContains(t, buf.String(), cpf)
+	})
+}
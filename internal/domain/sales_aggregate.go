@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type SalesAggregate struct {
+	PeriodStart   *time.Time
+	Brand         string
+	Model         string
+	UnitsSold     int
+	Revenue       float64
+	AvgTimeToSale time.Duration
+	Reservations  int
+}
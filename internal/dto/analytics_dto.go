@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type InputSalesAnalyticsDTO struct {
+	From    time.Time
+	To      time.Time
+	Period  string
+	GroupBy string
+}
+
+type OutputSalesAggregateDTO struct {
+	PeriodStart        *time.Time `json:"period_start,omitempty"`
+	Brand              string     `json:"brand,omitempty"`
+	Model              string     `json:"model,omitempty"`
+	UnitsSold          int        `json:"units_sold"`
+	Revenue            float64    `json:"revenue"`
+	AverageTicket      float64    `json:"average_ticket"`
+	AverageHoursToSale float64    `json:"average_hours_to_sale"`
+	Reservations       int        `json:"reservations"`
+	ConversionRate     float64    `json:"conversion_rate"`
+}
+
+type OutputSalesAnalyticsDTO struct {
+	From    time.Time                  `json:"from"`
+	To      time.Time                  `json:"to"`
+	Period  string                     `json:"period,omitempty"`
+	GroupBy string                     `json:"group_by,omitempty"`
+	Totals  *OutputSalesAggregateDTO   `json:"totals"`
+	Groups  []*OutputSalesAggregateDTO `json:"groups"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// OutputErrorDTO é o corpo das respostas de erro de requisições JSON. Fields lista os campos
+// inválidos quando o erro é de validação.
+type OutputErrorDTO struct {
+	Error  string           `json:"error"`
+	Fields []*FieldErrorDTO `json:"fields,omitempty"`
+}
@@ -10,11 +10,12 @@ type OutputSaleItemDTO struct {
 	Price     float64 `json:"price"`
 }
 
+// Os limites de tamanho e preço seguem as colunas da tabela sales.
 type InputCreateListingDTO struct {
-	VehicleID string  `json:"vehicle_id"`
-	Brand     string  `json:"brand"`
-	Model     string  `json:"model"`
-	Price     float64 `json:"price"`
+	VehicleID string  `json:"vehicle_id" validate:"required,max=36"`
+	Brand     string  `json:"brand" validate:"required,max=100"`
+	Model     string  `json:"model" validate:"required,max=100"`
+	Price     float64 `json:"price" validate:"required,gt=0,max=99999999.99"`
 }
 
 type OutputCreateListingDTO struct {
@@ -24,9 +25,27 @@ type OutputCreateListingDTO struct {
 }
 
 type InputUpdateListingDTO struct {
-	Brand string  `json:"brand"`
-	Model string  `json:"model"`
-	Price float64 `json:"price"`
+	Brand string  `json:"brand" validate:"required,max=100"`
+	Model string  `json:"model" validate:"required,max=100"`
+	Price float64 `json:"price" validate:"required,gt=0,max=99999999.99"`
+}
+
+// InputPatchListingDTO é uma atualização parcial de anúncio: Patch é um JSON Merge Patch
+// (RFC 7386) sobre os campos de InputUpdateListingDTO e IfMatch o header If-Match da requisição.
+type InputPatchListingDTO struct {
+	Patch   []byte
+	IfMatch string
+}
+
+type OutputListingDTO struct {
+	SaleID    string    `json:"sale_id"`
+	VehicleID string    `json:"vehicle_id"`
+	Brand     string    `json:"brand"`
+	Model     string    `json:"model"`
+	Price     float64   `json:"price"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ETag      string    `json:"-"`
 }
 
 type InputPurchaseDTO struct {
@@ -38,6 +57,69 @@ type OutputPurchaseDTO struct {
 }
 
 type InputWebhookDTO struct {
-	PaymentID string `json:"payment_id"`
+	PaymentID string `json:"payment_id" validate:"required,max=36"`
+	Status    string `json:"status" validate:"required,oneof=APPROVED CANCELED EFETUADO CANCELADO"`
+}
+
+const (
+	BulkRowCreated    = "CREATED"
+	BulkRowValid      = "VALID"
+	BulkRowFailed     = "FAILED"
+	BulkRowNotApplied = "NOT_APPLIED"
+)
+
+type InputBulkImportDTO struct {
+	DryRun bool
+	Atomic bool
+}
+
+type OutputBulkImportRowDTO struct {
+	Line      int    `json:"line"`
+	VehicleID string `json:"vehicle_id,omitempty"`
+	SaleID    string `json:"sale_id,omitempty"`
 	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+type OutputBulkImportDTO struct {
+	DryRun  bool                      `json:"dry_run"`
+	Atomic  bool                      `json:"atomic"`
+	Total   int                       `json:"total"`
+	Created int                       `json:"created"`
+	Failed  int                       `json:"failed"`
+	Rows    []*OutputBulkImportRowDTO `json:"rows"`
+}
+
+type InputSalesReportDTO struct {
+	From      time.Time
+	To        time.Time
+	Columns   []string
+	UnmaskCPF bool
+}
+
+type InputBuyerSalesDTO struct {
+	BuyerCPF string `json:"buyer_cpf" validate:"required,cpf"`
+	Page     int    `json:"page" validate:"min=0"`
+	PageSize int    `json:"page_size" validate:"min=0"`
+}
+
+type OutputBuyerSaleItemDTO struct {
+	SaleID    string     `json:"sale_id"`
+	VehicleID string     `json:"vehicle_id"`
+	Brand     string     `json:"brand"`
+	Model     string     `json:"model"`
+	Price     float64    `json:"price"`
+	Status    string     `json:"status"`
+	PaymentID string     `json:"payment_id,omitempty"`
+	BuyerCPF  string     `json:"buyer_cpf"`
+	SaleDate  *time.Time `json:"sale_date,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type OutputBuyerSalesDTO struct {
+	Page     int                       `json:"page"`
+	PageSize int                       `json:"page_size"`
+	Total    int                       `json:"total"`
+	Items    []*OutputBuyerSaleItemDTO `json:"items"`
 }
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+const subscriptionBufferSize = 64
+
+// Broker distribui os eventos para os assinantes deste processo e guarda os mais recentes
+// para que um cliente possa retomar o stream a partir do Last-Event-ID.
+type Broker struct {
+	mu          sync.Mutex
+	history     *ring
+	subscribers map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	Replay []Event
+
+	events chan Event
+	broker *Broker
+}
+
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		history:     newRing(historySize),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *Broker) Publish(_ context.Context, events ...Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		b.history.add(event)
+		for sub := range b.subscribers {
+			select {
+			case sub.events <- event:
+			default:
+				// Assinante lento: é desconectado e retoma depois pelo Last-Event-ID.
+				b.remove(sub)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *Broker) Subscribe(lastEventID string) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{
+		events: make(chan Event, subscriptionBufferSize),
+		broker: b,
+	}
+	if lastEventID != "" {
+		sub.Replay = b.history.since(lastEventID)
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// Events é fechado quando a assinatura é encerrada ou quando o assinante fica para trás.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
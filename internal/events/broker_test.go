@@ -0,0 +1,132 @@
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type BrokerSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *BrokerSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func Test_BrokerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(BrokerSuite))
+}
+
+func event(id string) events.Event {
+	return events.Event{ID: id, Type: events.TypeListingCreated}
+}
+
+func ids(list []events.Event) []string {
+	var result []string
+	for _, e := range list {
+		result = append(result, e.ID)
+	}
+	return result
+}
+
+func (suite *BrokerSuite) Test_PublishSubscribe() {
+	suite.T().Run("should deliver published events to every subscriber", func(t *testing.T) {
+		broker := events.NewBroker(10)
+		first := broker.Subscribe("")
+		second := broker.Subscribe("")
+		defer first.Close()
+		defer second.Close()
+
+		suite.NoError(broker.Publish(suite.ctx, event("1")))
+
+		suite.Equal("1", (<-first.Events()).ID)
+		suite.Equal("1", (<-second.Events()).ID)
+		suite.Empty(first.Replay)
+	})
+
+	suite.T().Run("should stop delivering after close", func(t *testing.T) {
+		broker := events.NewBroker(10)
+		sub := broker.Subscribe("")
+		sub.Close()
+		sub.Close()
+
+		suite.NoError(broker.Publish(suite.ctx, event("1")))
+
+		_, ok := <-sub.Events()
+		suite.False(ok)
+	})
+
+	suite.T().Run("should disconnect a subscriber that falls behind", func(t *testing.T) {
+		broker := events.NewBroker(10)
+		sub := broker.Subscribe("")
+
+		for i := 0; i < 100; i++ {
+			suite.NoError(broker.Publish(suite.ctx, event(fmt.Sprint(i))))
+		}
+
+		received := 0
+		for range sub.Events() {
+			received++
+		}
+		suite.Equal(64, received)
+	})
+}
+
+func (suite *BrokerSuite) Test_Replay() {
+	broker := events.NewBroker(3)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		suite.NoError(broker.Publish(suite.ctx, event(id)))
+	}
+
+	suite.T().Run("should replay events after the last event id", func(t *testing.T) {
+		sub := broker.Subscribe("3")
+		defer sub.Close()
+
+		suite.Equal([]string{"4"}, ids(sub.Replay))
+	})
+
+	suite.T().Run("should replay nothing when the client is up to date", func(t *testing.T) {
+		sub := broker.Subscribe("4")
+		defer sub.Close()
+
+		suite.Empty(sub.Replay)
+	})
+
+	suite.T().Run("should replay the whole history when the id is no longer buffered", func(t *testing.T) {
+		sub := broker.Subscribe("1")
+		defer sub.Close()
+
+		suite.Equal([]string{"2", "3", "4"}, ids(sub.Replay))
+	})
+
+	suite.T().Run("should keep nothing when history is disabled", func(t *testing.T) {
+		broker := events.NewBroker(0)
+		suite.NoError(broker.Publish(suite.ctx, event("1")))
+
+		sub := broker.Subscribe("0")
+		defer sub.Close()
+
+		suite.Empty(sub.Replay)
+	})
+}
+
+func (suite *BrokerSuite) Test_NewSaleEvent() {
+	sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Brand: "Toyota", Model: "Corolla", Price: 50000, Status: domain.StatusSold}
+
+	e := events.NewSaleEvent(events.TypeListingSold, sale)
+	suite.NotEmpty(e.ID)
+	suite.Equal(events.TypeListingSold, e.Type)
+	suite.Equal("sale-1", e.SaleID)
+	suite.Equal("vehicle-1", e.VehicleID)
+	suite.Equal(domain.StatusSold, e.Status)
+	suite.False(e.OccurredAt.IsZero())
+	suite.NotEqual(e.ID, events.NewSaleEvent(events.TypeListingSold, sale).ID)
+}
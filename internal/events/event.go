@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	TypeListingCreated  Type = "listing.created"
+	TypeListingUpdated  Type = "listing.updated"
+	TypeListingReserved Type = "listing.reserved"
+	TypeListingSold     Type = "listing.sold"
+	TypeListingCanceled Type = "listing.canceled"
+)
+
+type Event struct {
+	ID         string            `json:"id"`
+	Type       Type              `json:"type"`
+	SaleID     string            `json:"sale_id"`
+	VehicleID  string            `json:"vehicle_id"`
+	Brand      string            `json:"brand"`
+	Model      string            `json:"model"`
+	Price      float64           `json:"price"`
+	Status     domain.SaleStatus `json:"status"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+func NewSaleEvent(eventType Type, sale *domain.Sale) Event {
+	return Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		SaleID:     sale.ID,
+		VehicleID:  sale.VehicleID,
+		Brand:      sale.Brand,
+		Model:      sale.Model,
+		Price:      sale.Price,
+		Status:     sale.Status,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// Publisher entrega os eventos na ordem recebida. Vários eventos numa chamada saem juntos, como os
+// de uma importação em lote.
+//
+//go:generate mockgen -source=event.go -destination=./mocks/publisher_mock.go -package=mocks
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
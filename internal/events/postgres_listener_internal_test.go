@@ -0,0 +1,113 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeNotificationConn struct {
+	mu            sync.Mutex
+	executed      []string
+	notifications chan *pgconn.Notification
+	closed        bool
+}
+
+func (f *fakeNotificationConn) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.executed = append(f.executed, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeNotificationConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case n, ok := <-f.notifications:
+		if !ok {
+			return nil, errors.New("connection lost")
+		}
+		return n, nil
+	}
+}
+
+func (f *fakeNotificationConn) Close(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+type PostgresListenerSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresListenerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresListenerSuite))
+}
+
+func (suite *PostgresListenerSuite) Test_Run() {
+	suite.T().Run("should listen, forward notifications and reconnect", func(t *testing.T) {
+		broker := NewBroker(10)
+		sub := broker.Subscribe("")
+		defer sub.Close()
+
+		first := &fakeNotificationConn{notifications: make(chan *pgconn.Notification, 2)}
+		second := &fakeNotificationConn{notifications: make(chan *pgconn.Notification, 1)}
+		conns := []*fakeNotificationConn{first, second}
+		attempts := 0
+
+		listener := &PostgresListener{
+			connect: func(ctx context.Context) (notificationConn, error) {
+				attempts++
+				if attempts == 2 {
+					return nil, errors.New("connection refused")
+				}
+				conn := conns[0]
+				conns = conns[1:]
+				return conn, nil
+			},
+			channel:    "sale_events",
+			publisher:  broker,
+			retryDelay: time.Millisecond,
+		}
+
+		payload, _ := json.Marshal(Event{ID: "event-1", Type: TypeListingSold})
+		first.notifications <- &pgconn.Notification{Payload: "not json"}
+		first.notifications <- &pgconn.Notification{Payload: string(payload)}
+		close(first.notifications)
+
+		payload, _ = json.Marshal(Event{ID: "event-2", Type: TypeListingCanceled})
+		second.notifications <- &pgconn.Notification{Payload: string(payload)}
+
+		suite.ErrorIs(listener.Check(context.Background()), ErrListenerNotListening)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			listener.Run(ctx)
+			close(done)
+		}()
+
+		suite.Equal("event-1", (<-sub.Events()).ID)
+		suite.Equal("event-2", (<-sub.Events()).ID)
+		suite.NoError(listener.Check(context.Background()))
+
+		cancel()
+		<-done
+		suite.ErrorIs(listener.Check(context.Background()), ErrListenerNotListening)
+
+		suite.Equal([]string{`LISTEN "sale_events"`}, first.executed)
+		suite.True(first.closed)
+		suite.True(second.closed)
+		suite.Equal(3, attempts)
+	})
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const DefaultNotifyChannel = "sale_events"
+
+// notifyBatchSize limita os eventos de um único comando, bem abaixo do limite de parâmetros do
+// Postgres.
+const notifyBatchSize = 1000
+
+type postgresPublisher struct {
+	db      *sql.DB
+	channel string
+}
+
+// NewPostgresPublisher publica os eventos com NOTIFY para que todas as instâncias do serviço,
+// inclusive a que publicou, os recebam pelo PostgresListener.
+func NewPostgresPublisher(db *sql.DB, channel string) Publisher {
+	return &postgresPublisher{
+		db:      db,
+		channel: channel,
+	}
+}
+
+// Publish manda cada lote de eventos num único comando, em vez de um NOTIFY por evento.
+func (p *postgresPublisher) Publish(ctx context.Context, events ...Event) error {
+	for len(events) > 0 {
+		n := min(len(events), notifyBatchSize)
+		if err := p.notify(ctx, events[:n]); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+func (p *postgresPublisher) notify(ctx context.Context, events []Event) error {
+	args := make([]any, 0, len(events)+1)
+	args = append(args, p.channel)
+	placeholders := make([]string, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		args = append(args, string(payload))
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	if len(events) == 1 {
+		_, err := p.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", args...)
+		return err
+	}
+	// unnest mantém a ordem do array, e o NOTIFY entrega na ordem em que foi chamado.
+	query := "SELECT pg_notify($1, payload) FROM unnest(ARRAY[" + strings.Join(placeholders, ", ") + "]::text[]) AS payload"
+	_, err := p.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+var ErrListenerNotListening = errors.New("event listener is not listening")
+
+type notificationConn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+type PostgresListener struct {
+	connect    func(ctx context.Context) (notificationConn, error)
+	channel    string
+	publisher  Publisher
+	retryDelay time.Duration
+	listening  atomic.Bool
+}
+
+func NewPostgresListener(dsn, channel string, publisher Publisher) *PostgresListener {
+	return &PostgresListener{
+		connect: func(ctx context.Context) (notificationConn, error) {
+			return pgx.Connect(ctx, dsn)
+		},
+		channel:    channel,
+		publisher:  publisher,
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// Run escuta o canal até ctx ser cancelado, reconectando quando a conexão cai.
+func (l *PostgresListener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(ctx, "event listener disconnected", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.retryDelay):
+		}
+	}
+}
+
+// Check informa se o listener está conectado e escutando o canal; usado na prontidão.
+func (l *PostgresListener) Check(context.Context) error {
+	if !l.listening.Load() {
+		return ErrListenerNotListening
+	}
+	return nil
+}
+
+func (l *PostgresListener) listen(ctx context.Context) error {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return err
+	}
+	l.listening.Store(true)
+	defer l.listening.Store(false)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			slog.WarnContext(ctx, "discarding invalid event notification", "error", err)
+			continue
+		}
+
+		if err := l.publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresPublisherSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresPublisherSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresPublisherSuite))
+}
+
+func (suite *PostgresPublisherSuite) Test_Publish() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	publisher := events.NewPostgresPublisher(db, events.DefaultNotifyChannel)
+	e := event("event-1")
+	payload, _ := json.Marshal(e)
+
+	suite.T().Run("should notify the channel with the event as json", func(t *testing.T) {
+		mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+			WithArgs("sale_events", string(payload)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := publisher.Publish(context.Background(), e)
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should notify every event of a batch in one statement", func(t *testing.T) {
+		other := event("event-2")
+		otherPayload, _ := json.Marshal(other)
+		mock.ExpectExec(`SELECT pg_notify\(\$1, payload\) FROM unnest\(ARRAY\[\$2, \$3\]::text\[\]\) AS payload`).
+			WithArgs("sale_events", string(payload), string(otherPayload)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := publisher.Publish(context.Background(), e, other)
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectExec(`SELECT pg_notify`).WillReturnError(errors.New("db error"))
+
+		err := publisher.Publish(context.Background(), e)
+		suite.EqualError(err, "db error")
+	})
+}
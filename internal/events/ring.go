@@ -0,0 +1,50 @@
+package events
+
+type ring struct {
+	events []Event
+	start  int
+	size   int
+}
+
+func newRing(capacity int) *ring {
+	return &ring{
+		events: make([]Event, capacity),
+	}
+}
+
+func (r *ring) add(event Event) {
+	if len(r.events) == 0 {
+		return
+	}
+
+	if r.size < len(r.events) {
+		r.events[(r.start+r.size)%len(r.events)] = event
+		r.size++
+		return
+	}
+
+	r.events[r.start] = event
+	r.start = (r.start + 1) % len(r.events)
+}
+
+// since devolve os eventos posteriores a lastID. Quando lastID não está mais no buffer,
+// devolve tudo o que ainda está guardado, pois não há como saber o que foi perdido.
+func (r *ring) since(lastID string) []Event {
+	from := 0
+	for i := r.size - 1; i >= 0; i-- {
+		if r.at(i).ID == lastID {
+			from = i + 1
+			break
+		}
+	}
+
+	events := make([]Event, 0, r.size-from)
+	for i := from; i < r.size; i++ {
+		events = append(events, r.at(i))
+	}
+	return events
+}
+
+func (r *ring) at(i int) Event {
+	return r.events[(r.start+i)%len(r.events)]
+}
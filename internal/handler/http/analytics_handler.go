@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+)
+
+type AnalyticsHandler struct {
+	useCase usecase.AnalyticsUseCaseInterface
+}
+
+func NewAnalyticsHandler(useCase usecase.AnalyticsUseCaseInterface) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		useCase: useCase,
+	}
+}
+
+// SalesAnalytics lida com a requisição de indicadores agregados de vendas.
+// @Summary      Sales analytics
+// @Description  Returns units sold, revenue, average ticket, average time from listing to sale and conversion from reservation to sale for sales dated within [from, to], optionally broken down by period and by brand or brand/model.
+// @Tags         Analytics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from      query     string  true   "First sale date (YYYY-MM-DD)"
+// @Param        to        query     string  true   "Last sale date, inclusive (YYYY-MM-DD)"
+// @Param        period    query     string  false  "Time bucket"  Enums(day, week, month)
+// @Param        group_by  query     string  false  "Breakdown"    Enums(brand, model)
+// @Success      200       {object}  dto.OutputSalesAnalyticsDTO
+// @Failure      400       {string}  string "Invalid query parameters"
+// @Failure      401       {string}  string "Unauthorized"
+// @Failure      403       {string}  string "Forbidden"
+// @Failure      500       {string}  string "Internal server error"
+// @Router       /analytics/sales [get]
+func (h *AnalyticsHandler) SalesAnalytics(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input := dto.InputSalesAnalyticsDTO{
+		From:    from,
+		To:      to,
+		Period:  r.URL.Query().Get("period"),
+		GroupBy: r.URL.Query().Get("group_by"),
+	}
+
+	switch input.Period {
+	case "", repository.AnalyticsPeriodDay, repository.AnalyticsPeriodWeek, repository.AnalyticsPeriodMonth:
+	default:
+		http.Error(w, "Invalid period parameter, expected day, week or month", http.StatusBadRequest)
+		return
+	}
+
+	switch input.GroupBy {
+	case "", repository.AnalyticsGroupByBrand, repository.AnalyticsGroupByModel:
+	default:
+		http.Error(w, "Invalid group_by parameter, expected brand or model", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.useCase.SalesAnalytics(r.Context(), input)
+	if err != nil {
+		serverError(w, r, err, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(output)
+}
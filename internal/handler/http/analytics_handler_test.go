@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AnalyticsHandlerSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	useCase *mocks.MockAnalyticsUseCaseInterface
+	handler *h.AnalyticsHandler
+}
+
+func (suite *AnalyticsHandlerSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.ctx = context.Background()
+	suite.useCase = mocks.NewMockAnalyticsUseCaseInterface(ctrl)
+	suite.handler = h.NewAnalyticsHandler(suite.useCase)
+}
+
+func Test_AnalyticsHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AnalyticsHandlerSuite))
+}
+
+func (suite *AnalyticsHandlerSuite) Test_SalesAnalytics() {
+	input := dto.InputSalesAnalyticsDTO{
+		From:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Period:  "week",
+		GroupBy: "model",
+	}
+
+	suite.T().Run("Sales Analytics - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputSalesAnalyticsDTO{
+			From:   input.From,
+			To:     input.To,
+			Totals: &dto.OutputSalesAggregateDTO{UnitsSold: 2, Revenue: 100000},
+			Groups: []*dto.OutputSalesAggregateDTO{{Brand: "Toyota", Model: "Corolla", UnitsSold: 2}},
+		}
+		suite.useCase.EXPECT().SalesAnalytics(suite.ctx, input).Return(expectedOutput, nil)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/analytics/sales?from=2026-03-01&to=2026-03-31&period=week&group_by=model", nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.SalesAnalytics(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("application/json", rr.Header().Get("Content-Type"))
+
+		var resp dto.OutputSalesAnalyticsDTO
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		suite.NoError(err)
+		suite.Equal(2, resp.Totals.UnitsSold)
+		suite.Equal("Corolla", resp.Groups[0].Model)
+	})
+
+	suite.T().Run("Sales Analytics - Invalid Query", func(t *testing.T) {
+		for _, query := range []string{
+			"from=2026-03-01",
+			"from=01/03/2026&to=2026-03-31",
+			"from=2026-03-01&to=2026-03-31&period=year",
+			"from=2026-03-01&to=2026-03-31&group_by=color",
+		} {
+			req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/analytics/sales?"+query, nil)
+			rr := httptest.NewRecorder()
+
+			suite.handler.SalesAnalytics(rr, req)
+
+			suite.Equal(http.StatusBadRequest, rr.Code, query)
+		}
+	})
+
+	suite.T().Run("Sales Analytics - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().SalesAnalytics(suite.ctx, input).Return(nil, errors.New("usecase error"))
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/analytics/sales?from=2026-03-01&to=2026-03-31&period=week&group_by=model", nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.SalesAnalytics(rr, req)
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+		suite.Contains(rr.Body.String(), "usecase error")
+	})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// AuthenticateAPIKey aceita uma chave de API no header X-API-Key no lugar do bearer token.
+// Os escopos da chave viram os papéis do Principal. Sem o header, a requisição segue para
+// a validação do JWT.
+func AuthenticateAPIKey(apiKeys usecase.APIKeyUseCaseInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(apiKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, err := apiKeys.Authenticate(r.Context(), key)
+			if err != nil {
+				if errors.Is(err, usecase.ErrInvalidAPIKey) {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				slog.ErrorContext(r.Context(), "could not check API key", "error", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			slog.InfoContext(r.Context(), "request authenticated with API key", "client", client.Name, "key_prefix", client.Prefix)
+
+			principal := &auth.Principal{
+				Subject: "api-key:" + client.ID,
+				Roles:   make([]auth.Role, 0, len(client.Scopes)),
+			}
+			for _, scope := range client.Scopes {
+				principal.Roles = append(principal.Roles, auth.Role(scope))
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// Authenticate valida o bearer token da requisição e coloca o Principal no contexto. Requisições
+// já autenticadas por chave de API passam direto.
+func Authenticate(verifier auth.TokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.PrincipalFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			principal, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireRole libera a rota apenas para quem tem ao menos um dos papéis informados.
+func RequireRole(roles ...auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !principal.HasAnyRole(roles...) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	usecaseMocks "github.com/NicolasNSC/showcase-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AuthMiddlewareSuite struct {
+	suite.Suite
+
+	verifier  *mocks.MockTokenVerifier
+	apiKeys   *usecaseMocks.MockAPIKeyUseCaseInterface
+	principal *auth.Principal
+	next      http.Handler
+}
+
+func (suite *AuthMiddlewareSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.verifier = mocks.NewMockTokenVerifier(ctrl)
+	suite.apiKeys = usecaseMocks.NewMockAPIKeyUseCaseInterface(ctrl)
+	suite.principal = nil
+	suite.next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.principal, _ = auth.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func Test_AuthMiddlewareSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AuthMiddlewareSuite))
+}
+
+func (suite *AuthMiddlewareSuite) Test_AuthenticateAPIKey() {
+	suite.T().Run("Authenticate API Key - Valid Key", func(t *testing.T) {
+		suite.apiKeys.EXPECT().Authenticate(gomock.Any(), "sk_valid").Return(&dto.OutputAPIKeyDTO{
+			ID:     "key-1",
+			Name:   "catalog",
+			Prefix: "sk_valid",
+			Scopes: []string{"catalog-service"},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("X-API-Key", "sk_valid")
+		rr := httptest.NewRecorder()
+
+		h.AuthenticateAPIKey(suite.apiKeys)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("api-key:key-1", suite.principal.Subject)
+		suite.Equal([]auth.Role{auth.RoleCatalogService}, suite.principal.Roles)
+	})
+
+	suite.T().Run("Authenticate API Key - Invalid Key", func(t *testing.T) {
+		suite.apiKeys.EXPECT().Authenticate(gomock.Any(), "sk_revoked").Return(nil, usecase.ErrInvalidAPIKey)
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("X-API-Key", "sk_revoked")
+		rr := httptest.NewRecorder()
+
+		h.AuthenticateAPIKey(suite.apiKeys)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusUnauthorized, rr.Code)
+	})
+
+	suite.T().Run("Authenticate API Key - Lookup Error", func(t *testing.T) {
+		suite.apiKeys.EXPECT().Authenticate(gomock.Any(), "sk_valid").Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("X-API-Key", "sk_valid")
+		rr := httptest.NewRecorder()
+
+		h.AuthenticateAPIKey(suite.apiKeys)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+		suite.NotContains(rr.Body.String(), "db error")
+	})
+
+	suite.T().Run("Authenticate API Key - No Header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		rr := httptest.NewRecorder()
+
+		h.AuthenticateAPIKey(suite.apiKeys)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Nil(suite.principal)
+	})
+}
+
+func (suite *AuthMiddlewareSuite) Test_Authenticate() {
+	suite.T().Run("Authenticate - Valid Token", func(t *testing.T) {
+		principal := &auth.Principal{Subject: "catalog", Roles: []auth.Role{auth.RoleCatalogService}}
+		suite.verifier.EXPECT().Verify(gomock.Any(), "valid-token").Return(principal, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		rr := httptest.NewRecorder()
+
+		h.Authenticate(suite.verifier)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Same(principal, suite.principal)
+	})
+
+	suite.T().Run("Authenticate - Already Authenticated", func(t *testing.T) {
+		principal := &auth.Principal{Subject: "api-key:key-1"}
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), principal))
+		rr := httptest.NewRecorder()
+
+		h.Authenticate(suite.verifier)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Same(principal, suite.principal)
+	})
+
+	suite.T().Run("Authenticate - Invalid Token", func(t *testing.T) {
+		suite.verifier.EXPECT().Verify(gomock.Any(), "bad-token").Return(nil, auth.ErrInvalidToken)
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("Authorization", "Bearer bad-token")
+		rr := httptest.NewRecorder()
+
+		h.Authenticate(suite.verifier)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusUnauthorized, rr.Code)
+		suite.Equal(`Bearer error="invalid_token"`, rr.Header().Get("WWW-Authenticate"))
+	})
+
+	suite.T().Run("Authenticate - Missing Header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		rr := httptest.NewRecorder()
+
+		h.Authenticate(suite.verifier)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusUnauthorized, rr.Code)
+		suite.Equal("Bearer", rr.Header().Get("WWW-Authenticate"))
+	})
+
+	suite.T().Run("Authenticate - Other Scheme", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		rr := httptest.NewRecorder()
+
+		h.Authenticate(suite.verifier)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func (suite *AuthMiddlewareSuite) Test_RequireRole() {
+	suite.T().Run("Require Role - Allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Roles: []auth.Role{auth.RoleAdmin}}))
+		rr := httptest.NewRecorder()
+
+		h.RequireRole(auth.RoleCatalogService, auth.RoleAdmin)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+	})
+
+	suite.T().Run("Require Role - Missing Role", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Roles: []auth.Role{auth.RoleBuyer}}))
+		rr := httptest.NewRecorder()
+
+		h.RequireRole(auth.RoleAdmin)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusForbidden, rr.Code)
+	})
+
+	suite.T().Run("Require Role - Not Authenticated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales", nil)
+		rr := httptest.NewRecorder()
+
+		h.RequireRole(auth.RoleAdmin)(suite.next).ServeHTTP(rr, req)
+
+		suite.Equal(http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func (suite *AuthMiddlewareSuite) Test_Routes() {
+	router := newTestRouter(suite.verifier, suite.apiKeys)
+
+	suite.verifier.EXPECT().Verify(gomock.Any(), "buyer-token").
+		Return(&auth.Principal{Roles: []auth.Role{auth.RoleBuyer}}, nil).AnyTimes()
+	suite.verifier.EXPECT().Verify(gomock.Any(), "broken-token").
+		Return(nil, errors.New("broken")).AnyTimes()
+	suite.apiKeys.EXPECT().Authenticate(gomock.Any(), "sk_payments").
+		Return(&dto.OutputAPIKeyDTO{ID: "key-1", Name: "payments", Scopes: []string{"payment-gateway"}}, nil).AnyTimes()
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"Routes - Catalog Endpoint Without Token", http.MethodPost, "/listings", "", http.StatusUnauthorized},
+		{"Routes - Catalog Endpoint With Invalid Token", http.MethodPost, "/listings", "broken-token", http.StatusUnauthorized},
+		{"Routes - Catalog Endpoint As Buyer", http.MethodPost, "/listings", "buyer-token", http.StatusForbidden},
+		{"Routes - Webhook As Buyer", http.MethodPost, "/webhooks/payments", "buyer-token", http.StatusForbidden},
+		{"Routes - Report As Buyer", http.MethodGet, "/reports/sales", "buyer-token", http.StatusForbidden},
+		{"Routes - Analytics As Buyer", http.MethodGet, "/analytics/sales", "buyer-token", http.StatusForbidden},
+		{"Routes - Buyer History As Buyer", http.MethodPost, "/buyers/purchases", "buyer-token", http.StatusForbidden},
+		{"Routes - Purchase Without Token", http.MethodPost, "/sales/sale-1/purchase", "", http.StatusUnauthorized},
+		{"Routes - Catalog Endpoint With Payments API Key", http.MethodPost, "/listings", "sk_payments", http.StatusForbidden},
+		{"Routes - Liveness Without Token", http.MethodGet, "/healthz", "", http.StatusOK},
+		{"Routes - Readiness Without Token", http.MethodGet, "/readyz", "", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		suite.T().Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, c.path, nil)
+			if strings.HasPrefix(c.token, "sk_") {
+				req.Header.Set("X-API-Key", c.token)
+			} else if c.token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			suite.Equal(c.status, rr.Code)
+		})
+	}
+}
+
+func (suite *AuthMiddlewareSuite) Test_DisabledFeatures() {
+	router := newTestRouterWithFeatures(suite.verifier, suite.apiKeys, h.Features{})
+
+	for _, path := range []string{"/metrics", "/swagger/index.html", "/sales/stream"} {
+		suite.T().Run("Features - Disabled "+path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+			suite.Equal(http.StatusNotFound, rr.Code)
+		})
+	}
+
+	suite.T().Run("Features - Core Routes Still Served", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		suite.Equal(http.StatusOK, rr.Code)
+	})
+}
+
+func newTestRouter(verifier auth.TokenVerifier, apiKeys usecase.APIKeyUseCaseInterface) http.Handler {
+	return newTestRouterWithFeatures(verifier, apiKeys, h.Features{Swagger: true, Metrics: true, EventStream: true})
+}
+
+func newTestRouterWithFeatures(verifier auth.TokenVerifier, apiKeys usecase.APIKeyUseCaseInterface, features h.Features) http.Handler {
+	router := chi.NewRouter()
+	h.SetupRoutes(router, features, h.Handlers{
+		Sale:      h.NewSaleHandler(nil),
+		Analytics: h.NewAnalyticsHandler(nil),
+		Stream:    h.NewSaleStreamHandler(events.NewBroker(1), time.Second),
+		Health:    h.NewHealthHandler(health.NewReadiness(time.Second)),
+	}, verifier, apiKeys, metrics.New())
+	return router
+}
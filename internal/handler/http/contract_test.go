@@ -0,0 +1,248 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// Os payloads em testdata/contracts são exemplos do que os outros times enviam hoje. Eles não
+// devem ser editados para acompanhar uma mudança nos DTOs: se um deles quebrar, a mudança quebra
+// quem produz esse payload.
+const (
+	contractsDir = "testdata/contracts"
+	swaggerFile  = "../../../docs/swagger.json"
+)
+
+// payloadContract liga um payload de exemplo à rota que o recebe, ao schema do swagger e ao DTO.
+type payloadContract struct {
+	file       string
+	method     string
+	route      string
+	definition string
+	newInput   func() any
+	// expect registra no mock a chamada esperada com o DTO decodificado e devolve o status da resposta.
+	expect func(useCase *mocks.MockSaleUseCaseInterface, input any) int
+}
+
+var payloadContracts = []payloadContract{
+	{
+		file:       "catalog-service/create_listing.json",
+		method:     http.MethodPost,
+		route:      "/listings",
+		definition: "dto.InputCreateListingDTO",
+		newInput:   func() any { return &dto.InputCreateListingDTO{} },
+		expect: func(useCase *mocks.MockSaleUseCaseInterface, input any) int {
+			useCase.EXPECT().CreateListing(gomock.Any(), input).Return(&dto.OutputCreateListingDTO{SaleID: "sale-id"}, nil)
+			return http.StatusCreated
+		},
+	},
+	{
+		file:       "catalog-service/update_listing.json",
+		method:     http.MethodPut,
+		route:      "/listings/vehicle/{vehicle_id}",
+		definition: "dto.InputUpdateListingDTO",
+		newInput:   func() any { return &dto.InputUpdateListingDTO{} },
+		expect: func(useCase *mocks.MockSaleUseCaseInterface, input any) int {
+			useCase.EXPECT().UpdateListing(gomock.Any(), "vehicle-id", input).Return(nil)
+			return http.StatusOK
+		},
+	},
+	{
+		file:       "payment-gateway/webhook_approved.json",
+		method:     http.MethodPost,
+		route:      "/webhooks/payments",
+		definition: "dto.InputWebhookDTO",
+		newInput:   func() any { return &dto.InputWebhookDTO{} },
+		expect: func(useCase *mocks.MockSaleUseCaseInterface, input any) int {
+			useCase.EXPECT().HandlePaymentWebhook(gomock.Any(), input).Return(nil)
+			return http.StatusNoContent
+		},
+	},
+	{
+		file:       "payment-gateway/webhook_canceled.json",
+		method:     http.MethodPost,
+		route:      "/webhooks/payments",
+		definition: "dto.InputWebhookDTO",
+		newInput:   func() any { return &dto.InputWebhookDTO{} },
+		expect: func(useCase *mocks.MockSaleUseCaseInterface, input any) int {
+			useCase.EXPECT().HandlePaymentWebhook(gomock.Any(), input).Return(nil)
+			return http.StatusNoContent
+		},
+	},
+}
+
+type swaggerSchema struct {
+	Type       string                    `json:"type"`
+	Ref        string                    `json:"$ref"`
+	Properties map[string]*swaggerSchema `json:"properties"`
+	Required   []string                  `json:"required"`
+}
+
+type swaggerDocument struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			In     string        `json:"in"`
+			Schema swaggerSchema `json:"schema"`
+		} `json:"parameters"`
+	} `json:"paths"`
+	Definitions map[string]*swaggerSchema `json:"definitions"`
+}
+
+type ContractSuite struct {
+	suite.Suite
+
+	swagger swaggerDocument
+	useCase *mocks.MockSaleUseCaseInterface
+	router  *chi.Mux
+}
+
+func (suite *ContractSuite) SetupSuite() {
+	data, err := os.ReadFile(swaggerFile)
+	suite.Require().NoError(err)
+	suite.Require().NoError(json.Unmarshal(data, &suite.swagger))
+}
+
+func (suite *ContractSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.useCase = mocks.NewMockSaleUseCaseInterface(ctrl)
+	handler := h.NewSaleHandler(suite.useCase)
+
+	// As rotas sem autenticação: o contrato é o corpo da requisição, não as credenciais.
+	suite.router = chi.NewRouter()
+	suite.router.Post("/listings", handler.CreateListing)
+	suite.router.Put("/listings/vehicle/{vehicle_id}", handler.UpdateListing)
+	suite.router.Post("/webhooks/payments", handler.HandlePaymentWebhook)
+}
+
+func Test_ContractSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ContractSuite))
+}
+
+func (suite *ContractSuite) Test_EveryPayloadHasAContract() {
+	files, err := filepath.Glob(filepath.Join(contractsDir, "*", "*.json"))
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(files)
+
+	known := make(map[string]bool, len(payloadContracts))
+	for _, contract := range payloadContracts {
+		known[contract.file] = true
+	}
+	for _, file := range files {
+		rel, err := filepath.Rel(contractsDir, file)
+		suite.Require().NoError(err)
+		suite.True(known[filepath.ToSlash(rel)], "%s is not listed in payloadContracts", rel)
+	}
+}
+
+func (suite *ContractSuite) Test_Payloads() {
+	for _, contract := range payloadContracts {
+		payload, err := os.ReadFile(filepath.Join(contractsDir, contract.file))
+		suite.Require().NoError(err)
+
+		var fields map[string]any
+		suite.Require().NoError(json.Unmarshal(payload, &fields), contract.file)
+
+		suite.T().Run(contract.file+" should decode into "+contract.definition+" without losing fields", func(t *testing.T) {
+			input := contract.newInput()
+			decoder := json.NewDecoder(bytes.NewReader(payload))
+			decoder.DisallowUnknownFields()
+			suite.Require().NoError(decoder.Decode(input), "a field was removed, renamed or changed type")
+
+			encoded, err := json.Marshal(input)
+			suite.Require().NoError(err)
+			var roundTrip map[string]any
+			suite.Require().NoError(json.Unmarshal(encoded, &roundTrip))
+			for name, value := range fields {
+				suite.Equal(value, roundTrip[name], "field %q did not survive decoding", name)
+			}
+		})
+
+		suite.T().Run(contract.file+" should match the swagger schema of "+contract.method+" "+contract.route, func(t *testing.T) {
+			operation, ok := suite.swagger.Paths[contract.route][strings.ToLower(contract.method)]
+			suite.Require().True(ok, "route is not documented")
+
+			var body *swaggerSchema
+			for _, parameter := range operation.Parameters {
+				if parameter.In == "body" {
+					body = &parameter.Schema
+				}
+			}
+			suite.Require().NotNil(body, "route has no body parameter")
+			suite.Equal("#/definitions/"+contract.definition, body.Ref)
+
+			definition, ok := suite.swagger.Definitions[contract.definition]
+			suite.Require().True(ok, "definition is missing")
+			for _, name := range definition.Required {
+				suite.Contains(fields, name, "required field %q is not sent by the producer", name)
+			}
+			for name, value := range fields {
+				property, ok := definition.Properties[name]
+				if suite.True(ok, "field %q is not in the schema", name) {
+					suite.NoError(matchesSchemaType(property.Type, value), "field %q", name)
+				}
+			}
+		})
+
+		suite.T().Run(contract.file+" should be accepted by the handler", func(t *testing.T) {
+			input := contract.newInput()
+			suite.Require().NoError(json.Unmarshal(payload, input))
+			status := contract.expect(suite.useCase, input)
+
+			path := strings.Replace(contract.route, "{vehicle_id}", "vehicle-id", 1)
+			req := httptest.NewRequestWithContext(context.Background(), contract.method, path, bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			suite.router.ServeHTTP(rr, req)
+
+			suite.Equal(status, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+// matchesSchemaType confere o valor decodificado de um campo com o tipo do schema no swagger.
+func matchesSchemaType(schemaType string, value any) error {
+	var valueType string
+	switch v := value.(type) {
+	case string:
+		valueType = "string"
+	case bool:
+		valueType = "boolean"
+	case float64:
+		valueType = "number"
+		if schemaType == "integer" && v == math.Trunc(v) {
+			valueType = "integer"
+		}
+	case []any:
+		valueType = "array"
+	case map[string]any:
+		valueType = "object"
+	case nil:
+		return nil
+	default:
+		valueType = reflect.TypeOf(value).String()
+	}
+
+	if valueType != schemaType {
+		return fmt.Errorf("payload has %s, schema has %s", valueType, schemaType)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+)
+
+type HealthHandler struct {
+	readiness *health.Readiness
+}
+
+func NewHealthHandler(readiness *health.Readiness) *HealthHandler {
+	return &HealthHandler{
+		readiness: readiness,
+	}
+}
+
+// Liveness lida com a verificação de que o processo está no ar.
+// @Summary      Liveness probe
+// @Description  Returns 200 while the process is able to serve HTTP. It does not check dependencies.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  health.ComponentStatus
+// @Router       /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(health.ComponentStatus{Status: health.StatusUp})
+}
+
+// Readiness lida com a verificação de que a instância pode receber tráfego.
+// @Summary      Readiness probe
+// @Description  Checks the database connection, pending migrations and background workers, returning the status of each component. Returns 503 when any component is down or the server is shutting down.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Failure      503  {object}  health.Report
+// @Router       /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := h.readiness.Check(r.Context())
+
+	status := http.StatusOK
+	if !report.Up() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthHandlerSuite struct {
+	suite.Suite
+}
+
+func Test_HealthHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(HealthHandlerSuite))
+}
+
+func (suite *HealthHandlerSuite) Test_Liveness() {
+	suite.T().Run("Liveness - Success", func(t *testing.T) {
+		handler := h.NewHealthHandler(health.NewReadiness(time.Second))
+		rr := httptest.NewRecorder()
+
+		handler.Liveness(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.JSONEq(`{"status":"up"}`, rr.Body.String())
+	})
+}
+
+func (suite *HealthHandlerSuite) Test_Readiness() {
+	suite.T().Run("Readiness - All Components Up", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.Register("database", func(context.Context) error { return nil })
+		handler := h.NewHealthHandler(readiness)
+		rr := httptest.NewRecorder()
+
+		handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("application/json", rr.Header().Get("Content-Type"))
+		suite.JSONEq(`{"status":"up","components":{"database":{"status":"up"}}}`, rr.Body.String())
+	})
+
+	suite.T().Run("Readiness - Component Down", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.Register("database", func(context.Context) error { return nil })
+		readiness.Register("migrations", func(context.Context) error { return errors.New("1 pending migration(s)") })
+		handler := h.NewHealthHandler(readiness)
+		rr := httptest.NewRecorder()
+
+		handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		suite.Equal(http.StatusServiceUnavailable, rr.Code)
+		var report health.Report
+		suite.NoError(json.Unmarshal(rr.Body.Bytes(), &report))
+		suite.Equal(health.StatusDown, report.Status)
+		suite.Equal(health.StatusUp, report.Components["database"].Status)
+		suite.Equal("1 pending migration(s)", report.Components["migrations"].Error)
+	})
+
+	suite.T().Run("Readiness - Shutting Down", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.ShutDown()
+		handler := h.NewHealthHandler(readiness)
+		rr := httptest.NewRecorder()
+
+		handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		suite.Equal(http.StatusServiceUnavailable, rr.Code)
+		suite.Contains(rr.Body.String(), `"shutdown":{"status":"down","error":"server is shutting down"}`)
+	})
+}
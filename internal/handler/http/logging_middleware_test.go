@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/logger"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggingMiddlewareSuite struct {
+	suite.Suite
+}
+
+func Test_LoggingMiddlewareSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LoggingMiddlewareSuite))
+}
+
+func (suite *LoggingMiddlewareSuite) Test_RequestID() {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestIDFromContext(r.Context())
+	})
+
+	suite.T().Run("Request ID - Generated", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		h.RequestID(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/sales/available", nil))
+
+		suite.NotEmpty(seen)
+		suite.Equal(seen, rr.Header().Get("X-Request-ID"))
+	})
+
+	suite.T().Run("Request ID - Propagated From Header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/sales/available", nil)
+		req.Header.Set("X-Request-ID", "upstream-123")
+		rr := httptest.NewRecorder()
+
+		h.RequestID(next).ServeHTTP(rr, req)
+
+		suite.Equal("upstream-123", seen)
+		suite.Equal("upstream-123", rr.Header().Get("X-Request-ID"))
+	})
+
+	suite.T().Run("Request ID - Unsafe Header Replaced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/sales/available", nil)
+		req.Header.Set("X-Request-ID", "bad id\n")
+		rr := httptest.NewRecorder()
+
+		h.RequestID(next).ServeHTTP(rr, req)
+
+		suite.NotEqual("bad id\n", seen)
+		suite.Len(seen, 36)
+
+		req.Header.Set("X-Request-ID", strings.Repeat("a", 129))
+		h.RequestID(next).ServeHTTP(rr, req)
+		suite.Len(seen, 36)
+	})
+}
+
+func (suite *LoggingMiddlewareSuite) Test_RequestLogger() {
+	suite.T().Run("Request Logger - Logs Status And Request ID", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{})
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("ok"))
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/listings", nil)
+		req.Header.Set("X-Request-ID", "req-1")
+		h.RequestID(h.RequestLogger(log)(next)).ServeHTTP(httptest.NewRecorder(), req)
+
+		var line map[string]any
+		suite.NoError(json.Unmarshal(buf.Bytes(), &line))
+		suite.Equal("INFO", line["level"])
+		suite.Equal("POST", line["method"])
+		suite.Equal("/listings", line["path"])
+		suite.Equal(float64(http.StatusCreated), line["status"])
+		suite.Equal(float64(2), line["bytes"])
+		suite.Equal("req-1", line["request_id"])
+	})
+
+	suite.T().Run("Request Logger - Server Errors Logged As Errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{})
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		})
+
+		h.RequestLogger(log)(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		suite.Contains(buf.String(), `"level":"ERROR"`)
+	})
+}
+
+func (suite *LoggingMiddlewareSuite) Test_Recoverer() {
+	suite.T().Run("Recoverer - Panic Becomes 500", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})
+		rr := httptest.NewRecorder()
+
+		h.Recoverer(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+	})
+
+	suite.T().Run("Recoverer - Abort Handler Is Rethrown", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		})
+
+		suite.PanicsWithValue(http.ErrAbortHandler, func() {
+			h.Recoverer(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
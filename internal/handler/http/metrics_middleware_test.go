@@ -0,0 +1,46 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsMiddlewareSuite struct {
+	suite.Suite
+}
+
+func Test_MetricsMiddlewareSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MetricsMiddlewareSuite))
+}
+
+func (suite *MetricsMiddlewareSuite) Test_Metrics() {
+	m := metrics.New()
+	router := chi.NewRouter()
+	router.Use(h.Metrics(m))
+	router.Get("/metrics", m.Handler().ServeHTTP)
+	router.Post("/sales/{id}/purchase", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	suite.T().Run("Metrics - Route Pattern Label", func(t *testing.T) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/sales/sale-1/purchase", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/sales/sale-2/purchase", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body, _ := io.ReadAll(rr.Body)
+
+		suite.Contains(string(body), `showcase_http_request_duration_seconds_count{method="POST",route="/sales/{id}/purchase",status="409"} 2`)
+		suite.Contains(string(body), `showcase_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+		suite.NotContains(string(body), "sale-1")
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const dateQueryLayout = "2006-01-02"
+
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// parseDateRange lê os parâmetros from e to (YYYY-MM-DD, ambos inclusivos) e devolve o
+// intervalo semiaberto [from, to+1 dia) usado nas consultas.
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	from, err := time.Parse(dateQueryLayout, query.Get("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid from parameter, expected YYYY-MM-DD")
+	}
+	to, err := time.Parse(dateQueryLayout, query.Get("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid to parameter, expected YYYY-MM-DD")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to must not be before from")
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
+)
+
+const (
+	jsonContentType       = "application/json"
+	mergePatchContentType = "application/merge-patch+json"
+	maxJSONBodySize       = 1 << 20
+	maxBulkImportBodySize = 32 << 20
+)
+
+// requestError é um corpo de requisição recusado, com o status e a mensagem da resposta.
+type requestError struct {
+	status  int
+	message string
+	fields  validation.Errors
+}
+
+func (e *requestError) Error() string {
+	if len(e.fields) > 0 {
+		return e.message + ": " + e.fields.Error()
+	}
+	return e.message
+}
+
+func invalidBody(message string, fields ...validation.FieldError) *requestError {
+	return &requestError{status: http.StatusBadRequest, message: message, fields: fields}
+}
+
+// decodeJSON lê o corpo com readJSONObject e decodifica e valida v com validation.JSON. O erro
+// devolvido deve ser escrito com writeRequestError.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	raw, err := readJSONObject(w, r, jsonContentType)
+	if err != nil {
+		return err
+	}
+
+	if err := validation.JSON(raw, v); err != nil {
+		var fields validation.Errors
+		errors.As(err, &fields)
+		return invalidBody("Invalid request body", fields...)
+	}
+	return nil
+}
+
+// readJSONObject lê um único objeto JSON de até maxJSONBodySize bytes enviado como mediaType,
+// recusando corpos que não são objetos (inclusive null).
+func readJSONObject(w http.ResponseWriter, r *http.Request, mediaType string) (json.RawMessage, error) {
+	if contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType != mediaType {
+		return nil, &requestError{status: http.StatusUnsupportedMediaType, message: "Content-Type must be " + mediaType}
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJSONBodySize))
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, decodeError(err)
+	}
+	if err := decoder.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, decodeError(err)
+		}
+		return nil, invalidBody("Request body must contain a single JSON object")
+	}
+	if raw[0] != '{' {
+		return nil, invalidBody("Request body must be a JSON object")
+	}
+	return raw, nil
+}
+
+// decodeError traduz os erros de leitura do corpo que não são de um campo específico.
+func decodeError(err error) *requestError {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return &requestError{
+			status:  http.StatusRequestEntityTooLarge,
+			message: fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit),
+		}
+	case errors.Is(err, io.EOF):
+		return invalidBody("Request body is empty")
+	}
+	return invalidBody("Invalid request body")
+}
+
+// serverError responde 500 com message e registra err. Os casos de uso e repositórios só devolvem
+// o erro, então este é o único registro de uma falha inesperada; erros esperados, como um recurso
+// não encontrado, não passam por aqui.
+func serverError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// writeRequestError responde com dto.OutputErrorDTO. validation.Errors viram a lista de campos
+// inválidos e os demais erros que não vieram de decodeJSON viram 400.
+func writeRequestError(w http.ResponseWriter, err error) {
+	var reqErr *requestError
+	var fields validation.Errors
+	switch {
+	case errors.As(err, &reqErr):
+	case errors.As(err, &fields):
+		reqErr = invalidBody("Invalid request body", fields...)
+	default:
+		reqErr = invalidBody(err.Error())
+	}
+
+	output := dto.OutputErrorDTO{Error: reqErr.message}
+	for _, field := range reqErr.fields {
+		output.Fields = append(output.Fields, &dto.FieldErrorDTO{Field: field.Field, Message: field.Message})
+	}
+
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(reqErr.status)
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// RequestDecodingSuite cobre a leitura de corpos JSON pelas rotas de anúncio; as outras rotas
+// usam o mesmo decodeJSON.
+type RequestDecodingSuite struct {
+	suite.Suite
+
+	useCase *mocks.MockSaleUseCaseInterface
+	router  *chi.Mux
+}
+
+func (suite *RequestDecodingSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.useCase = mocks.NewMockSaleUseCaseInterface(ctrl)
+	handler := h.NewSaleHandler(suite.useCase)
+
+	suite.router = chi.NewRouter()
+	suite.router.Post("/listings", handler.CreateListing)
+	suite.router.Put("/listings/vehicle/{vehicle_id}", handler.UpdateListing)
+}
+
+func Test_RequestDecodingSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RequestDecodingSuite))
+}
+
+func (suite *RequestDecodingSuite) send(method, path, contentType, body string) (*httptest.ResponseRecorder, dto.OutputErrorDTO) {
+	req := httptest.NewRequestWithContext(context.Background(), method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rr := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(rr, req)
+
+	var output dto.OutputErrorDTO
+	if rr.Code >= http.StatusBadRequest {
+		suite.Equal("application/json", rr.Header().Get("Content-Type"))
+		suite.NoError(json.Unmarshal(rr.Body.Bytes(), &output), rr.Body.String())
+	}
+	return rr, output
+}
+
+func (suite *RequestDecodingSuite) Test_ContentType() {
+	body := `{"vehicle_id":"vehicle-1","brand":"Toyota","model":"Corolla","price":95000}`
+
+	suite.T().Run("should reject a missing or non JSON content type", func(t *testing.T) {
+		for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded"} {
+			rr, output := suite.send(http.MethodPost, "/listings", contentType, body)
+
+			suite.Equal(http.StatusUnsupportedMediaType, rr.Code, contentType)
+			suite.Equal("Content-Type must be application/json", output.Error)
+		}
+	})
+
+	suite.T().Run("should accept a JSON content type with parameters", func(t *testing.T) {
+		suite.useCase.EXPECT().CreateListing(gomock.Any(), gomock.Any()).Return(&dto.OutputCreateListingDTO{}, nil)
+
+		rr, _ := suite.send(http.MethodPost, "/listings", "application/json; charset=utf-8", body)
+
+		suite.Equal(http.StatusCreated, rr.Code)
+	})
+}
+
+func (suite *RequestDecodingSuite) Test_Body() {
+	testCases := []struct {
+		name    string
+		body    string
+		status  int
+		message string
+		fields  []*dto.FieldErrorDTO
+	}{
+		{
+			name:    "should reject an empty body",
+			body:    "",
+			status:  http.StatusBadRequest,
+			message: "Request body is empty",
+		},
+		{
+			name:    "should reject a null body",
+			body:    "null",
+			status:  http.StatusBadRequest,
+			message: "Request body must be a JSON object",
+		},
+		{
+			name:    "should reject an array body",
+			body:    `[{"brand":"Toyota"}]`,
+			status:  http.StatusBadRequest,
+			message: "Request body must be a JSON object",
+		},
+		{
+			name:    "should reject malformed JSON",
+			body:    `{"brand":`,
+			status:  http.StatusBadRequest,
+			message: "Invalid request body",
+		},
+		{
+			name:    "should reject data after the object",
+			body:    `{"brand":"Toyota","model":"Corolla","price":1} {}`,
+			status:  http.StatusBadRequest,
+			message: "Request body must contain a single JSON object",
+		},
+		{
+			name:    "should reject unknown fields",
+			body:    `{"brand":"Toyota","model":"Corolla","price":1,"color":"red"}`,
+			status:  http.StatusBadRequest,
+			message: "Invalid request body",
+			fields:  []*dto.FieldErrorDTO{{Field: "color", Message: "is not a known field"}},
+		},
+		{
+			name:    "should point the field with the wrong type",
+			body:    `{"brand":"Toyota","model":"Corolla","price":"95000"}`,
+			status:  http.StatusBadRequest,
+			message: "Invalid request body",
+			fields:  []*dto.FieldErrorDTO{{Field: "price", Message: "must be a number"}},
+		},
+		{
+			name:    "should list every field that breaks a rule",
+			body:    `{"brand":"","model":"` + strings.Repeat("x", 101) + `","price":-1}`,
+			status:  http.StatusBadRequest,
+			message: "Invalid request body",
+			fields: []*dto.FieldErrorDTO{
+				{Field: "brand", Message: "is required"},
+				{Field: "model", Message: "must be at most 100 characters"},
+				{Field: "price", Message: "must be greater than 0"},
+			},
+		},
+		{
+			name:    "should reject a body larger than 1 MiB",
+			body:    `{"brand":"` + strings.Repeat("x", 1<<20) + `"}`,
+			status:  http.StatusRequestEntityTooLarge,
+			message: "Request body must not be larger than 1048576 bytes",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			rr, output := suite.send(http.MethodPut, "/listings/vehicle/vehicle-1", "application/json", tc.body)
+
+			suite.Equal(tc.status, rr.Code)
+			suite.Equal(tc.message, output.Error)
+			suite.Equal(tc.fields, output.Fields)
+		})
+	}
+}
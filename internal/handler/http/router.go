@@ -1,28 +1,72 @@
 package handler
 
 import (
+	"log/slog"
+
 	_ "github.com/NicolasNSC/showcase-service-fiap/docs"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
 	"github.com/go-chi/chi"
-	"github.com/go-chi/chi/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "github.com/NicolasNSC/showcase-service-fiap/docs"
 )
 
-func SetupRoutes(router *chi.Mux, saleHandler *SaleHandler) {
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
+type Handlers struct {
+	Sale      *SaleHandler
+	Analytics *AnalyticsHandler
+	Stream    *SaleStreamHandler
+	Health    *HealthHandler
+}
 
-	router.Get("/swagger/*", httpSwagger.WrapHandler)
+// Features liga as rotas opcionais; uma rota desligada responde 404.
+type Features struct {
+	Swagger     bool
+	Metrics     bool
+	EventStream bool
+}
 
-	router.Post("/listings", saleHandler.CreateListing)
-	router.Put("/listings/vehicle/{vehicle_id}", saleHandler.UpdateListing)
-	router.Post("/webhooks/payments", saleHandler.HandlePaymentWebhook)
+func SetupRoutes(router *chi.Mux, features Features, handlers Handlers, verifier auth.TokenVerifier, apiKeys usecase.APIKeyUseCaseInterface, m *metrics.Metrics) {
+	saleHandler := handlers.Sale
+	router.Use(RequestID)
+	router.Use(Tracing)
+	router.Use(RequestLogger(slog.Default()))
+	router.Use(Recoverer)
+	if features.Metrics {
+		router.Use(Metrics(m))
+	}
 
-	router.Route("/sales/{id}", func(r chi.Router) {
-		r.Post("/purchase", saleHandler.Purchase)
-	})
+	router.Get("/healthz", handlers.Health.Liveness)
+	router.Get("/readyz", handlers.Health.Readiness)
+	if features.Metrics {
+		router.Get("/metrics", m.Handler().ServeHTTP)
+	}
+	if features.Swagger {
+		router.Get("/swagger/*", httpSwagger.WrapHandler)
+	}
 
 	router.Get("/sales/available", saleHandler.ListAvailable)
 	router.Get("/sales/sold", saleHandler.ListSold)
+	if features.EventStream {
+		router.Get("/sales/stream", handlers.Stream.Stream)
+	}
+
+	router.Group(func(r chi.Router) {
+		r.Use(AuthenticateAPIKey(apiKeys))
+		r.Use(Authenticate(verifier))
+
+		r.With(RequireRole(auth.RoleCatalogService, auth.RoleAdmin)).Post("/listings", saleHandler.CreateListing)
+		r.With(RequireRole(auth.RoleCatalogService, auth.RoleAdmin)).Post("/listings/bulk", saleHandler.BulkCreateListings)
+		r.With(RequireRole(auth.RoleCatalogService, auth.RoleAdmin)).Put("/listings/vehicle/{vehicle_id}", saleHandler.UpdateListing)
+		r.With(RequireRole(auth.RoleCatalogService, auth.RoleAdmin)).Get("/listings/vehicle/{vehicle_id}", saleHandler.GetListing)
+		r.With(RequireRole(auth.RoleCatalogService, auth.RoleAdmin)).Patch("/listings/vehicle/{vehicle_id}", saleHandler.PatchListing)
+		r.With(RequireRole(auth.RolePaymentGateway)).Post("/webhooks/payments", saleHandler.HandlePaymentWebhook)
+
+		r.With(RequireRole(auth.RoleBuyer)).Post("/sales/{id}/purchase", saleHandler.Purchase)
+
+		r.With(RequireRole(auth.RoleAdmin)).Get("/reports/sales", saleHandler.ExportSalesReport)
+		r.With(RequireRole(auth.RoleAdmin)).Get("/analytics/sales", handlers.Analytics.SalesAnalytics)
+		r.With(RequireRole(auth.RoleAdmin)).Post("/buyers/purchases", saleHandler.ListBuyerSales)
+	})
 }
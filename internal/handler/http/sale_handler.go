@@ -2,10 +2,21 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
 	"github.com/go-chi/chi"
 )
 
@@ -25,22 +36,27 @@ func NewSaleHandler(useCase usecase.SaleUseCaseInterface) *SaleHandler {
 // @Tags         Internal
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
 // @Param        listing  body      dto.InputCreateListingDTO  true  "Listing Data"
 // @Success      201      {object}  dto.OutputCreateListingDTO
-// @Failure      400      {string}  string "Invalid request body"
+// @Failure      400      {object}  dto.OutputErrorDTO "Invalid request body"
+// @Failure      401      {string}  string "Unauthorized"
+// @Failure      403      {string}  string "Forbidden"
+// @Failure      413      {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415      {object}  dto.OutputErrorDTO "Unsupported content type"
 // @Failure      500      {string}  string "Internal server error"
 // @Router       /listings [post]
 func (h *SaleHandler) CreateListing(w http.ResponseWriter, r *http.Request) {
 	var input dto.InputCreateListingDTO
-	err := json.NewDecoder(r.Body).Decode(&input)
-	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeRequestError(w, err)
 		return
 	}
 
 	output, err := h.useCase.CreateListing(r.Context(), &input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		serverError(w, r, err, err.Error())
 		return
 	}
 
@@ -49,6 +65,71 @@ func (h *SaleHandler) CreateListing(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(output)
 }
 
+// BulkCreateListings lida com a importação em lote de listagens a partir de CSV ou NDJSON.
+// @Summary      Bulk import sale listings
+// @Description  Imports many listings at once from a CSV file (header: vehicle_id,brand,model,price) or NDJSON. Rows are validated one by one and inserted in batches. Use dry_run to only validate and atomic to insert all rows or none. Bodies are limited to 32 MiB. This is an internal endpoint.
+// @Tags         Internal
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
+// @Param        dry_run  query     bool  false  "Only validate rows, nothing is inserted"
+// @Param        atomic   query     bool  false  "Insert every row in a single transaction or none at all"
+// @Success      200      {object}  dto.OutputBulkImportDTO
+// @Failure      400      {string}  string "Invalid request body or query"
+// @Failure      401      {string}  string "Unauthorized"
+// @Failure      403      {string}  string "Forbidden"
+// @Failure      413      {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415      {string}  string "Unsupported content type"
+// @Failure      500      {string}  string "Internal server error"
+// @Router       /listings/bulk [post]
+func (h *SaleHandler) BulkCreateListings(w http.ResponseWriter, r *http.Request) {
+	var input dto.InputBulkImportDTO
+	var err error
+
+	if input.DryRun, err = parseBoolQuery(r, "dry_run"); err != nil {
+		http.Error(w, "Invalid dry_run parameter", http.StatusBadRequest)
+		return
+	}
+	if input.Atomic, err = parseBoolQuery(r, "atomic"); err != nil {
+		http.Error(w, "Invalid atomic parameter", http.StatusBadRequest)
+		return
+	}
+
+	var reader importer.ListingReader
+	body := http.MaxBytesReader(w, r.Body, maxBulkImportBodySize)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		reader = importer.NewCSVListingReader(body)
+	case "application/x-ndjson":
+		reader = importer.NewNDJSONListingReader(body)
+	default:
+		http.Error(w, "Content-Type must be text/csv or application/x-ndjson", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	output, err := h.useCase.BulkCreateListings(r.Context(), reader, input)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeRequestError(w, decodeError(err))
+			return
+		}
+		if errors.Is(err, importer.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		serverError(w, r, err, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(output)
+}
+
 // ListAvailable lida com a requisição para listar veículos à venda.
 // @Summary      List available vehicles
 // @Description  Get a list of all vehicles available for sale, sorted by price.
@@ -61,7 +142,7 @@ func (h *SaleHandler) CreateListing(w http.ResponseWriter, r *http.Request) {
 func (h *SaleHandler) ListAvailable(w http.ResponseWriter, r *http.Request) {
 	output, err := h.useCase.ListAvailable(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		serverError(w, r, err, err.Error())
 		return
 	}
 
@@ -82,7 +163,45 @@ func (h *SaleHandler) ListAvailable(w http.ResponseWriter, r *http.Request) {
 func (h *SaleHandler) ListSold(w http.ResponseWriter, r *http.Request) {
 	output, err := h.useCase.ListSold(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		serverError(w, r, err, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListBuyerSales lida com a consulta do histórico de compras de um comprador.
+// @Summary      List a buyer's purchase history
+// @Description  Lists every sale reserved or bought with the given CPF, newest first. The CPF goes in the body so it never shows up in URLs or access logs, and the response only carries the masked CPF. Requires the admin role.
+// @Tags         Support
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lookup  body      dto.InputBuyerSalesDTO  true  "Buyer CPF and pagination"
+// @Success      200     {object}  dto.OutputBuyerSalesDTO
+// @Failure      400     {object}  dto.OutputErrorDTO "Invalid request body or CPF"
+// @Failure      401     {string}  string "Unauthorized"
+// @Failure      403     {string}  string "Forbidden"
+// @Failure      413     {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415     {object}  dto.OutputErrorDTO "Unsupported content type"
+// @Failure      500     {string}  string "Internal server error"
+// @Router       /buyers/purchases [post]
+func (h *SaleHandler) ListBuyerSales(w http.ResponseWriter, r *http.Request) {
+	var input dto.InputBuyerSalesDTO
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+
+	output, err := h.useCase.ListBuyerSales(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidBuyerCPF) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		serverError(w, r, err, err.Error())
 		return
 	}
 
@@ -97,10 +216,18 @@ func (h *SaleHandler) ListSold(w http.ResponseWriter, r *http.Request) {
 // @Tags         Internal
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
 // @Param        vehicle_id  path      string                         true  "Vehicle ID"
 // @Param        listing     body      dto.InputUpdateListingDTO  true  "Listing Data to Update"
 // @Success      200         {string}  string "OK"
+// @Failure      400         {object}  dto.OutputErrorDTO "Invalid request body"
+// @Failure      401         {string}  string "Unauthorized"
+// @Failure      403         {string}  string "Forbidden"
 // @Failure      404         {string}  string "Listing not found"
+// @Failure      409         {string}  string "Listing kept changing concurrently"
+// @Failure      413         {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415         {object}  dto.OutputErrorDTO "Unsupported content type"
 // @Failure      500         {string}  string "Internal server error"
 // @Router       /listings/vehicle/{vehicle_id} [put]
 func (h *SaleHandler) UpdateListing(w http.ResponseWriter, r *http.Request) {
@@ -110,32 +237,151 @@ func (h *SaleHandler) UpdateListing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var input *dto.InputUpdateListingDTO
-	err := json.NewDecoder(r.Body).Decode(&input)
+	var input dto.InputUpdateListingDTO
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeRequestError(w, err)
+		return
+	}
+
+	err := h.useCase.UpdateListing(r.Context(), vehicleID, &input)
+	if err != nil {
+		writeListingError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetListing lida com a consulta de uma listagem pelo veículo.
+// @Summary      Get a sale listing
+// @Description  Returns a listing by vehicle ID. The ETag header identifies the version that was read; send it back in If-Match when patching the listing. This is an internal endpoint.
+// @Tags         Internal
+// @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
+// @Param        vehicle_id  path      string  true  "Vehicle ID"
+// @Success      200         {object}  dto.OutputListingDTO
+// @Header       200         {string}  ETag  "Version of the listing"
+// @Failure      401         {string}  string "Unauthorized"
+// @Failure      403         {string}  string "Forbidden"
+// @Failure      404         {string}  string "Listing not found"
+// @Failure      500         {string}  string "Internal server error"
+// @Router       /listings/vehicle/{vehicle_id} [get]
+func (h *SaleHandler) GetListing(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "vehicle_id")
+	if vehicleID == "" {
+		http.Error(w, "Vehicle ID is required", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.useCase.GetListing(r.Context(), vehicleID)
+	if err != nil {
+		writeListingError(w, r, err)
+		return
+	}
+
+	writeListing(w, output)
+}
+
+// PatchListing lida com a atualização parcial de uma listagem.
+// @Summary      Partially update a sale listing
+// @Description  Applies a JSON Merge Patch (RFC 7386) to brand, model and price; fields left out of the patch keep their values and null removes a field, which fails validation for required fields. The merged listing goes through the same rules as a full update. With If-Match, the patch is only applied if the listing still has that ETag. This is an internal endpoint.
+// @Tags         Internal
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
+// @Param        vehicle_id  path      string                     true   "Vehicle ID"
+// @Param        If-Match    header    string                     false  "ETag of the listing version being patched"
+// @Param        patch       body      dto.InputUpdateListingDTO  true   "Fields to change"
+// @Success      200         {object}  dto.OutputListingDTO
+// @Header       200         {string}  ETag  "Version of the patched listing"
+// @Failure      400         {object}  dto.OutputErrorDTO "Invalid patch or merged listing"
+// @Failure      401         {string}  string "Unauthorized"
+// @Failure      403         {string}  string "Forbidden"
+// @Failure      404         {string}  string "Listing not found"
+// @Failure      409         {string}  string "Listing kept changing concurrently"
+// @Failure      412         {string}  string "Listing was modified since it was read"
+// @Failure      413         {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415         {object}  dto.OutputErrorDTO "Unsupported content type"
+// @Failure      500         {string}  string "Internal server error"
+// @Router       /listings/vehicle/{vehicle_id} [patch]
+func (h *SaleHandler) PatchListing(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "vehicle_id")
+	if vehicleID == "" {
+		http.Error(w, "Vehicle ID is required", http.StatusBadRequest)
+		return
+	}
+
+	patch, err := readJSONObject(w, r, mergePatchContentType)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeRequestError(w, err)
 		return
 	}
 
-	err = h.useCase.UpdateListing(r.Context(), vehicleID, input)
+	output, err := h.useCase.PatchListing(r.Context(), vehicleID, dto.InputPatchListingDTO{
+		Patch:   patch,
+		IfMatch: r.Header.Get("If-Match"),
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeListingError(w, r, err)
 		return
 	}
 
+	writeListing(w, output)
+}
+
+func writeListing(w http.ResponseWriter, output *dto.OutputListingDTO) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", output.ETag)
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(output)
+}
+
+// writeListingError responde aos erros das rotas de uma listagem.
+func writeListingError(w http.ResponseWriter, r *http.Request, err error) {
+	var fields validation.Errors
+	switch {
+	case errors.As(err, &fields), errors.Is(err, validation.ErrMalformedJSON), errors.Is(err, usecase.ErrInvalidListing):
+		writeRequestError(w, err)
+	case errors.Is(err, repository.ErrSaleNotFound):
+		http.Error(w, "Listing not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrListingModified):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, repository.ErrConcurrentModification):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		serverError(w, r, err, err.Error())
+	}
+}
+
+// writeSaleError traduz os erros da compra e do webhook. Venda inexistente e venda fora do status
+// esperado são respostas definitivas, que não adianta repetir; só o resto vira 500 com message.
+func writeSaleError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrSaleNotFound):
+		http.Error(w, "Sale not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrSaleNotAvailable), errors.Is(err, usecase.ErrSaleNotPendingPayment),
+		errors.Is(err, repository.ErrConcurrentModification):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, usecase.ErrInvalidPaymentStatus):
+		writeRequestError(w, err)
+	default:
+		serverError(w, r, err, message)
+	}
 }
 
 // Purchase lida com a requisição para iniciar a compra de um veículo.
 // @Summary      Purchase a vehicle
-// @Description  Initiates the purchase process for a specific sale listing.
+// @Description  Initiates the purchase process for a specific sale listing. The buyer is identified by the cpf claim of the bearer token; any CPF sent in the body is ignored.
 // @Tags         Sales
-// @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id        path      string                   true  "Sale ID"
-// @Param        purchase  body      dto.InputPurchaseDTO true  "Buyer's CPF"
 // @Success      202       {object}  dto.OutputPurchaseDTO
-// @Failure      400       {string}  string "Invalid request body or ID"
+// @Failure      400       {string}  string "Invalid ID"
+// @Failure      401       {string}  string "Unauthorized"
+// @Failure      403       {string}  string "Token does not identify a buyer"
 // @Failure      404       {string}  string "Sale not found"
 // @Failure      409       {string}  string "Sale is not available for purchase"
 // @Failure      500       {string}  string "Internal server error"
@@ -147,16 +393,16 @@ func (h *SaleHandler) Purchase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var input dto.InputPurchaseDTO
-	err := json.NewDecoder(r.Body).Decode(&input)
-	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok || len(domain.NormalizeCPF(principal.CPF)) != 11 {
+		http.Error(w, "Token does not identify a buyer", http.StatusForbidden)
 		return
 	}
 
+	input := dto.InputPurchaseDTO{BuyerCPF: principal.CPF}
 	output, err := h.useCase.Purchase(r.Context(), saleID, input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSaleError(w, r, err, err.Error())
 		return
 	}
 
@@ -171,24 +417,135 @@ func (h *SaleHandler) Purchase(w http.ResponseWriter, r *http.Request) {
 // @Tags         Webhooks
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
+// @Security     APIKeyAuth
 // @Param        notification  body      dto.InputWebhookDTO  true  "Payment Notification Payload"
 // @Success      204           {string}  string "No Content"
-// @Failure      400           {string}  string "Invalid request body"
+// @Failure      400           {object}  dto.OutputErrorDTO "Invalid request body or payment status"
+// @Failure      401           {string}  string "Unauthorized"
+// @Failure      403           {string}  string "Forbidden"
+// @Failure      404           {string}  string "Sale not found"
+// @Failure      409           {string}  string "Sale is not in pending payment status"
+// @Failure      413           {object}  dto.OutputErrorDTO "Request body too large"
+// @Failure      415           {object}  dto.OutputErrorDTO "Unsupported content type"
 // @Failure      500           {string}  string "Failed to process webhook"
 // @Router       /webhooks/payments [post]
 func (h *SaleHandler) HandlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
-	var input *dto.InputWebhookDTO
-	err := json.NewDecoder(r.Body).Decode(&input)
-	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	var input dto.InputWebhookDTO
+	if err := decodeJSON(w, r, &input); err != nil {
+		writeRequestError(w, err)
 		return
 	}
 
-	err = h.useCase.HandlePaymentWebhook(r.Context(), input)
+	err := h.useCase.HandlePaymentWebhook(r.Context(), &input)
 	if err != nil {
-		http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
+		writeSaleError(w, r, err, "Failed to process webhook")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+const (
+	xlsxContentType   = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	csvReportMimeType = "text/csv"
+
+	// reportWriteTimeout limita cada escrita do relatório. Substitui o WriteTimeout do servidor,
+	// que cortaria relatórios grandes no meio do download.
+	reportWriteTimeout = 30 * time.Second
+)
+
+// ExportSalesReport lida com a exportação do relatório de veículos vendidos.
+// @Summary      Export sold vehicles report
+// @Description  Streams the sold vehicles whose sale date is within [from, to] as CSV, or as XLSX when the Accept header asks for it. The buyer CPF is masked unless unmask_cpf is true.
+// @Tags         Reports
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     BearerAuth
+// @Param        from        query     string  true   "First sale date (YYYY-MM-DD)"
+// @Param        to          query     string  true   "Last sale date, inclusive (YYYY-MM-DD)"
+// @Param        columns     query     string  false  "Comma-separated columns: sale_id, vehicle_id, brand, model, price, status, sale_date, payment_id, buyer_cpf, created_at"
+// @Param        unmask_cpf  query     bool    false  "Show the full buyer CPF"
+// @Success      200         {file}    file
+// @Failure      400         {string}  string "Invalid query parameters"
+// @Failure      401         {string}  string "Unauthorized"
+// @Failure      403         {string}  string "Forbidden"
+// @Failure      500         {string}  string "Internal server error"
+// @Router       /reports/sales [get]
+func (h *SaleHandler) ExportSalesReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input := dto.InputSalesReportDTO{
+		From: from,
+		To:   to,
+	}
+	if columns := r.URL.Query().Get("columns"); columns != "" {
+		input.Columns = strings.Split(columns, ",")
+	}
+	if input.UnmaskCPF, err = parseBoolQuery(r, "unmask_cpf"); err != nil {
+		http.Error(w, "Invalid unmask_cpf parameter", http.StatusBadRequest)
+		return
+	}
+
+	out := newStartedWriter(w)
+	filename := "sales-report-" + from.Format(dateQueryLayout) + "-" + to.AddDate(0, 0, -1).Format(dateQueryLayout)
+
+	var writer report.Writer
+	if strings.Contains(r.Header.Get("Accept"), xlsxContentType) {
+		writer = report.NewXLSXWriter(out)
+		w.Header().Set("Content-Type", xlsxContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.xlsx"`)
+	} else {
+		writer = report.NewCSVWriter(out)
+		w.Header().Set("Content-Type", csvReportMimeType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.csv"`)
+	}
+
+	err = h.useCase.ExportSoldReport(r.Context(), input, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if out.started {
+			slog.ErrorContext(r.Context(), "sales report aborted after the response started", "error", err)
+			panic(http.ErrAbortHandler)
+		}
+		w.Header().Del("Content-Disposition")
+		if errors.Is(err, report.ErrUnknownColumn) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		serverError(w, r, err, err.Error())
+	}
+}
+
+// startedWriter registra se algum byte já foi enviado, pois depois disso não é mais possível
+// responder com um status de erro e a conexão precisa ser abortada. A cada escrita ele renova o
+// prazo de escrita da conexão, como o stream SSE faz.
+type startedWriter struct {
+	http.ResponseWriter
+	controller *http.ResponseController
+	started    bool
+}
+
+func newStartedWriter(w http.ResponseWriter) *startedWriter {
+	s := &startedWriter{ResponseWriter: w, controller: http.NewResponseController(w)}
+	s.extendDeadline()
+	return s
+}
+
+// extendDeadline ignora o erro: writers sem suporte a deadline (como nos testes) seguem sem
+// limite por escrita.
+func (s *startedWriter) extendDeadline() {
+	_ = s.controller.SetWriteDeadline(time.Now().Add(reportWriteTimeout))
+}
+
+func (s *startedWriter) Write(p []byte) (int, error) {
+	s.started = true
+	s.extendDeadline()
+	return s.ResponseWriter.Write(p)
+}
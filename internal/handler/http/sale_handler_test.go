@@ -5,14 +5,23 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/NicolasNSC/showcase-service-fiap/internal/auth"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
 	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
 	"github.com/go-chi/chi"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -57,6 +66,7 @@ func (suite *SaleHandlerSuite) Test_CreateListing() {
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.CreateListing(rr, req)
@@ -71,6 +81,7 @@ func (suite *SaleHandlerSuite) Test_CreateListing() {
 
 	suite.T().Run("Create Listing - Invalid Body", func(t *testing.T) {
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings", bytes.NewReader([]byte("invalid json")))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.CreateListing(rr, req)
@@ -86,6 +97,7 @@ func (suite *SaleHandlerSuite) Test_CreateListing() {
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.CreateListing(rr, req)
@@ -95,6 +107,116 @@ func (suite *SaleHandlerSuite) Test_CreateListing() {
 	})
 }
 
+func (suite *SaleHandlerSuite) Test_BulkCreateListings() {
+	csvBody := "vehicle_id,brand,model,price\nvehicle-1,Toyota,Corolla,50000\n"
+	expectedOutput := &dto.OutputBulkImportDTO{
+		Total:   1,
+		Created: 1,
+		Rows: []*dto.OutputBulkImportRowDTO{
+			{Line: 2, VehicleID: "vehicle-1", SaleID: "sale-1", Status: dto.BulkRowCreated},
+		},
+	}
+
+	suite.T().Run("Bulk Create Listings - CSV Success", func(t *testing.T) {
+		suite.useCase.EXPECT().BulkCreateListings(suite.ctx, gomock.Any(), dto.InputBulkImportDTO{DryRun: true, Atomic: true}).Return(expectedOutput, nil)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk?dry_run=true&atomic=true", strings.NewReader(csvBody))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		rr := httptest.NewRecorder()
+
+		suite.handler.BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("application/json", rr.Header().Get("Content-Type"))
+
+		var resp dto.OutputBulkImportDTO
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		suite.NoError(err)
+		suite.Equal(1, resp.Created)
+		suite.Equal("sale-1", resp.Rows[0].SaleID)
+	})
+
+	suite.T().Run("Bulk Create Listings - NDJSON Success", func(t *testing.T) {
+		suite.useCase.EXPECT().BulkCreateListings(suite.ctx, gomock.Any(), dto.InputBulkImportDTO{}).Return(expectedOutput, nil)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk", strings.NewReader(`{"vehicle_id":"vehicle-1"}`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		rr := httptest.NewRecorder()
+
+		suite.handler.BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+	})
+
+	suite.T().Run("Bulk Create Listings - Unsupported Content Type", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk", strings.NewReader(csvBody))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusUnsupportedMediaType, rr.Code)
+	})
+
+	suite.T().Run("Bulk Create Listings - Invalid Query", func(t *testing.T) {
+		for _, query := range []string{"dry_run=maybe", "atomic=maybe"} {
+			req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk?"+query, strings.NewReader(csvBody))
+			req.Header.Set("Content-Type", "text/csv")
+			rr := httptest.NewRecorder()
+
+			suite.handler.BulkCreateListings(rr, req)
+
+			suite.Equal(http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	suite.T().Run("Bulk Create Listings - Malformed Input", func(t *testing.T) {
+		suite.useCase.EXPECT().BulkCreateListings(suite.ctx, gomock.Any(), dto.InputBulkImportDTO{}).Return(nil, fmt.Errorf("%w: csv header is missing", importer.ErrInvalidInput))
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk", strings.NewReader(""))
+		req.Header.Set("Content-Type", "text/csv")
+		rr := httptest.NewRecorder()
+
+		suite.handler.BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+		suite.Contains(rr.Body.String(), "csv header is missing")
+	})
+
+	suite.T().Run("Bulk Create Listings - Body Too Large", func(t *testing.T) {
+		useCase := mocks.NewMockSaleUseCaseInterface(gomock.NewController(t))
+		useCase.EXPECT().BulkCreateListings(gomock.Any(), gomock.Any(), dto.InputBulkImportDTO{}).DoAndReturn(
+			func(ctx context.Context, reader importer.ListingReader, input dto.InputBulkImportDTO) (*dto.OutputBulkImportDTO, error) {
+				for {
+					if _, err := reader.Next(); err != nil {
+						return nil, err
+					}
+				}
+			})
+		body := "vehicle_id,brand,model,price\n" + strings.Repeat("x", 33<<20)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		rr := httptest.NewRecorder()
+
+		h.NewSaleHandler(useCase).BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	suite.T().Run("Bulk Create Listings - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().BulkCreateListings(suite.ctx, gomock.Any(), dto.InputBulkImportDTO{}).Return(nil, errors.New("usecase error"))
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/bulk", strings.NewReader(csvBody))
+		req.Header.Set("Content-Type", "text/csv")
+		rr := httptest.NewRecorder()
+
+		suite.handler.BulkCreateListings(rr, req)
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+	})
+}
+
 func (suite *SaleHandlerSuite) Test_ListAvailable() {
 	suite.T().Run("List Available - Success", func(t *testing.T) {
 		expectedOutput := []*dto.OutputSaleItemDTO{
@@ -199,6 +321,84 @@ func (suite *SaleHandlerSuite) Test_ListSold() {
 	})
 }
 
+func (suite *SaleHandlerSuite) Test_ListBuyerSales() {
+	input := dto.InputBuyerSalesDTO{BuyerCPF: "123.456.789-00", Page: 2, PageSize: 10}
+
+	suite.T().Run("List Buyer Sales - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputBuyerSalesDTO{
+			Page:     2,
+			PageSize: 10,
+			Total:    11,
+			Items: []*dto.OutputBuyerSaleItemDTO{
+				{SaleID: "sale-id-1", Status: "SOLD", BuyerCPF: "***.456.789-**"},
+			},
+		}
+		suite.useCase.EXPECT().ListBuyerSales(suite.ctx, input).Return(expectedOutput, nil)
+
+		body, _ := json.Marshal(input)
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/buyers/purchases", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListBuyerSales(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		var output dto.OutputBuyerSalesDTO
+		suite.NoError(json.Unmarshal(rr.Body.Bytes(), &output))
+		suite.Equal(*expectedOutput, output)
+	})
+
+	suite.T().Run("List Buyer Sales - Invalid Body", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/buyers/purchases", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListBuyerSales(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	suite.T().Run("List Buyer Sales - Invalid CPF", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/buyers/purchases", bytes.NewBufferString(`{"buyer_cpf":"123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListBuyerSales(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+		var output dto.OutputErrorDTO
+		suite.NoError(json.Unmarshal(rr.Body.Bytes(), &output))
+		suite.Equal([]*dto.FieldErrorDTO{{Field: "buyer_cpf", Message: "must be a CPF with 11 digits"}}, output.Fields)
+	})
+
+	suite.T().Run("List Buyer Sales - CPF Rejected By Use Case", func(t *testing.T) {
+		suite.useCase.EXPECT().ListBuyerSales(suite.ctx, input).Return(nil, usecase.ErrInvalidBuyerCPF)
+
+		body, _ := json.Marshal(input)
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/buyers/purchases", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListBuyerSales(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	suite.T().Run("List Buyer Sales - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().ListBuyerSales(suite.ctx, input).Return(nil, errors.New("usecase error"))
+
+		body, _ := json.Marshal(input)
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/buyers/purchases", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ListBuyerSales(rr, req)
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+		suite.Contains(rr.Body.String(), "usecase error")
+	})
+}
+
 func (suite *SaleHandlerSuite) Test_UpdateListing() {
 	vehicleID := "vehicle-123"
 	input := &dto.InputUpdateListingDTO{
@@ -212,6 +412,7 @@ func (suite *SaleHandlerSuite) Test_UpdateListing() {
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPut, "/listings/"+vehicleID, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
 			URLParams: chi.RouteParams{
 				Keys:   []string{"vehicle_id"},
@@ -228,6 +429,7 @@ func (suite *SaleHandlerSuite) Test_UpdateListing() {
 	suite.T().Run("Update Listing - Missing Vehicle ID", func(t *testing.T) {
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPut, "/listings/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.UpdateListing(rr, req)
@@ -238,6 +440,7 @@ func (suite *SaleHandlerSuite) Test_UpdateListing() {
 
 	suite.T().Run("Update Listing - Invalid Body", func(t *testing.T) {
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPut, "/listings/"+vehicleID, bytes.NewReader([]byte("invalid json")))
+		req.Header.Set("Content-Type", "application/json")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
 			URLParams: chi.RouteParams{
 				Keys:   []string{"vehicle_id"},
@@ -258,6 +461,7 @@ func (suite *SaleHandlerSuite) Test_UpdateListing() {
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPut, "/listings/"+vehicleID, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
 			URLParams: chi.RouteParams{
 				Keys:   []string{"vehicle_id"},
@@ -273,10 +477,142 @@ func (suite *SaleHandlerSuite) Test_UpdateListing() {
 	})
 }
 
+func (suite *SaleHandlerSuite) Test_GetListing() {
+	vehicleID := "vehicle-123"
+	routeCtx := &chi.Context{URLParams: chi.RouteParams{Keys: []string{"vehicle_id"}, Values: []string{vehicleID}}}
+
+	suite.T().Run("Get Listing - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().GetListing(gomock.Any(), vehicleID).Return(&dto.OutputListingDTO{
+			SaleID:    "sale-123",
+			VehicleID: vehicleID,
+			Brand:     "Ford",
+			Model:     "Focus",
+			Price:     45000,
+			ETag:      `"1714564800000000"`,
+		}, nil)
+
+		req, _ := http.NewRequestWithContext(context.WithValue(suite.ctx, chi.RouteCtxKey, routeCtx), http.MethodGet, "/listings/vehicle/"+vehicleID, nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.GetListing(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal(`"1714564800000000"`, rr.Header().Get("ETag"))
+		suite.Contains(rr.Body.String(), `"model":"Focus"`)
+		suite.NotContains(rr.Body.String(), "1714564800000000")
+	})
+
+	suite.T().Run("Get Listing - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().GetListing(gomock.Any(), vehicleID).Return(nil, repository.ErrSaleNotFound)
+
+		req, _ := http.NewRequestWithContext(context.WithValue(suite.ctx, chi.RouteCtxKey, routeCtx), http.MethodGet, "/listings/vehicle/"+vehicleID, nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.GetListing(rr, req)
+
+		suite.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
+func (suite *SaleHandlerSuite) Test_PatchListing() {
+	vehicleID := "vehicle-123"
+	routeCtx := &chi.Context{URLParams: chi.RouteParams{Keys: []string{"vehicle_id"}, Values: []string{vehicleID}}}
+	newRequest := func(body, contentType, ifMatch string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.WithValue(suite.ctx, chi.RouteCtxKey, routeCtx), http.MethodPatch, "/listings/vehicle/"+vehicleID, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		return req
+	}
+
+	suite.T().Run("Patch Listing - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().PatchListing(gomock.Any(), vehicleID, dto.InputPatchListingDTO{
+			Patch:   []byte(`{"price": 47500}`),
+			IfMatch: `"1"`,
+		}).Return(&dto.OutputListingDTO{SaleID: "sale-123", Price: 47500, ETag: `"2"`}, nil)
+
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"price": 47500}`, "application/merge-patch+json", `"1"`))
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal(`"2"`, rr.Header().Get("ETag"))
+		suite.Contains(rr.Body.String(), `"price":47500`)
+	})
+
+	suite.T().Run("Patch Listing - Plain JSON Content Type", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"price": 47500}`, "application/json", ""))
+
+		suite.Equal(http.StatusUnsupportedMediaType, rr.Code)
+		suite.Contains(rr.Body.String(), "application/merge-patch+json")
+	})
+
+	suite.T().Run("Patch Listing - Not An Object", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`[]`, "application/merge-patch+json", ""))
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	suite.T().Run("Patch Listing - Stale If-Match", func(t *testing.T) {
+		suite.useCase.EXPECT().PatchListing(gomock.Any(), vehicleID, gomock.Any()).Return(nil, usecase.ErrListingModified)
+
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"price": 47500}`, "application/merge-patch+json", `"1"`))
+
+		suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	})
+
+	suite.T().Run("Patch Listing - Invalid Merged Listing", func(t *testing.T) {
+		suite.useCase.EXPECT().PatchListing(gomock.Any(), vehicleID, gomock.Any()).
+			Return(nil, validation.Errors{{Field: "brand", Message: "is required"}})
+
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"brand": null}`, "application/merge-patch+json", ""))
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+		suite.Contains(rr.Body.String(), `"field":"brand"`)
+	})
+
+	suite.T().Run("Patch Listing - Concurrent Modification", func(t *testing.T) {
+		suite.useCase.EXPECT().PatchListing(gomock.Any(), vehicleID, gomock.Any()).
+			Return(nil, &repository.ConcurrentModificationError{SaleID: "sale-123", Expected: 3, Current: 4})
+
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"price": 47500}`, "application/merge-patch+json", ""))
+
+		suite.Equal(http.StatusConflict, rr.Code)
+	})
+
+	suite.T().Run("Patch Listing - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().PatchListing(gomock.Any(), vehicleID, gomock.Any()).Return(nil, repository.ErrSaleNotFound)
+
+		rr := httptest.NewRecorder()
+		suite.handler.PatchListing(rr, newRequest(`{"price": 47500}`, "application/merge-patch+json", ""))
+
+		suite.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
 func (suite *SaleHandlerSuite) Test_Purchase() {
 	saleID := "sale-123"
+	buyer := &auth.Principal{Subject: "buyer-1", Roles: []auth.Role{auth.RoleBuyer}, CPF: "12345678900"}
 	input := dto.InputPurchaseDTO{
-		BuyerCPF: "buyer-456",
+		BuyerCPF: "12345678900",
+	}
+	newRequest := func(principal *auth.Principal, body string) *http.Request {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/"+saleID+"/purchase", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"id"},
+				Values: []string{saleID},
+			},
+		})
+		if principal != nil {
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+		return req.WithContext(ctx)
 	}
 
 	suite.T().Run("Purchase - Success", func(t *testing.T) {
@@ -285,17 +621,9 @@ func (suite *SaleHandlerSuite) Test_Purchase() {
 		}
 		suite.useCase.EXPECT().Purchase(gomock.Any(), saleID, input).Return(expectedOutput, nil)
 
-		body, _ := json.Marshal(input)
-		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/"+saleID+"/purchase", bytes.NewReader(body))
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
-			URLParams: chi.RouteParams{
-				Keys:   []string{"id"},
-				Values: []string{saleID},
-			},
-		}))
 		rr := httptest.NewRecorder()
 
-		suite.handler.Purchase(rr, req)
+		suite.handler.Purchase(rr, newRequest(buyer, ""))
 
 		suite.Equal(http.StatusAccepted, rr.Code)
 		suite.Equal("application/json", rr.Header().Get("Content-Type"))
@@ -306,9 +634,18 @@ func (suite *SaleHandlerSuite) Test_Purchase() {
 		suite.Equal(expectedOutput.PaymentID, resp.PaymentID)
 	})
 
+	suite.T().Run("Purchase - Body CPF Is Ignored", func(t *testing.T) {
+		suite.useCase.EXPECT().Purchase(gomock.Any(), saleID, input).Return(&dto.OutputPurchaseDTO{PaymentID: "payment-789"}, nil)
+
+		rr := httptest.NewRecorder()
+
+		suite.handler.Purchase(rr, newRequest(buyer, `{"buyer_cpf":"99999999999"}`))
+
+		suite.Equal(http.StatusAccepted, rr.Code)
+	})
+
 	suite.T().Run("Purchase - Missing Sale ID", func(t *testing.T) {
-		body, _ := json.Marshal(input)
-		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings//purchase", bytes.NewReader(body))
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings//purchase", nil)
 		rr := httptest.NewRecorder()
 
 		suite.handler.Purchase(rr, req)
@@ -317,37 +654,46 @@ func (suite *SaleHandlerSuite) Test_Purchase() {
 		suite.Contains(rr.Body.String(), "Sale ID is required")
 	})
 
-	suite.T().Run("Purchase - Invalid Body", func(t *testing.T) {
-		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/"+saleID+"/purchase", bytes.NewReader([]byte("invalid json")))
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
-			URLParams: chi.RouteParams{
-				Keys:   []string{"id"},
-				Values: []string{saleID},
-			},
-		}))
+	suite.T().Run("Purchase - Token Without Buyer CPF", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 
-		suite.handler.Purchase(rr, req)
+		suite.handler.Purchase(rr, newRequest(&auth.Principal{Subject: "buyer-1", Roles: []auth.Role{auth.RoleBuyer}}, ""))
 
-		suite.Equal(http.StatusBadRequest, rr.Code)
-		suite.Contains(rr.Body.String(), "Invalid request body")
+		suite.Equal(http.StatusForbidden, rr.Code)
+		suite.Contains(rr.Body.String(), "Token does not identify a buyer")
+	})
+
+	suite.T().Run("Purchase - Not Authenticated", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		suite.handler.Purchase(rr, newRequest(nil, ""))
+
+		suite.Equal(http.StatusForbidden, rr.Code)
+	})
+
+	suite.T().Run("Purchase - Expected Errors", func(t *testing.T) {
+		for err, status := range map[error]int{
+			repository.ErrSaleNotFound:           http.StatusNotFound,
+			usecase.ErrSaleNotAvailable:          http.StatusConflict,
+			repository.ErrConcurrentModification: http.StatusConflict,
+		} {
+			suite.useCase.EXPECT().Purchase(gomock.Any(), saleID, input).Return(nil, err)
+
+			rr := httptest.NewRecorder()
+
+			suite.handler.Purchase(rr, newRequest(buyer, ""))
+
+			suite.Equal(status, rr.Code, err.Error())
+		}
 	})
 
 	suite.T().Run("Purchase - Use Case Error", func(t *testing.T) {
 		expectedErr := errors.New("purchase error")
 		suite.useCase.EXPECT().Purchase(gomock.Any(), saleID, input).Return(nil, expectedErr)
 
-		body, _ := json.Marshal(input)
-		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/listings/"+saleID+"/purchase", bytes.NewReader(body))
-		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
-			URLParams: chi.RouteParams{
-				Keys:   []string{"id"},
-				Values: []string{saleID},
-			},
-		}))
 		rr := httptest.NewRecorder()
 
-		suite.handler.Purchase(rr, req)
+		suite.handler.Purchase(rr, newRequest(buyer, ""))
 
 		suite.Equal(http.StatusInternalServerError, rr.Code)
 		suite.Contains(rr.Body.String(), expectedErr.Error())
@@ -358,12 +704,13 @@ func (suite *SaleHandlerSuite) Test_HandlePaymentWebhook() {
 	suite.T().Run("HandlePaymentWebhook - Success", func(t *testing.T) {
 		input := &dto.InputWebhookDTO{
 			PaymentID: "payment-123",
-			Status:    "PAID",
+			Status:    "APPROVED",
 		}
 		suite.useCase.EXPECT().HandlePaymentWebhook(gomock.Any(), input).Return(nil)
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/webhook/payment", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.HandlePaymentWebhook(rr, req)
@@ -373,6 +720,7 @@ func (suite *SaleHandlerSuite) Test_HandlePaymentWebhook() {
 
 	suite.T().Run("HandlePaymentWebhook - Invalid Body", func(t *testing.T) {
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/webhook/payment", bytes.NewReader([]byte("invalid json")))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.HandlePaymentWebhook(rr, req)
@@ -381,15 +729,50 @@ func (suite *SaleHandlerSuite) Test_HandlePaymentWebhook() {
 		suite.Contains(rr.Body.String(), "Invalid request body")
 	})
 
+	suite.T().Run("HandlePaymentWebhook - Invalid Status", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/webhook/payment", bytes.NewBufferString(`{"payment_id":"payment-123","status":"PAID"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		suite.handler.HandlePaymentWebhook(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+		suite.Contains(rr.Body.String(), `"field":"status"`)
+	})
+
+	suite.T().Run("HandlePaymentWebhook - Expected Errors", func(t *testing.T) {
+		input := &dto.InputWebhookDTO{
+			PaymentID: "payment-456",
+			Status:    "APPROVED",
+		}
+		for err, status := range map[error]int{
+			repository.ErrSaleNotFound:       http.StatusNotFound,
+			usecase.ErrSaleNotPendingPayment: http.StatusConflict,
+			usecase.ErrInvalidPaymentStatus:  http.StatusBadRequest,
+		} {
+			suite.useCase.EXPECT().HandlePaymentWebhook(gomock.Any(), input).Return(err)
+
+			body, _ := json.Marshal(input)
+			req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/webhook/payment", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			suite.handler.HandlePaymentWebhook(rr, req)
+
+			suite.Equal(status, rr.Code, err.Error())
+		}
+	})
+
 	suite.T().Run("HandlePaymentWebhook - Use Case Error", func(t *testing.T) {
 		input := &dto.InputWebhookDTO{
 			PaymentID: "payment-456",
-			Status:    "FAILED",
+			Status:    "CANCELED",
 		}
 		suite.useCase.EXPECT().HandlePaymentWebhook(gomock.Any(), input).Return(errors.New("webhook error"))
 
 		body, _ := json.Marshal(input)
 		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodPost, "/webhook/payment", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
 		suite.handler.HandlePaymentWebhook(rr, req)
@@ -398,3 +781,130 @@ func (suite *SaleHandlerSuite) Test_HandlePaymentWebhook() {
 		suite.Contains(rr.Body.String(), "Failed to process webhook")
 	})
 }
+
+func (suite *SaleHandlerSuite) Test_ExportSalesReport() {
+	expectedInput := dto.InputSalesReportDTO{
+		From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	writeRow := func(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error {
+		return writer.WriteRow([]any{"sale_id"})
+	}
+
+	suite.T().Run("Export Sales Report - CSV Success", func(t *testing.T) {
+		input := expectedInput
+		input.Columns = []string{"sale_id", "price"}
+		input.UnmaskCPF = true
+		suite.useCase.EXPECT().ExportSoldReport(suite.ctx, input, gomock.Any()).DoAndReturn(writeRow)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?from=2026-03-01&to=2026-03-31&columns=sale_id,price&unmask_cpf=true", nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.ExportSalesReport(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("text/csv", rr.Header().Get("Content-Type"))
+		suite.Equal(`attachment; filename="sales-report-2026-03-01-2026-03-31.csv"`, rr.Header().Get("Content-Disposition"))
+		suite.Equal("sale_id\n", rr.Body.String())
+	})
+
+	suite.T().Run("Export Sales Report - XLSX Success", func(t *testing.T) {
+		suite.useCase.EXPECT().ExportSoldReport(suite.ctx, expectedInput, gomock.Any()).DoAndReturn(writeRow)
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?from=2026-03-01&to=2026-03-31", nil)
+		req.Header.Set("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		rr := httptest.NewRecorder()
+
+		suite.handler.ExportSalesReport(rr, req)
+
+		suite.Equal(http.StatusOK, rr.Code)
+		suite.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rr.Header().Get("Content-Type"))
+		suite.Equal("PK", rr.Body.String()[:2])
+	})
+
+	suite.T().Run("Export Sales Report - Invalid Query", func(t *testing.T) {
+		for _, query := range []string{
+			"to=2026-03-31",
+			"from=2026-03-01",
+			"from=2026-03-31&to=2026-03-01",
+			"from=2026-03-01&to=2026-03-31&unmask_cpf=maybe",
+		} {
+			req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?"+query, nil)
+			rr := httptest.NewRecorder()
+
+			suite.handler.ExportSalesReport(rr, req)
+
+			suite.Equal(http.StatusBadRequest, rr.Code, query)
+		}
+	})
+
+	suite.T().Run("Export Sales Report - Unknown Column", func(t *testing.T) {
+		input := expectedInput
+		input.Columns = []string{"secret"}
+		suite.useCase.EXPECT().ExportSoldReport(suite.ctx, input, gomock.Any()).Return(fmt.Errorf("%w: secret", report.ErrUnknownColumn))
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?from=2026-03-01&to=2026-03-31&columns=secret", nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.ExportSalesReport(rr, req)
+
+		suite.Equal(http.StatusBadRequest, rr.Code)
+		suite.Empty(rr.Header().Get("Content-Disposition"))
+	})
+
+	suite.T().Run("Export Sales Report - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().ExportSoldReport(suite.ctx, expectedInput, gomock.Any()).Return(errors.New("db error"))
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?from=2026-03-01&to=2026-03-31", nil)
+		rr := httptest.NewRecorder()
+
+		suite.handler.ExportSalesReport(rr, req)
+
+		suite.Equal(http.StatusInternalServerError, rr.Code)
+	})
+
+	suite.T().Run("Export Sales Report - Outlives The Server Write Timeout", func(t *testing.T) {
+		row := strings.Repeat("x", 5000)
+		suite.useCase.EXPECT().ExportSoldReport(gomock.Any(), expectedInput, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error {
+				for i := 0; i < 20; i++ {
+					time.Sleep(10 * time.Millisecond)
+					if err := writer.WriteRow([]any{row}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(suite.handler.ExportSalesReport))
+		server.Config.WriteTimeout = 50 * time.Millisecond
+		server.Start()
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/reports/sales?from=2026-03-01&to=2026-03-31")
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		suite.NoError(err)
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal(20*(len(row)+1), len(body))
+	})
+
+	suite.T().Run("Export Sales Report - Abort After Streaming Started", func(t *testing.T) {
+		suite.useCase.EXPECT().ExportSoldReport(suite.ctx, expectedInput, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error {
+				for i := 0; i < 1000; i++ {
+					writer.WriteRow([]any{"a long enough value to fill the csv buffer"})
+				}
+				return errors.New("db error")
+			})
+
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/reports/sales?from=2026-03-01&to=2026-03-31", nil)
+		rr := httptest.NewRecorder()
+
+		suite.PanicsWithValue(http.ErrAbortHandler, func() {
+			suite.handler.ExportSalesReport(rr, req)
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+)
+
+const (
+	sseRetryMillis = 3000
+
+	// sseWriteTimeout limita cada escrita no stream. Substitui o WriteTimeout do servidor, que
+	// derrubaria toda conexão SSE depois de alguns segundos.
+	sseWriteTimeout = 10 * time.Second
+)
+
+type SaleStreamHandler struct {
+	broker    *events.Broker
+	heartbeat time.Duration
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewSaleStreamHandler(broker *events.Broker, heartbeat time.Duration) *SaleStreamHandler {
+	return &SaleStreamHandler{
+		broker:    broker,
+		heartbeat: heartbeat,
+		done:      make(chan struct{}),
+	}
+}
+
+// Shutdown encerra os streams abertos. Sem isso o http.Server.Shutdown esperaria por conexões
+// que nunca ficam ociosas; o cliente reconecta em outra instância usando o Last-Event-ID.
+func (h *SaleStreamHandler) Shutdown() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// Stream lida com a conexão Server-Sent Events de mudanças no estoque.
+// @Summary      Stream inventory changes
+// @Description  Server-Sent Events stream with listing.created, listing.updated, listing.reserved, listing.sold and listing.canceled events. Send the Last-Event-ID header to resume after the last received event. Comment lines are sent periodically as heartbeats.
+// @Tags         Sales
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header    string  false  "ID of the last event received"
+// @Success      200            {object}  events.Event
+// @Failure      500            {string}  string "Streaming unsupported"
+// @Router       /sales/stream [get]
+func (h *SaleStreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := h.broker.Subscribe(r.Header.Get("Last-Event-ID"))
+	defer sub.Close()
+
+	// Writers sem suporte a deadline (como nos testes) seguem sem limite por escrita.
+	controller := http.NewResponseController(w)
+	extendDeadline := func() {
+		_ = controller.SetWriteDeadline(time.Now().Add(sseWriteTimeout))
+	}
+	extendDeadline()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis)
+	for _, event := range sub.Replay {
+		if err := writeSSEEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.done:
+			return
+		case event, ok := <-sub.Events():
+			extendDeadline()
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			extendDeadline()
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeSSEEvent(w io.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/stretchr/testify/suite"
+)
+
+type SaleStreamHandlerSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	broker  *events.Broker
+	handler *h.SaleStreamHandler
+	server  *httptest.Server
+}
+
+func (suite *SaleStreamHandlerSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.broker = events.NewBroker(10)
+	suite.handler = h.NewSaleStreamHandler(suite.broker, 20*time.Millisecond)
+	suite.server = httptest.NewUnstartedServer(http.HandlerFunc(suite.handler.Stream))
+	suite.server.Config.WriteTimeout = 50 * time.Millisecond
+	suite.server.Start()
+}
+
+func (suite *SaleStreamHandlerSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func Test_SaleStreamHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SaleStreamHandlerSuite))
+}
+
+func (suite *SaleStreamHandlerSuite) readUntil(scanner *bufio.Scanner, prefix string) string {
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), prefix) {
+			return scanner.Text()
+		}
+	}
+	suite.FailNow("stream ended before " + prefix)
+	return ""
+}
+
+func (suite *SaleStreamHandlerSuite) Test_Stream() {
+	suite.T().Run("Stream - Replays After Last-Event-ID And Pushes New Events", func(t *testing.T) {
+		suite.NoError(suite.broker.Publish(suite.ctx, events.Event{ID: "event-1", Type: events.TypeListingCreated}))
+		suite.NoError(suite.broker.Publish(suite.ctx, events.Event{ID: "event-2", Type: events.TypeListingReserved, SaleID: "sale-1"}))
+
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL, nil)
+		req.Header.Set("Last-Event-ID", "event-1")
+		resp, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+		suite.Equal("no-cache", resp.Header.Get("Cache-Control"))
+
+		scanner := bufio.NewScanner(resp.Body)
+		suite.Equal("retry: 3000", suite.readUntil(scanner, "retry:"))
+		suite.Equal("id: event-2", suite.readUntil(scanner, "id:"))
+		suite.Equal("event: listing.reserved", suite.readUntil(scanner, "event:"))
+		suite.Contains(suite.readUntil(scanner, "data:"), `"sale_id":"sale-1"`)
+
+		suite.NoError(suite.broker.Publish(suite.ctx, events.Event{ID: "event-3", Type: events.TypeListingSold}))
+		suite.Equal("id: event-3", suite.readUntil(scanner, "id:"))
+		suite.Equal("event: listing.sold", suite.readUntil(scanner, "event:"))
+	})
+
+	suite.T().Run("Stream - Sends Heartbeats", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		suite.Equal(": heartbeat", suite.readUntil(scanner, ": "))
+	})
+
+	suite.T().Run("Stream - Outlives The Server Write Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(suite.ctx)
+		defer cancel()
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		time.Sleep(150 * time.Millisecond)
+		suite.NoError(suite.broker.Publish(suite.ctx, events.Event{ID: "event-late", Type: events.TypeListingSold}))
+
+		scanner := bufio.NewScanner(resp.Body)
+		suite.Equal("id: event-late", suite.readUntil(scanner, "id:"))
+	})
+
+	suite.T().Run("Stream - Ends On Shutdown", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, suite.server.URL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		suite.readUntil(scanner, "retry:")
+
+		suite.handler.Shutdown()
+		suite.handler.Shutdown()
+
+		for scanner.Scan() {
+		}
+		suite.NoError(scanner.Err())
+	})
+}
+
+type nonFlusherWriter struct {
+	http.ResponseWriter
+}
+
+func (suite *SaleStreamHandlerSuite) Test_Stream_Unsupported() {
+	handler := h.NewSaleStreamHandler(suite.broker, time.Second)
+	req, _ := http.NewRequestWithContext(suite.ctx, http.MethodGet, "/sales/stream", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Stream(nonFlusherWriter{rr}, req)
+
+	suite.Equal(http.StatusInternalServerError, rr.Code)
+	suite.Contains(rr.Body.String(), "Streaming unsupported")
+}
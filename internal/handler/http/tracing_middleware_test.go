@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	h "github.com/NicolasNSC/showcase-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry/telemetrytest"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSuite struct {
+	suite.Suite
+}
+
+func Test_TracingMiddlewareSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TracingMiddlewareSuite))
+}
+
+func (suite *TracingMiddlewareSuite) Test_Tracing() {
+	exporter := telemetrytest.Install(suite.T())
+
+	var handlerSpan trace.SpanContext
+	router := chi.NewRouter()
+	router.Use(h.Tracing)
+	router.Get("/tracing/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	suite.T().Run("Tracing - Continues Incoming Trace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tracing/sale-1", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := telemetrytest.Named(exporter, "GET /tracing/{id}")
+		suite.Require().Len(spans, 1)
+		span := spans[0]
+		suite.Equal(trace.SpanKindServer, span.SpanKind)
+		suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		suite.Equal("00f067aa0ba902b7", span.Parent.SpanID().String())
+		suite.Equal(span.SpanContext.SpanID(), handlerSpan.SpanID())
+		suite.Equal(codes.Error, span.Status.Code)
+		suite.Contains(span.Attributes, attribute.String("http.route", "/tracing/{id}"))
+		suite.Contains(span.Attributes, attribute.Int("http.response.status_code", http.StatusInternalServerError))
+	})
+}
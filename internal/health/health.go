@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// ShutdownComponent aparece no relatório quando o servidor começou a encerrar.
+	ShutdownComponent = "shutdown"
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Check verifica um componente; qualquer erro deixa a instância fora do balanceamento.
+type Check func(ctx context.Context) error
+
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+type Readiness struct {
+	timeout      time.Duration
+	names        []string
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+// NewReadiness cria o verificador de prontidão. Cada Check roda com o timeout informado.
+func NewReadiness(timeout time.Duration) *Readiness {
+	return &Readiness{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+func (r *Readiness) Register(name string, check Check) {
+	if _, ok := r.checks[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.checks[name] = check
+}
+
+// ShutDown faz a prontidão falhar a partir de agora, para que o balanceador pare de enviar tráfego
+// enquanto as requisições em andamento terminam.
+func (r *Readiness) ShutDown() {
+	r.shuttingDown.Store(true)
+}
+
+// Check roda todos os componentes em paralelo e devolve o resultado de cada um.
+func (r *Readiness) Check(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make(map[string]ComponentStatus, len(r.names)+1)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range r.names {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+			status := componentStatus(check(checkCtx))
+
+			mu.Lock()
+			report.Components[name] = status
+			mu.Unlock()
+		}(name, r.checks[name])
+	}
+	wg.Wait()
+
+	if r.shuttingDown.Load() {
+		report.Components[ShutdownComponent] = componentStatus(ErrShuttingDown)
+	}
+	for _, component := range report.Components {
+		if component.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func componentStatus(err error) ComponentStatus {
+	if err != nil {
+		return ComponentStatus{Status: StatusDown, Error: err.Error()}
+	}
+	return ComponentStatus{Status: StatusUp}
+}
+
+// Database verifica a conexão com o banco com um ping.
+func Database(db *sql.DB) Check {
+	return db.PingContext
+}
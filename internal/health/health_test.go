@@ -0,0 +1,91 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/health"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadinessSuite struct {
+	suite.Suite
+}
+
+func Test_ReadinessSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ReadinessSuite))
+}
+
+func (suite *ReadinessSuite) Test_Check() {
+	suite.T().Run("should be up without components", func(t *testing.T) {
+		report := health.NewReadiness(time.Second).Check(context.Background())
+
+		suite.True(report.Up())
+		suite.Empty(report.Components)
+	})
+
+	suite.T().Run("should report each component and go down when one fails", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.Register("database", func(context.Context) error { return nil })
+		readiness.Register("event_listener", func(context.Context) error { return errors.New("not listening") })
+
+		report := readiness.Check(context.Background())
+
+		suite.False(report.Up())
+		suite.Equal(health.ComponentStatus{Status: health.StatusUp}, report.Components["database"])
+		suite.Equal(health.ComponentStatus{Status: health.StatusDown, Error: "not listening"}, report.Components["event_listener"])
+	})
+
+	suite.T().Run("should stop a slow component at the timeout", func(t *testing.T) {
+		readiness := health.NewReadiness(10 * time.Millisecond)
+		readiness.Register("database", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		start := time.Now()
+		report := readiness.Check(context.Background())
+
+		suite.Less(time.Since(start), time.Second)
+		suite.Equal(context.DeadlineExceeded.Error(), report.Components["database"].Error)
+	})
+
+	suite.T().Run("should replace a component registered twice", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.Register("database", func(context.Context) error { return errors.New("old") })
+		readiness.Register("database", func(context.Context) error { return nil })
+
+		report := readiness.Check(context.Background())
+
+		suite.True(report.Up())
+		suite.Len(report.Components, 1)
+	})
+
+	suite.T().Run("should go down after shutdown even with healthy components", func(t *testing.T) {
+		readiness := health.NewReadiness(time.Second)
+		readiness.Register("database", func(context.Context) error { return nil })
+
+		readiness.ShutDown()
+		report := readiness.Check(context.Background())
+
+		suite.False(report.Up())
+		suite.Equal(health.ErrShuttingDown.Error(), report.Components[health.ShutdownComponent].Error)
+	})
+}
+
+func (suite *ReadinessSuite) Test_Database() {
+	suite.T().Run("should ping the database", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		suite.Require().NoError(err)
+		defer db.Close()
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+		suite.EqualError(health.Database(db)(context.Background()), "connection refused")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+)
+
+var csvRequiredColumns = []string{"vehicle_id", "brand", "model", "price"}
+
+type csvListingReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func NewCSVListingReader(r io.Reader) ListingReader {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &csvListingReader{
+		reader: reader,
+	}
+}
+
+func (c *csvListingReader) Next() (*Row, error) {
+	if c.columns == nil {
+		if err := c.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := c.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+		return &Row{Line: parseErr.StartLine, Err: errors.New("wrong number of fields")}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	row := &Row{Line: line}
+
+	price, err := strconv.ParseFloat(strings.TrimSpace(record[c.columns["price"]]), 64)
+	if err != nil {
+		row.Err = errors.New("price must be a number")
+		return row, nil
+	}
+
+	row.Input = &dto.InputCreateListingDTO{
+		VehicleID: strings.TrimSpace(record[c.columns["vehicle_id"]]),
+		Brand:     strings.TrimSpace(record[c.columns["brand"]]),
+		Model:     strings.TrimSpace(record[c.columns["model"]]),
+		Price:     price,
+	}
+
+	return row, nil
+}
+
+func (c *csvListingReader) readHeader() error {
+	header, err := c.reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("%w: csv header is missing", ErrInvalidInput)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	var missing []string
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: csv header is missing columns: %s", ErrInvalidInput, strings.Join(missing, ", "))
+	}
+
+	c.columns = columns
+	return nil
+}
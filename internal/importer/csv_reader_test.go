@@ -0,0 +1,89 @@
+package importer_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVListingReaderSuite struct {
+	suite.Suite
+}
+
+func Test_CSVListingReaderSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CSVListingReaderSuite))
+}
+
+func (suite *CSVListingReaderSuite) Test_Next() {
+	suite.T().Run("should read rows using the header to locate columns", func(t *testing.T) {
+		input := "price,model,brand,vehicle_id\n50000,Corolla,Toyota,vehicle-1\n 60000.50 , Civic ,Honda,vehicle-2\n"
+		reader := importer.NewCSVListingReader(strings.NewReader(input))
+
+		row, err := reader.Next()
+		suite.NoError(err)
+		suite.NoError(row.Err)
+		suite.Equal(2, row.Line)
+		suite.Equal("vehicle-1", row.Input.VehicleID)
+		suite.Equal("Toyota", row.Input.Brand)
+		suite.Equal("Corolla", row.Input.Model)
+		suite.Equal(50000.0, row.Input.Price)
+
+		row, err = reader.Next()
+		suite.NoError(err)
+		suite.NoError(row.Err)
+		suite.Equal(3, row.Line)
+		suite.Equal("Civic", row.Input.Model)
+		suite.Equal(60000.50, row.Input.Price)
+
+		_, err = reader.Next()
+		suite.Equal(io.EOF, err)
+	})
+
+	suite.T().Run("should report row errors and keep reading", func(t *testing.T) {
+		input := "vehicle_id,brand,model,price\nvehicle-1,Toyota,Corolla,abc\nvehicle-2,Honda\nvehicle-3,Ford,Ka,30000\n"
+		reader := importer.NewCSVListingReader(strings.NewReader(input))
+
+		row, err := reader.Next()
+		suite.NoError(err)
+		suite.EqualError(row.Err, "price must be a number")
+		suite.Nil(row.Input)
+
+		row, err = reader.Next()
+		suite.NoError(err)
+		suite.EqualError(row.Err, "wrong number of fields")
+		suite.Equal(3, row.Line)
+
+		row, err = reader.Next()
+		suite.NoError(err)
+		suite.NoError(row.Err)
+		suite.Equal("vehicle-3", row.Input.VehicleID)
+	})
+
+	suite.T().Run("should return invalid input error when header misses columns", func(t *testing.T) {
+		reader := importer.NewCSVListingReader(strings.NewReader("vehicle_id,brand\nvehicle-1,Toyota\n"))
+
+		row, err := reader.Next()
+		suite.Nil(row)
+		suite.True(errors.Is(err, importer.ErrInvalidInput))
+		suite.Contains(err.Error(), "model, price")
+	})
+
+	suite.T().Run("should return invalid input error on empty input", func(t *testing.T) {
+		reader := importer.NewCSVListingReader(strings.NewReader(""))
+
+		_, err := reader.Next()
+		suite.True(errors.Is(err, importer.ErrInvalidInput))
+	})
+
+	suite.T().Run("should return invalid input error on malformed csv", func(t *testing.T) {
+		reader := importer.NewCSVListingReader(strings.NewReader("vehicle_id,brand,model,price\n\"vehicle-1,Toyota,Corolla,1\n"))
+
+		_, err := reader.Next()
+		suite.True(errors.Is(err, importer.ErrInvalidInput))
+	})
+}
@@ -0,0 +1,21 @@
+package importer
+
+import (
+	"errors"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+)
+
+var ErrInvalidInput = errors.New("invalid import input")
+
+type Row struct {
+	Line  int
+	Input *dto.InputCreateListingDTO
+	Err   error
+}
+
+// ListingReader lê as listagens de um arquivo de importação uma linha por vez.
+// Next retorna io.EOF quando a entrada termina; erros de uma única linha vêm em Row.Err.
+type ListingReader interface {
+	Next() (*Row, error)
+}
@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+)
+
+const maxNDJSONLineSize = 1 << 20
+
+type ndjsonListingReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewNDJSONListingReader(r io.Reader) ListingReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	return &ndjsonListingReader{
+		scanner: scanner,
+	}
+}
+
+func (n *ndjsonListingReader) Next() (*Row, error) {
+	for n.scanner.Scan() {
+		n.line++
+
+		data := bytes.TrimSpace(n.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &Row{Line: n.line}
+		var input dto.InputCreateListingDTO
+		if err := json.Unmarshal(data, &input); err != nil {
+			row.Err = fmt.Errorf("invalid json: %v", err)
+			return row, nil
+		}
+
+		row.Input = &input
+		return row, nil
+	}
+
+	if err := n.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	return nil, io.EOF
+}
@@ -0,0 +1,68 @@
+package importer_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/stretchr/testify/suite"
+)
+
+type NDJSONListingReaderSuite struct {
+	suite.Suite
+}
+
+func Test_NDJSONListingReaderSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(NDJSONListingReaderSuite))
+}
+
+func (suite *NDJSONListingReaderSuite) Test_Next() {
+	suite.T().Run("should read one listing per line skipping blank lines", func(t *testing.T) {
+		input := `{"vehicle_id":"vehicle-1","brand":"Toyota","model":"Corolla","price":50000}
+
+{"vehicle_id":"vehicle-2","brand":"Honda","model":"Civic","price":60000}
+`
+		reader := importer.NewNDJSONListingReader(strings.NewReader(input))
+
+		row, err := reader.Next()
+		suite.NoError(err)
+		suite.NoError(row.Err)
+		suite.Equal(1, row.Line)
+		suite.Equal("vehicle-1", row.Input.VehicleID)
+		suite.Equal(50000.0, row.Input.Price)
+
+		row, err = reader.Next()
+		suite.NoError(err)
+		suite.Equal(3, row.Line)
+		suite.Equal("Civic", row.Input.Model)
+
+		_, err = reader.Next()
+		suite.Equal(io.EOF, err)
+	})
+
+	suite.T().Run("should report invalid json as a row error", func(t *testing.T) {
+		input := "{\"vehicle_id\":\"vehicle-1\"\n{\"vehicle_id\":\"vehicle-2\",\"brand\":\"Honda\",\"model\":\"Civic\",\"price\":1}\n"
+		reader := importer.NewNDJSONListingReader(strings.NewReader(input))
+
+		row, err := reader.Next()
+		suite.NoError(err)
+		suite.Error(row.Err)
+		suite.Contains(row.Err.Error(), "invalid json")
+
+		row, err = reader.Next()
+		suite.NoError(err)
+		suite.NoError(row.Err)
+		suite.Equal("vehicle-2", row.Input.VehicleID)
+	})
+
+	suite.T().Run("should return invalid input error when a line is too long", func(t *testing.T) {
+		input := `{"brand":"` + strings.Repeat("a", 2<<20) + `"}`
+		reader := importer.NewNDJSONListingReader(strings.NewReader(input))
+
+		_, err := reader.Next()
+		suite.True(errors.Is(err, importer.ErrInvalidInput))
+	})
+}
@@ -0,0 +1,135 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/logger"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LoggerSuite struct {
+	suite.Suite
+}
+
+func Test_LoggerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LoggerSuite))
+}
+
+func decodeLine(data []byte) map[string]any {
+	var line map[string]any
+	_ = json.Unmarshal(data, &line)
+	return line
+}
+
+func (suite *LoggerSuite) Test_New() {
+	suite.T().Run("should write JSON with the request id from the context", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, err := logger.New(&buf, logger.Config{})
+		suite.NoError(err)
+
+		ctx := logger.WithRequestID(context.Background(), "req-1")
+		log.InfoContext(ctx, "sale created", "sale_id", "sale-1")
+
+		line := decodeLine(buf.Bytes())
+		suite.Equal("INFO", line["level"])
+		suite.Equal("sale created", line["msg"])
+		suite.Equal("sale-1", line["sale_id"])
+		suite.Equal("req-1", line["request_id"])
+	})
+
+	suite.T().Run("should keep the request id through WithAttrs and WithGroup", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{})
+
+		ctx := logger.WithRequestID(context.Background(), "req-2")
+		log.With("component", "usecase").WithGroup("sale").InfoContext(ctx, "updated", "id", "sale-1")
+
+		line := decodeLine(buf.Bytes())
+		suite.Equal("usecase", line["component"])
+		suite.Equal(map[string]any{"id": "sale-1", "request_id": "req-2"}, line["sale"])
+	})
+
+	suite.T().Run("should redact sensitive attributes at any depth", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{})
+
+		log.Info("purchase", "buyer_cpf", "12345678900", slog.Group("request", "Authorization", "Bearer abc", "path", "/sales"))
+
+		line := decodeLine(buf.Bytes())
+		suite.Equal("[REDACTED]", line["buyer_cpf"])
+		suite.Equal(map[string]any{"Authorization": "[REDACTED]", "path": "/sales"}, line["request"])
+		suite.NotContains(buf.String(), "12345678900")
+	})
+
+	suite.T().Run("should filter records below the configured level", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{Level: "warn"})
+
+		log.Info("ignored")
+		suite.Empty(buf.String())
+
+		log.Warn("kept")
+		suite.Contains(buf.String(), "kept")
+	})
+
+	suite.T().Run("should write text when asked", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, _ := logger.New(&buf, logger.Config{Format: "text"})
+
+		log.Info("hello", "cpf", "12345678900")
+		suite.Contains(buf.String(), "msg=hello")
+		suite.Contains(buf.String(), "cpf=[REDACTED]")
+	})
+
+	suite.T().Run("should reject unknown level or format", func(t *testing.T) {
+		_, err := logger.New(&bytes.Buffer{}, logger.Config{Level: "verbose"})
+		suite.ErrorContains(err, `unknown log level "verbose"`)
+
+		_, err = logger.New(&bytes.Buffer{}, logger.Config{Format: "xml"})
+		suite.ErrorContains(err, `unknown log format "xml"`)
+	})
+}
+
+func (suite *LoggerSuite) Test_ParseLevel() {
+	suite.T().Run("should parse known levels", func(t *testing.T) {
+		level, err := logger.ParseLevel("DEBUG")
+		suite.NoError(err)
+		suite.Equal(slog.LevelDebug, level)
+
+		level, err = logger.ParseLevel("")
+		suite.NoError(err)
+		suite.Equal(slog.LevelInfo, level)
+	})
+}
+
+func (suite *LoggerSuite) Test_RequestID() {
+	suite.T().Run("should return empty without request id", func(t *testing.T) {
+		suite.Empty(logger.RequestIDFromContext(context.Background()))
+	})
+}
+
+func (suite *LoggerSuite) Test_TraceContext() {
+	suite.T().Run("should add trace and span ids when the context carries a span", func(t *testing.T) {
+		var buf bytes.Buffer
+		log, err := logger.New(&buf, logger.Config{})
+		suite.NoError(err)
+
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+		log.InfoContext(ctx, "sale reserved")
+
+		line := decodeLine(buf.Bytes())
+		suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", line["trace_id"])
+		suite.Equal("00f067aa0ba902b7", line["span_id"])
+	})
+}
@@ -0,0 +1,54 @@
+// Package mergepatch aplica documentos JSON Merge Patch (RFC 7386).
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Apply aplica patch a target e devolve o documento resultante. Um membro null no patch remove o
+// membro do alvo; objetos são mesclados recursivamente e qualquer outro valor substitui o anterior.
+func Apply(target, patch []byte) ([]byte, error) {
+	targetDoc, err := decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge patch target: %w", err)
+	}
+	patchDoc, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	return json.Marshal(merge(targetDoc, patchDoc))
+}
+
+// decode usa json.Number para que números grandes ou com casas decimais voltem como vieram.
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,66 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/mergepatch"
+	"github.com/stretchr/testify/suite"
+)
+
+type MergePatchSuite struct {
+	suite.Suite
+}
+
+func Test_MergePatchSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MergePatchSuite))
+}
+
+func (suite *MergePatchSuite) Test_Apply() {
+	// Exemplos do apêndice A da RFC 7386.
+	testCases := []struct {
+		target string
+		patch  string
+		result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run("should merge "+tc.patch+" into "+tc.target, func(t *testing.T) {
+			result, err := mergepatch.Apply([]byte(tc.target), []byte(tc.patch))
+
+			suite.NoError(err)
+			suite.JSONEq(tc.result, string(result))
+		})
+	}
+
+	suite.T().Run("should keep numbers exactly as they were sent", func(t *testing.T) {
+		result, err := mergepatch.Apply([]byte(`{"price":95000.10,"id":12345678901234567890}`), []byte(`{"price":99999999.99}`))
+
+		suite.NoError(err)
+		suite.Equal(`{"id":12345678901234567890,"price":99999999.99}`, string(result))
+	})
+
+	suite.T().Run("should reject malformed documents", func(t *testing.T) {
+		_, err := mergepatch.Apply([]byte(`{"a":`), []byte(`{}`))
+		suite.ErrorContains(err, "invalid merge patch target")
+
+		_, err = mergepatch.Apply([]byte(`{}`), []byte(`{"a"`))
+		suite.ErrorContains(err, "invalid merge patch")
+	})
+}
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace          = "showcase"
+	statusCountTimeout = 5 * time.Second
+	statusCountTTL     = 30 * time.Second
+)
+
+// StatusCounter devolve o número de vendas em cada status; é consultado no máximo uma vez a cada
+// statusCountTTL, por mais coletores que façam scrape.
+type StatusCounter func(ctx context.Context) (map[string]int, error)
+
+// Metrics concentra os coletores do Prometheus. Fora deste pacote a aplicação só conhece a
+// interface Recorder e os métodos abaixo.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration    *prometheus.HistogramVec
+	listingsCreated prometheus.Counter
+	purchases       prometheus.Counter
+	payments        *prometheus.CounterVec
+	cacheLookups    *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, chi route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		listingsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "listings_created_total",
+			Help:      "Sale listings created, one by one or through bulk import.",
+		}),
+		purchases: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "purchases_initiated_total",
+			Help:      "Purchases that reserved a sale and are waiting for payment.",
+		}),
+		payments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "payments_processed_total",
+			Help:      "Payment webhooks applied to a sale, by result.",
+		}, []string{"result"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Cache lookups by cache name and result (hit or miss).",
+		}, []string{"cache", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.listingsCreated,
+		m.purchases,
+		m.payments,
+		m.cacheLookups,
+	)
+	m.payments.WithLabelValues(PaymentApproved)
+	m.payments.WithLabelValues(PaymentCanceled)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	m.httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// RegisterDB expõe as estatísticas do pool de conexões (sql.DB.Stats).
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterPgxPool expõe as estatísticas do pool nativo do pgx (pgxpool.Pool.Stat), usado com
+// DB_DRIVER=pgx.
+func (m *Metrics) RegisterPgxPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPgxPoolCollector(pool.Stat))
+}
+
+// RegisterSalesByStatus expõe o gauge showcase_sales{status}, com a contagem guardada por
+// statusCountTTL para que os scrapes não consultem o banco toda vez.
+func (m *Metrics) RegisterSalesByStatus(count StatusCounter) {
+	m.registry.MustRegister(&statusCollector{
+		count: count,
+		ttl:   statusCountTTL,
+		desc:  prometheus.NewDesc(namespace+"_sales", "Sales currently in each status.", []string{"status"}, nil),
+	})
+}
+
+func (m *Metrics) ListingsCreated(count int) {
+	m.listingsCreated.Add(float64(count))
+}
+
+func (m *Metrics) PurchaseInitiated() {
+	m.purchases.Inc()
+}
+
+func (m *Metrics) PaymentProcessed(result string) {
+	m.payments.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) CacheLookup(cache, result string) {
+	m.cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+type statusCollector struct {
+	count StatusCounter
+	ttl   time.Duration
+	desc  *prometheus.Desc
+
+	mu        sync.Mutex
+	counts    map[string]int
+	fetchedAt time.Time
+}
+
+func (c *statusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *statusCollector) Collect(ch chan<- prometheus.Metric) {
+	// Sem a contagem, o gauge fica de fora desta coleta; uma métrica inválida derrubaria o
+	// /metrics inteiro justamente quando o banco está fora.
+	counts, err := c.current()
+	if err != nil {
+		return
+	}
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), status)
+	}
+}
+
+// current devolve a contagem guardada ou, se ela venceu, consulta de novo. Scrapes simultâneos
+// esperam pela mesma consulta.
+func (c *statusCollector) current() (map[string]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.counts, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusCountTimeout)
+	defer cancel()
+	counts, err := c.count(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "could not count sales by status", "error", err)
+		return nil, err
+	}
+	c.counts = counts
+	c.fetchedAt = time.Now()
+	return counts, nil
+}
@@ -0,0 +1,157 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func Test_MetricsSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MetricsSuite))
+}
+
+func scrape(m *metrics.Metrics) (int, string) {
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	return rr.Code, string(body)
+}
+
+func (suite *MetricsSuite) Test_BusinessCounters() {
+	suite.T().Run("should expose payment results before any webhook arrives", func(t *testing.T) {
+		_, body := scrape(metrics.New())
+
+		suite.Contains(body, `showcase_payments_processed_total{result="approved"} 0`)
+		suite.Contains(body, `showcase_payments_processed_total{result="canceled"} 0`)
+		suite.Contains(body, "showcase_listings_created_total 0")
+	})
+
+	suite.T().Run("should count listings, purchases and payments", func(t *testing.T) {
+		m := metrics.New()
+
+		m.ListingsCreated(1)
+		m.ListingsCreated(3)
+		m.PurchaseInitiated()
+		m.PaymentProcessed(metrics.PaymentApproved)
+		m.PaymentProcessed(metrics.PaymentApproved)
+		m.PaymentProcessed(metrics.PaymentCanceled)
+
+		_, body := scrape(m)
+		suite.Contains(body, "showcase_listings_created_total 4")
+		suite.Contains(body, "showcase_purchases_initiated_total 1")
+		suite.Contains(body, `showcase_payments_processed_total{result="approved"} 2`)
+		suite.Contains(body, `showcase_payments_processed_total{result="canceled"} 1`)
+	})
+}
+
+func (suite *MetricsSuite) Test_CacheLookup() {
+	suite.T().Run("should count hits and misses per cache", func(t *testing.T) {
+		m := metrics.New()
+
+		m.CacheLookup("available_sales", metrics.CacheMiss)
+		m.CacheLookup("available_sales", metrics.CacheHit)
+		m.CacheLookup("available_sales", metrics.CacheHit)
+
+		_, body := scrape(m)
+		suite.Contains(body, `showcase_cache_lookups_total{cache="available_sales",result="hit"} 2`)
+		suite.Contains(body, `showcase_cache_lookups_total{cache="available_sales",result="miss"} 1`)
+	})
+}
+
+func (suite *MetricsSuite) Test_ObserveHTTPRequest() {
+	suite.T().Run("should label the histogram with method, route and status", func(t *testing.T) {
+		m := metrics.New()
+
+		m.ObserveHTTPRequest(http.MethodPost, "/sales/{id}/purchase", http.StatusOK, 20*time.Millisecond)
+
+		_, body := scrape(m)
+		suite.Contains(body, `showcase_http_request_duration_seconds_count{method="POST",route="/sales/{id}/purchase",status="200"} 1`)
+	})
+}
+
+func (suite *MetricsSuite) Test_RegisterDB() {
+	suite.T().Run("should expose the connection pool stats", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		suite.NoError(err)
+		defer db.Close()
+
+		m := metrics.New()
+		m.RegisterDB(db, "showcase")
+
+		_, body := scrape(m)
+		suite.Contains(body, `go_sql_max_open_connections{db_name="showcase"}`)
+		suite.Contains(body, `go_sql_in_use_connections{db_name="showcase"}`)
+	})
+}
+
+func (suite *MetricsSuite) Test_RegisterPgxPool() {
+	suite.T().Run("should expose the pgx pool stats", func(t *testing.T) {
+		pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/showcase?pool_max_conns=7")
+		suite.Require().NoError(err)
+		defer pool.Close()
+
+		m := metrics.New()
+		m.RegisterPgxPool(pool)
+
+		_, body := scrape(m)
+		suite.Contains(body, "showcase_pgxpool_max_connections 7")
+		suite.Contains(body, "showcase_pgxpool_acquired_connections 0")
+		suite.Contains(body, "showcase_pgxpool_acquires_total 0")
+	})
+}
+
+func (suite *MetricsSuite) Test_RegisterSalesByStatus() {
+	suite.T().Run("should expose a gauge per status", func(t *testing.T) {
+		m := metrics.New()
+		m.RegisterSalesByStatus(func(ctx context.Context) (map[string]int, error) {
+			return map[string]int{"AVAILABLE": 5, "SOLD": 2}, nil
+		})
+
+		code, body := scrape(m)
+		suite.Equal(http.StatusOK, code)
+		suite.Contains(body, `showcase_sales{status="AVAILABLE"} 5`)
+		suite.Contains(body, `showcase_sales{status="SOLD"} 2`)
+	})
+
+	suite.T().Run("should reuse the counts across scrapes", func(t *testing.T) {
+		m := metrics.New()
+		calls := 0
+		m.RegisterSalesByStatus(func(ctx context.Context) (map[string]int, error) {
+			calls++
+			return map[string]int{"AVAILABLE": calls}, nil
+		})
+
+		scrape(m)
+		_, body := scrape(m)
+		suite.Equal(1, calls)
+		suite.Contains(body, `showcase_sales{status="AVAILABLE"} 1`)
+	})
+
+	suite.T().Run("should keep serving the other metrics when the sales cannot be counted", func(t *testing.T) {
+		m := metrics.New()
+		m.RegisterSalesByStatus(func(ctx context.Context) (map[string]int, error) {
+			return nil, errors.New("db down")
+		})
+		m.ObserveHTTPRequest(http.MethodGet, "/sales/available", http.StatusOK, 10*time.Millisecond)
+
+		code, body := scrape(m)
+		suite.Equal(http.StatusOK, code)
+		suite.NotContains(body, "showcase_sales{")
+		suite.Contains(body, `showcase_http_request_duration_seconds_count{method="GET",route="/sales/available",status="200"} 1`)
+		suite.Contains(body, "showcase_listings_created_total 0")
+	})
+}
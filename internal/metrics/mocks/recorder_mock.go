@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recorder.go
+//
+// Generated by this command:
+//
+//	mockgen -source=recorder.go -destination=./mocks/recorder_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecorder is a mock of Recorder interface.
+type MockRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRecorderMockRecorder is the mock recorder for MockRecorder.
+type MockRecorderMockRecorder struct {
+	mock *MockRecorder
+}
+
+// NewMockRecorder creates a new mock instance.
+func NewMockRecorder(ctrl *gomock.Controller) *MockRecorder {
+	mock := &MockRecorder{ctrl: ctrl}
+	mock.recorder = &MockRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecorder) EXPECT() *MockRecorderMockRecorder {
+	return m.recorder
+}
+
+// ListingsCreated mocks base method.
+func (m *MockRecorder) ListingsCreated(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListingsCreated", count)
+}
+
+// ListingsCreated indicates an expected call of ListingsCreated.
+func (mr *MockRecorderMockRecorder) ListingsCreated(count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListingsCreated", reflect.TypeOf((*MockRecorder)(nil).ListingsCreated), count)
+}
+
+// PaymentProcessed mocks base method.
+func (m *MockRecorder) PaymentProcessed(result string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PaymentProcessed", result)
+}
+
+// PaymentProcessed indicates an expected call of PaymentProcessed.
+func (mr *MockRecorderMockRecorder) PaymentProcessed(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentProcessed", reflect.TypeOf((*MockRecorder)(nil).PaymentProcessed), result)
+}
+
+// PurchaseInitiated mocks base method.
+func (m *MockRecorder) PurchaseInitiated() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PurchaseInitiated")
+}
+
+// PurchaseInitiated indicates an expected call of PurchaseInitiated.
+func (mr *MockRecorderMockRecorder) PurchaseInitiated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseInitiated", reflect.TypeOf((*MockRecorder)(nil).PurchaseInitiated))
+}
+
+// MockCacheRecorder is a mock of CacheRecorder interface.
+type MockCacheRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockCacheRecorderMockRecorder is the mock recorder for MockCacheRecorder.
+type MockCacheRecorderMockRecorder struct {
+	mock *MockCacheRecorder
+}
+
+// NewMockCacheRecorder creates a new mock instance.
+func NewMockCacheRecorder(ctrl *gomock.Controller) *MockCacheRecorder {
+	mock := &MockCacheRecorder{ctrl: ctrl}
+	mock.recorder = &MockCacheRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCacheRecorder) EXPECT() *MockCacheRecorderMockRecorder {
+	return m.recorder
+}
+
+// CacheLookup mocks base method.
+func (m *MockCacheRecorder) CacheLookup(cache, result string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CacheLookup", cache, result)
+}
+
+// CacheLookup indicates an expected call of CacheLookup.
+func (mr *MockCacheRecorderMockRecorder) CacheLookup(cache, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheLookup", reflect.TypeOf((*MockCacheRecorder)(nil).CacheLookup), cache, result)
+}
@@ -0,0 +1,21 @@
+package metrics
+
+//go:generate mockgen -source=recorder.go -destination=./mocks/recorder_mock.go -package=mocks
+type Recorder interface {
+	ListingsCreated(count int)
+	PurchaseInitiated()
+	PaymentProcessed(result string)
+}
+
+// CacheRecorder conta as consultas a uma cache, pelo nome da cache e pelo resultado.
+type CacheRecorder interface {
+	CacheLookup(cache, result string)
+}
+
+const (
+	PaymentApproved = "approved"
+	PaymentCanceled = "canceled"
+
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	c.record = c.record[:0]
+	for _, value := range values {
+		text := formatValue(value)
+		if _, ok := value.(string); ok {
+			text = escapeFormula(text)
+		}
+		c.record = append(c.record, text)
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
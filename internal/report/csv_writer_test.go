@@ -0,0 +1,43 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVWriterSuite struct {
+	suite.Suite
+}
+
+func Test_CSVWriterSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CSVWriterSuite))
+}
+
+func (suite *CSVWriterSuite) Test_WriteRow() {
+	suite.T().Run("should format values and quote when needed", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := report.NewCSVWriter(&buf)
+
+		saleDate := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+		suite.NoError(writer.WriteRow([]any{"sale_id", "brand", "price", "sale_date", "payment_id"}))
+		suite.NoError(writer.WriteRow([]any{"sale-1", "Mercedes, Benz", 150000.5, saleDate, nil}))
+		suite.NoError(writer.Close())
+
+		suite.Equal("sale_id,brand,price,sale_date,payment_id\nsale-1,\"Mercedes, Benz\",150000.50,2026-03-10T14:30:00Z,\n", buf.String())
+	})
+
+	suite.T().Run("should keep text from being read as a formula", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := report.NewCSVWriter(&buf)
+
+		suite.NoError(writer.WriteRow([]any{"=1+1", "+1", "-1", "@SUM(A1)", "\tTab", "\rCR", "Fiat-500", -1.5}))
+		suite.NoError(writer.Close())
+
+		suite.Equal("'=1+1,'+1,'-1,'@SUM(A1),'\tTab,\"'\rCR\",Fiat-500,-1.50\n", buf.String())
+	})
+}
@@ -0,0 +1,8 @@
+package report
+
+// Writer grava um relatório tabular linha por linha, sem manter as linhas em memória.
+// Os valores aceitos são string, float64 e time.Time.
+type Writer interface {
+	WriteRow(values []any) error
+	Close() error
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+var ErrUnknownColumn = errors.New("unknown report column")
+
+type SalesColumn struct {
+	Name  string
+	Value func(sale *domain.Sale, maskCPF bool) any
+}
+
+var salesColumns = []SalesColumn{
+	{Name: "sale_id", Value: func(s *domain.Sale, _ bool) any { return s.ID }},
+	{Name: "vehicle_id", Value: func(s *domain.Sale, _ bool) any { return s.VehicleID }},
+	{Name: "brand", Value: func(s *domain.Sale, _ bool) any { return s.Brand }},
+	{Name: "model", Value: func(s *domain.Sale, _ bool) any { return s.Model }},
+	{Name: "price", Value: func(s *domain.Sale, _ bool) any { return s.Price }},
+	{Name: "status", Value: func(s *domain.Sale, _ bool) any { return string(s.Status) }},
+	{Name: "sale_date", Value: func(s *domain.Sale, _ bool) any {
+		if s.SaleDate == nil {
+			return nil
+		}
+		return *s.SaleDate
+	}},
+	{Name: "payment_id", Value: func(s *domain.Sale, _ bool) any { return s.PaymentID }},
+	{Name: "buyer_cpf", Value: func(s *domain.Sale, maskCPF bool) any {
+		if s.BuyerCPF == nil {
+			return nil
+		}
+		if maskCPF {
+			return domain.MaskCPF(*s.BuyerCPF)
+		}
+		return *s.BuyerCPF
+	}},
+	{Name: "created_at", Value: func(s *domain.Sale, _ bool) any { return s.CreatedAt }},
+}
+
+var DefaultSalesColumns = []string{"sale_id", "vehicle_id", "brand", "model", "price", "sale_date", "payment_id", "buyer_cpf"}
+
+func SalesColumns(names []string) ([]SalesColumn, error) {
+	if len(names) == 0 {
+		names = DefaultSalesColumns
+	}
+
+	columns := make([]SalesColumn, 0, len(names))
+	for _, name := range names {
+		column, ok := findSalesColumn(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func findSalesColumn(name string) (SalesColumn, bool) {
+	for _, column := range salesColumns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return SalesColumn{}, false
+}
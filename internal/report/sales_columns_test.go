@@ -0,0 +1,73 @@
+package report_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/stretchr/testify/suite"
+)
+
+type SalesColumnsSuite struct {
+	suite.Suite
+}
+
+func Test_SalesColumnsSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SalesColumnsSuite))
+}
+
+func (suite *SalesColumnsSuite) Test_SalesColumns() {
+	buyerCPF := "12345678900"
+	saleDate := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	sale := &domain.Sale{
+		ID:        "sale-1",
+		VehicleID: "vehicle-1",
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     50000,
+		Status:    domain.StatusSold,
+		PaymentID: "payment-1",
+		BuyerCPF:  &buyerCPF,
+		SaleDate:  &saleDate,
+	}
+
+	suite.T().Run("should return the default columns when none is given", func(t *testing.T) {
+		columns, err := report.SalesColumns(nil)
+		suite.NoError(err)
+		suite.Len(columns, len(report.DefaultSalesColumns))
+
+		var names []string
+		for _, column := range columns {
+			names = append(names, column.Name)
+		}
+		suite.Equal(report.DefaultSalesColumns, names)
+	})
+
+	suite.T().Run("should extract values and mask cpf on demand", func(t *testing.T) {
+		columns, err := report.SalesColumns([]string{"price", " buyer_cpf ", "sale_date"})
+		suite.NoError(err)
+
+		suite.Equal(50000.0, columns[0].Value(sale, true))
+		suite.Equal("***.456.789-**", columns[1].Value(sale, true))
+		suite.Equal("12345678900", columns[1].Value(sale, false))
+		suite.Equal(saleDate, columns[2].Value(sale, true))
+	})
+
+	suite.T().Run("should return nil for empty optional fields", func(t *testing.T) {
+		columns, err := report.SalesColumns([]string{"buyer_cpf", "sale_date"})
+		suite.NoError(err)
+
+		suite.Nil(columns[0].Value(&domain.Sale{}, true))
+		suite.Nil(columns[1].Value(&domain.Sale{}, true))
+	})
+
+	suite.T().Run("should return error for unknown columns", func(t *testing.T) {
+		columns, err := report.SalesColumns([]string{"brand", "password"})
+		suite.Nil(columns)
+		suite.True(errors.Is(err, report.ErrUnknownColumn))
+		suite.Contains(err.Error(), "password")
+	})
+}
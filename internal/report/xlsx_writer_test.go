@@ -0,0 +1,82 @@
+package report_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/stretchr/testify/suite"
+)
+
+type XLSXWriterSuite struct {
+	suite.Suite
+}
+
+func Test_XLSXWriterSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(XLSXWriterSuite))
+}
+
+func (suite *XLSXWriterSuite) readPart(data []byte, name string) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	suite.Require().NoError(err)
+
+	f, err := archive.Open(name)
+	suite.Require().NoError(err)
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	suite.Require().NoError(err)
+	return string(content)
+}
+
+func (suite *XLSXWriterSuite) Test_WriteRow() {
+	suite.T().Run("should write a workbook with one sheet", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := report.NewXLSXWriter(&buf)
+
+		suite.NoError(writer.WriteRow([]any{"brand", "price"}))
+		suite.NoError(writer.WriteRow([]any{"Fiat & <Co>", 150000.5}))
+		suite.NoError(writer.Close())
+
+		suite.Contains(suite.readPart(buf.Bytes(), "[Content_Types].xml"), "/xl/worksheets/sheet1.xml")
+		suite.Contains(suite.readPart(buf.Bytes(), "xl/workbook.xml"), `<sheet name="Report"`)
+
+		sheet := suite.readPart(buf.Bytes(), "xl/worksheets/sheet1.xml")
+		suite.Contains(sheet, `<row><c t="inlineStr"><is><t xml:space="preserve">brand</t></is></c>`)
+		suite.Contains(sheet, `<t xml:space="preserve">Fiat &amp; &lt;Co&gt;</t>`)
+		suite.Contains(sheet, `<c><v>150000.5</v></c></row>`)
+		suite.Contains(sheet, `</sheetData></worksheet>`)
+	})
+
+	suite.T().Run("should keep text from being read as a formula", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := report.NewXLSXWriter(&buf)
+
+		suite.NoError(writer.WriteRow([]any{"=1+1", "@SUM(A1)", "Fiat-500", -1.5}))
+		suite.NoError(writer.Close())
+
+		sheet := suite.readPart(buf.Bytes(), "xl/worksheets/sheet1.xml")
+		suite.Contains(sheet, `<t xml:space="preserve">&#39;=1+1</t>`)
+		suite.Contains(sheet, `<t xml:space="preserve">&#39;@SUM(A1)</t>`)
+		suite.Contains(sheet, `<t xml:space="preserve">Fiat-500</t>`)
+		suite.Contains(sheet, `<c><v>-1.5</v></c>`)
+	})
+
+	suite.T().Run("should not write anything before the first row", func(t *testing.T) {
+		var buf bytes.Buffer
+		report.NewXLSXWriter(&buf)
+
+		suite.Zero(buf.Len())
+	})
+
+	suite.T().Run("should write a valid empty workbook on close", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer := report.NewXLSXWriter(&buf)
+
+		suite.NoError(writer.Close())
+		suite.Contains(suite.readPart(buf.Bytes(), "xl/worksheets/sheet1.xml"), "<sheetData></sheetData>")
+	})
+}
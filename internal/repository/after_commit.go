@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+// afterCommitKey guarda no ctx as funções agendadas com AfterCommit na transação aberta.
+type afterCommitKey struct{}
+
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+// AfterCommit agenda fn para depois do commit da transação aberta no ctx por um TxManager, na
+// ordem em que foi agendada; se a transação for desfeita, fn não roda. Sem transação no ctx, fn
+// roda na hora. fn recebe o ctx de fora da transação.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// withAfterCommit prepara o ctx de uma transação nova para receber as funções de AfterCommit.
+func withAfterCommit(ctx context.Context) (context.Context, *afterCommitHooks) {
+	hooks := &afterCommitHooks{}
+	return context.WithValue(ctx, afterCommitKey{}, hooks), hooks
+}
+
+func (h *afterCommitHooks) run(ctx context.Context) {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(ctx)
+	}
+}
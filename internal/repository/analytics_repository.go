@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+const (
+	AnalyticsPeriodDay   = "day"
+	AnalyticsPeriodWeek  = "week"
+	AnalyticsPeriodMonth = "month"
+
+	AnalyticsGroupByBrand = "brand"
+	AnalyticsGroupByModel = "model"
+)
+
+type SalesAnalyticsFilter struct {
+	From    time.Time
+	To      time.Time
+	Period  string
+	GroupBy string
+}
+
+//go:generate mockgen -source=analytics_repository.go -destination=./mocks/analytics_repository_mock.go -package=mocks
+type AnalyticsRepository interface {
+	AggregateSales(ctx context.Context, filter SalesAnalyticsFilter) ([]*domain.SalesAggregate, error)
+}
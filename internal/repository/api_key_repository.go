@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+//go:generate mockgen -source=api_key_repository.go -destination=./mocks/api_key_repository_mock.go -package=mocks
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *domain.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+}
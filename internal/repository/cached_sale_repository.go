@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+const availableSalesKey = "showcase:sales:available"
+
+// CachedSaleRepository guarda na cache a lista de vendas disponíveis, a consulta mais frequente
+// do serviço; os outros métodos vão direto para o repositório decorado. Quem altera vendas
+// precisa chamar Invalidate depois de gravar, o que o app faz a cada evento de venda.
+type CachedSaleRepository struct {
+	SaleRepository
+	loader *cache.Loader
+}
+
+func NewCachedSaleRepository(repo SaleRepository, loader *cache.Loader) *CachedSaleRepository {
+	return &CachedSaleRepository{
+		SaleRepository: repo,
+		loader:         loader,
+	}
+}
+
+func (r *CachedSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	data, err := r.loader.Load(ctx, availableSalesKey, func(ctx context.Context) ([]byte, error) {
+		sales, err := r.SaleRepository.GetAvailableByPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(sales)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sales []*domain.Sale
+	if err := json.Unmarshal(data, &sales); err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+// Invalidate descarta as listas guardadas na cache.
+func (r *CachedSaleRepository) Invalidate(ctx context.Context) error {
+	return r.loader.Invalidate(ctx, availableSalesKey)
+}
@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/cache"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	metricMocks "github.com/NicolasNSC/showcase-service-fiap/internal/metrics/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CachedSaleRepositoryTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	inner    *mocks.MockSaleRepository
+	recorder *metricMocks.MockCacheRecorder
+	repo     *repository.CachedSaleRepository
+}
+
+func Test_CachedSaleRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CachedSaleRepositoryTestSuite))
+}
+
+func (suite *CachedSaleRepositoryTestSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.ctx = context.Background()
+	suite.inner = mocks.NewMockSaleRepository(ctrl)
+	suite.recorder = metricMocks.NewMockCacheRecorder(ctrl)
+	loader := cache.NewLoader("available_sales", cache.NewLRU(10), time.Minute, suite.recorder)
+	suite.repo = repository.NewCachedSaleRepository(suite.inner, loader)
+}
+
+func (suite *CachedSaleRepositoryTestSuite) Test_GetAvailableByPrice() {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	available := []*domain.Sale{
+		{ID: "sale-1", VehicleID: "vehicle-1", Brand: "Toyota", Model: "Corolla", Price: 50000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+		{ID: "sale-2", VehicleID: "vehicle-2", Brand: "Honda", Model: "Civic", Price: 60000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+	}
+
+	suite.T().Run("should query the repository once and serve copies from the cache", func(t *testing.T) {
+		suite.inner.EXPECT().GetAvailableByPrice(gomock.Any()).Return(available, nil)
+		gomock.InOrder(
+			suite.recorder.EXPECT().CacheLookup("available_sales", metrics.CacheMiss),
+			suite.recorder.EXPECT().CacheLookup("available_sales", metrics.CacheHit),
+		)
+
+		first, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(available, first)
+		first[0].Price = 1
+
+		second, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(available, second)
+	})
+
+	suite.T().Run("should query the repository again after Invalidate", func(t *testing.T) {
+		suite.NoError(suite.repo.Invalidate(suite.ctx))
+		suite.inner.EXPECT().GetAvailableByPrice(gomock.Any()).Return(available[1:], nil)
+		suite.recorder.EXPECT().CacheLookup("available_sales", metrics.CacheMiss)
+
+		sales, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(available[1:], sales)
+	})
+
+	suite.T().Run("should return the repository error", func(t *testing.T) {
+		suite.NoError(suite.repo.Invalidate(suite.ctx))
+		suite.inner.EXPECT().GetAvailableByPrice(gomock.Any()).Return(nil, errors.New("db error"))
+		suite.recorder.EXPECT().CacheLookup("available_sales", metrics.CacheMiss)
+
+		sales, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.EqualError(err, "db error")
+		suite.Nil(sales)
+	})
+
+	suite.T().Run("should pass the other methods through", func(t *testing.T) {
+		suite.inner.EXPECT().GetSoldByPrice(gomock.Any()).Return(nil, nil).Times(2)
+
+		for range 2 {
+			_, err := suite.repo.GetSoldByPrice(suite.ctx)
+			suite.NoError(err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+// AnalyticsRepositoryIntegrationSuite usa os mesmos dados dos testes das implementações em memória
+// e SQLite, para que as três concordem com o date_trunc e o AVG do Postgres.
+type AnalyticsRepositoryIntegrationSuite struct {
+	suite.Suite
+	repo repository.AnalyticsRepository
+	from time.Time
+	to   time.Time
+}
+
+func Test_AnalyticsRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AnalyticsRepositoryIntegrationSuite))
+}
+
+func (suite *AnalyticsRepositoryIntegrationSuite) SetupTest() {
+	suite.from = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	suite.to = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	db := newTestDatabase(suite.T())
+	sales := repository.NewPostgresSaleRepository(db.db)
+	created := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	// 2026-03-02 é uma segunda-feira.
+	for _, s := range []struct {
+		id       string
+		brand    string
+		model    string
+		price    float64
+		status   domain.SaleStatus
+		saleDate *time.Time
+	}{
+		{"sale-1", "Toyota", "Corolla", 100000, domain.StatusSold, ptr(time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC))},
+		{"sale-2", "Toyota", "Yaris", 60000, domain.StatusSold, ptr(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))},
+		{"sale-3", "Honda", "Civic", 80000, domain.StatusPendingPayment, ptr(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))},
+		{"sale-4", "Honda", "Civic", 70000, domain.StatusSold, ptr(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))},
+		{"sale-5", "Honda", "Fit", 50000, domain.StatusAvailable, nil},
+	} {
+		sale := &domain.Sale{ID: s.id, VehicleID: s.id, Brand: s.brand, Model: s.model, Price: s.price, Status: s.status, SaleDate: s.saleDate, CreatedAt: created, UpdatedAt: created}
+		suite.Require().NoError(sales.Save(context.Background(), sale))
+		suite.Require().NoError(sales.Update(context.Background(), sale))
+	}
+	suite.repo = repository.NewPostgresAnalyticsRepository(db.db)
+}
+
+func (suite *AnalyticsRepositoryIntegrationSuite) Test_AggregateSales() {
+	suite.T().Run("should aggregate the whole range without grouping", func(t *testing.T) {
+		aggregates, err := suite.repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{From: suite.from, To: suite.to})
+
+		suite.NoError(err)
+		suite.Require().Len(aggregates, 1)
+		suite.Nil(aggregates[0].PeriodStart)
+		suite.Equal(2, aggregates[0].UnitsSold)
+		suite.Equal(160000.0, aggregates[0].Revenue)
+		suite.Equal(3, aggregates[0].Reservations)
+		suite.Equal(11*24*time.Hour+5*time.Hour, aggregates[0].AvgTimeToSale)
+	})
+
+	suite.T().Run("should group by week starting on monday and by brand", func(t *testing.T) {
+		aggregates, err := suite.repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{
+			From: suite.from, To: suite.to, Period: repository.AnalyticsPeriodWeek, GroupBy: repository.AnalyticsGroupByBrand,
+		})
+
+		suite.NoError(err)
+		suite.Require().Len(aggregates, 2)
+		suite.True(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC).Equal(*aggregates[0].PeriodStart))
+		suite.Equal("Toyota", aggregates[0].Brand)
+		suite.Equal(2, aggregates[0].UnitsSold)
+		suite.True(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC).Equal(*aggregates[1].PeriodStart))
+		suite.Equal("Honda", aggregates[1].Brand)
+		suite.Zero(aggregates[1].UnitsSold)
+		suite.Equal(1, aggregates[1].Reservations)
+	})
+
+	suite.T().Run("should return one empty row for a range without sales", func(t *testing.T) {
+		aggregates, err := suite.repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{From: suite.from.AddDate(0, -1, 0), To: suite.from})
+
+		suite.NoError(err)
+		suite.Require().Len(aggregates, 1)
+		suite.Zero(aggregates[0].UnitsSold)
+		suite.Zero(aggregates[0].Reservations)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
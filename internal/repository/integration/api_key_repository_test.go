@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyRepositoryIntegrationSuite struct {
+	suite.Suite
+	ctx  context.Context
+	repo repository.APIKeyRepository
+	now  time.Time
+}
+
+func Test_APIKeyRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(APIKeyRepositoryIntegrationSuite))
+}
+
+func (suite *APIKeyRepositoryIntegrationSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.repo = repository.NewPostgresAPIKeyRepository(newTestDatabase(suite.T()).db)
+	suite.now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *APIKeyRepositoryIntegrationSuite) Test_Lifecycle() {
+	expires := suite.now.AddDate(0, 1, 0)
+	key := &domain.APIKey{
+		ID: "key-1", Name: "payments", Prefix: "sk_abc", KeyHash: "hash-1",
+		Scopes: []string{"payment-gateway", "sales:read"}, ExpiresAt: &expires, CreatedAt: suite.now,
+	}
+
+	suite.T().Run("should round trip the scopes array and the optional dates", func(t *testing.T) {
+		suite.Require().NoError(suite.repo.Save(suite.ctx, key))
+
+		found, err := suite.repo.GetByHash(suite.ctx, "hash-1")
+
+		suite.NoError(err)
+		suite.Equal(key.Scopes, found.Scopes)
+		suite.True(expires.Equal(*found.ExpiresAt))
+		suite.Nil(found.RevokedAt)
+	})
+
+	suite.T().Run("should reject a duplicated hash with a unique violation", func(t *testing.T) {
+		err := suite.repo.Save(suite.ctx, &domain.APIKey{ID: "key-2", Name: "other", Prefix: "sk_def", KeyHash: "hash-1", Scopes: []string{"sales:read"}, CreatedAt: suite.now})
+
+		suite.Equal(uniqueViolation, pgErrorCode(err))
+	})
+
+	suite.T().Run("should revoke only once", func(t *testing.T) {
+		suite.NoError(suite.repo.Revoke(suite.ctx, "key-1", suite.now.Add(time.Hour)))
+		suite.ErrorIs(suite.repo.Revoke(suite.ctx, "key-1", suite.now.Add(2*time.Hour)), repository.ErrAPIKeyNotFound)
+
+		keys, err := suite.repo.List(suite.ctx)
+		suite.NoError(err)
+		suite.Require().Len(keys, 1)
+		suite.True(suite.now.Add(time.Hour).Equal(*keys[0].RevokedAt))
+	})
+}
@@ -0,0 +1,169 @@
+//go:build integration
+
+// Package integration roda os repositórios contra um Postgres de verdade. Use
+// go test -tags integration ./internal/repository/integration/... com TEST_DATABASE_URL ou com os
+// binários do Postgres (initdb e pg_ctl) instalados; sem nenhum dos dois, os testes são pulados.
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Códigos SQLSTATE usados nos testes.
+const (
+	uniqueViolation = "23505"
+	checkViolation  = "23514"
+	stringTooLong   = "22001"
+)
+
+// postgresOptions deixa o servidor descartável mais rápido; os dados não precisam sobreviver a uma
+// queda.
+const postgresOptions = "-c listen_addresses=127.0.0.1 -c fsync=off -c synchronous_commit=off -c full_page_writes=off"
+
+var databaseURL string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	databaseURL = os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		server, err := startPostgres()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipping integration tests: set TEST_DATABASE_URL or install postgres (%v)\n", err)
+			return 0
+		}
+		defer server.stop()
+		databaseURL = server.url
+	}
+
+	return m.Run()
+}
+
+// testDatabase é um schema exclusivo de um teste, com as migrações aplicadas, acessível pelos
+// dois pools usados pelos repositórios.
+type testDatabase struct {
+	db   *sql.DB
+	pool *pgxpool.Pool
+}
+
+// newTestDatabase abre os dois pools em um schema exclusivo de t.
+func newTestDatabase(t *testing.T) *testDatabase {
+	t.Helper()
+	schemaURL := repositorytest.PostgresSchema(t, databaseURL)
+
+	db, err := sql.Open("pgx", schemaURL)
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	pool, err := pgxpool.New(context.Background(), schemaURL)
+	if err != nil {
+		t.Fatalf("could not open pgx pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	return &testDatabase{db: db, pool: pool}
+}
+
+// pgErrorCode devolve o SQLSTATE de err, ou vazio quando não é um erro do Postgres.
+func pgErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// ephemeralPostgres é um servidor descartável criado com initdb em um diretório temporário.
+type ephemeralPostgres struct {
+	pgCtl string
+	dir   string
+	url   string
+}
+
+func startPostgres() (*ephemeralPostgres, error) {
+	binDir, err := postgresBinDir()
+	if err != nil {
+		return nil, err
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "showcase-postgres-")
+	if err != nil {
+		return nil, err
+	}
+
+	server := &ephemeralPostgres{
+		pgCtl: filepath.Join(binDir, "pg_ctl"),
+		dir:   dir,
+		url:   fmt.Sprintf("postgres://postgres@127.0.0.1:%d/postgres?sslmode=disable", port),
+	}
+	data := filepath.Join(dir, "data")
+
+	initdb := exec.Command(filepath.Join(binDir, "initdb"), "-D", data, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync")
+	if out, err := initdb.CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("initdb: %w: %s", err, out)
+	}
+
+	options := fmt.Sprintf("-p %d -k %s %s", port, dir, postgresOptions)
+	start := exec.Command(server.pgCtl, "-D", data, "-o", options, "-l", filepath.Join(dir, "postgres.log"), "-w", "start")
+	if out, err := start.CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("pg_ctl start: %w: %s", err, out)
+	}
+	return server, nil
+}
+
+func (p *ephemeralPostgres) stop() {
+	exec.Command(p.pgCtl, "-D", filepath.Join(p.dir, "data"), "-m", "immediate", "-w", "stop").Run()
+	os.RemoveAll(p.dir)
+}
+
+// postgresBinDir procura initdb em PG_BIN, no PATH e em /usr/lib/postgresql/*/bin, onde o Debian
+// e o Ubuntu instalam o servidor.
+func postgresBinDir() (string, error) {
+	if dir := os.Getenv("PG_BIN"); dir != "" {
+		return dir, nil
+	}
+	if initdb, err := exec.LookPath("initdb"); err == nil {
+		return filepath.Dir(initdb), nil
+	}
+
+	dirs, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, "initdb")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", errors.New("initdb not found")
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,237 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+// saleRepositories são as implementações de SaleRepository sobre o Postgres.
+var saleRepositories = map[string]func(db *testDatabase) repository.SaleRepository{
+	"database/sql": func(db *testDatabase) repository.SaleRepository { return repository.NewPostgresSaleRepository(db.db) },
+	"pgx":          func(db *testDatabase) repository.SaleRepository { return repository.NewPgxSaleRepository(db.pool) },
+}
+
+func Test_SaleRepositoryContract(t *testing.T) {
+	t.Parallel()
+	for name, newRepo := range saleRepositories {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			suite.Run(t, &repositorytest.SaleRepositorySuite{New: func(t *testing.T) repository.SaleRepository {
+				return newRepo(newTestDatabase(t))
+			}})
+		})
+	}
+}
+
+// SaleRepositoryIntegrationSuite cobre o que só o Postgres garante: constraints do esquema e
+// escritas concorrentes.
+type SaleRepositoryIntegrationSuite struct {
+	suite.Suite
+	newRepo func(db *testDatabase) repository.SaleRepository
+
+	ctx  context.Context
+	repo repository.SaleRepository
+	now  time.Time
+}
+
+func Test_SaleRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+	for name, newRepo := range saleRepositories {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			suite.Run(t, &SaleRepositoryIntegrationSuite{newRepo: newRepo})
+		})
+	}
+}
+
+func (suite *SaleRepositoryIntegrationSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.repo = suite.newRepo(newTestDatabase(suite.T()))
+	suite.now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *SaleRepositoryIntegrationSuite) sale(id string) *domain.Sale {
+	return &domain.Sale{
+		ID:        id,
+		VehicleID: "vehicle-" + id,
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     100000,
+		Status:    domain.StatusAvailable,
+		CreatedAt: suite.now,
+		UpdatedAt: suite.now,
+		Version:   1,
+	}
+}
+
+func (suite *SaleRepositoryIntegrationSuite) Test_Constraints() {
+	suite.T().Run("should reject a duplicated id with a unique violation", func(t *testing.T) {
+		suite.Require().NoError(suite.repo.Save(suite.ctx, suite.sale("sale-1")))
+
+		err := suite.repo.Save(suite.ctx, suite.sale("sale-1"))
+
+		suite.Equal(uniqueViolation, pgErrorCode(err))
+	})
+
+	suite.T().Run("should reject an unknown status", func(t *testing.T) {
+		sale := suite.sale("sale-2")
+		sale.Status = "RESERVED"
+
+		err := suite.repo.Save(suite.ctx, sale)
+
+		suite.Equal(checkViolation, pgErrorCode(err))
+	})
+
+	suite.T().Run("should reject a brand longer than the column", func(t *testing.T) {
+		sale := suite.sale("sale-3")
+		sale.Brand = strings.Repeat("x", 101)
+
+		err := suite.repo.Save(suite.ctx, sale)
+
+		suite.Equal(stringTooLong, pgErrorCode(err))
+	})
+
+	suite.T().Run("should round prices to cents", func(t *testing.T) {
+		sale := suite.sale("sale-4")
+		sale.Price = 99999.999
+		suite.Require().NoError(suite.repo.Save(suite.ctx, sale))
+
+		found, err := suite.repo.GetByID(suite.ctx, "sale-4")
+
+		suite.NoError(err)
+		suite.Equal(100000.0, found.Price)
+	})
+
+	suite.T().Run("should not match sales without payment for an empty payment id", func(t *testing.T) {
+		_, err := suite.repo.GetByPaymentID(suite.ctx, "")
+
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *SaleRepositoryIntegrationSuite) Test_Concurrency() {
+	suite.T().Run("should keep exactly one sale when the same id is saved concurrently", func(t *testing.T) {
+		errs := suite.parallel(10, func(int) error {
+			return suite.repo.Save(suite.ctx, suite.sale("sale-race"))
+		})
+
+		var saved int
+		for _, err := range errs {
+			if err == nil {
+				saved++
+				continue
+			}
+			suite.Equal(uniqueViolation, pgErrorCode(err))
+		}
+		suite.Equal(1, saved)
+	})
+
+	suite.T().Run("should apply concurrent updates to different sales", func(t *testing.T) {
+		const total = 20
+		for i := range total {
+			suite.Require().NoError(suite.repo.Save(suite.ctx, suite.sale(fmt.Sprintf("sale-update-%d", i))))
+		}
+
+		errs := suite.parallel(total, func(i int) error {
+			sale := suite.sale(fmt.Sprintf("sale-update-%d", i))
+			saleDate := suite.now.Add(time.Duration(i) * time.Minute)
+			sale.Status = domain.StatusSold
+			sale.PaymentID = fmt.Sprintf("payment-%d", i)
+			sale.SaleDate = &saleDate
+			return suite.repo.Update(suite.ctx, sale)
+		})
+
+		for _, err := range errs {
+			suite.NoError(err)
+		}
+		sold, err := suite.repo.GetSoldByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Len(sold, total)
+	})
+
+	suite.T().Run("should apply only one of several updates read from the same version", func(t *testing.T) {
+		suite.Require().NoError(suite.repo.Save(suite.ctx, suite.sale("sale-contended")))
+
+		errs := suite.parallel(10, func(i int) error {
+			sale := suite.sale("sale-contended")
+			sale.Price = float64(50000 + i)
+			return suite.repo.Update(suite.ctx, sale)
+		})
+
+		var applied int
+		for _, err := range errs {
+			if err == nil {
+				applied++
+				continue
+			}
+			suite.ErrorIs(err, repository.ErrConcurrentModification)
+		}
+		suite.Equal(1, applied)
+
+		found, err := suite.repo.GetByID(suite.ctx, "sale-contended")
+		suite.NoError(err)
+		suite.Equal(2, found.Version)
+	})
+
+	suite.T().Run("should commit only one of two overlapping batches", func(t *testing.T) {
+		batch := func(prefix string) []*domain.Sale {
+			return []*domain.Sale{suite.sale(prefix + "-1"), suite.sale("sale-shared"), suite.sale(prefix + "-2")}
+		}
+		batches := [][]*domain.Sale{batch("batch-a"), batch("batch-b")}
+
+		errs := suite.parallel(len(batches), func(i int) error {
+			return suite.repo.SaveBatch(suite.ctx, batches[i])
+		})
+
+		committed := -1
+		for i, err := range errs {
+			if err == nil {
+				suite.Equal(-1, committed, "both batches were committed")
+				committed = i
+				continue
+			}
+			suite.Equal(uniqueViolation, pgErrorCode(err))
+		}
+		suite.Require().NotEqual(-1, committed, "no batch was committed")
+
+		for i, batch := range batches {
+			for _, sale := range []*domain.Sale{batch[0], batch[2]} {
+				_, err := suite.repo.GetByID(suite.ctx, sale.ID)
+				if i == committed {
+					suite.NoError(err)
+				} else {
+					suite.ErrorIs(err, repository.ErrSaleNotFound)
+				}
+			}
+		}
+	})
+}
+
+// parallel roda fn n vezes ao mesmo tempo e devolve os erros na ordem dos índices.
+func (suite *SaleRepositoryIntegrationSuite) parallel(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
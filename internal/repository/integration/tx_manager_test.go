@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// txRepositories associam cada repositório ao gerenciador de transações da mesma conexão.
+var txRepositories = map[string]func(db *testDatabase) (repository.SaleRepository, repository.TxManager){
+	"database/sql": func(db *testDatabase) (repository.SaleRepository, repository.TxManager) {
+		return repository.NewPostgresSaleRepository(db.db), repository.NewSQLTxManager(db.db)
+	},
+	"pgx": func(db *testDatabase) (repository.SaleRepository, repository.TxManager) {
+		return repository.NewPgxSaleRepository(db.pool), repository.NewPgxTxManager(db.pool)
+	},
+}
+
+func Test_TxManagerIntegration(t *testing.T) {
+	t.Parallel()
+	for name, newRepo := range txRepositories {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			repo, txManager := newRepo(newTestDatabase(t))
+			now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+			sale := func(id string) *domain.Sale {
+				return &domain.Sale{ID: id, VehicleID: "vehicle-" + id, Brand: "Toyota", Model: "Corolla", Price: 100000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+			}
+			require.NoError(t, repo.Save(ctx, sale("sale-1")))
+
+			err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+				current, err := repo.GetByID(ctx, "sale-1")
+				if err != nil {
+					return err
+				}
+				current.Price = 90000
+				if err := repo.Update(ctx, current); err != nil {
+					return err
+				}
+				if err := repo.SaveBatch(ctx, []*domain.Sale{sale("sale-2"), sale("sale-3")}); err != nil {
+					return err
+				}
+				return errors.New("history error")
+			})
+			require.EqualError(t, err, "history error")
+
+			found, err := repo.GetByID(ctx, "sale-1")
+			require.NoError(t, err)
+			require.Equal(t, 100000.0, found.Price)
+			require.Equal(t, 1, found.Version)
+			_, err = repo.GetByID(ctx, "sale-2")
+			require.ErrorIs(t, err, repository.ErrSaleNotFound)
+
+			err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+				return repo.SaveBatch(ctx, []*domain.Sale{sale("sale-2")})
+			})
+			require.NoError(t, err)
+			_, err = repo.GetByID(ctx, "sale-2")
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+// memoryAnalyticsRepository agrega as vendas de um MemorySaleRepository com as mesmas regras
+// da consulta do Postgres. Os períodos são truncados em UTC.
+type memoryAnalyticsRepository struct {
+	sales *MemorySaleRepository
+}
+
+func NewMemoryAnalyticsRepository(sales *MemorySaleRepository) AnalyticsRepository {
+	return &memoryAnalyticsRepository{
+		sales: sales,
+	}
+}
+
+type aggregateKey struct {
+	period time.Time
+	brand  string
+	model  string
+}
+
+func (r *memoryAnalyticsRepository) AggregateSales(ctx context.Context, filter SalesAnalyticsFilter) ([]*domain.SalesAggregate, error) {
+	switch filter.Period {
+	case "", AnalyticsPeriodDay, AnalyticsPeriodWeek, AnalyticsPeriodMonth:
+	default:
+		return nil, fmt.Errorf("invalid analytics period: %s", filter.Period)
+	}
+	switch filter.GroupBy {
+	case "", AnalyticsGroupByBrand, AnalyticsGroupByModel:
+	default:
+		return nil, fmt.Errorf("invalid analytics grouping: %s", filter.GroupBy)
+	}
+
+	sales := r.sales.filter(func(s *domain.Sale) bool {
+		return s.Status != domain.StatusAvailable && s.SaleDate != nil &&
+			!s.SaleDate.Before(filter.From) && s.SaleDate.Before(filter.To)
+	})
+
+	aggregates := map[aggregateKey]*domain.SalesAggregate{}
+	totalSeconds := map[aggregateKey]float64{}
+	var keys []aggregateKey
+	for _, sale := range sales {
+		key := aggregateKey{}
+		if filter.Period != "" {
+			key.period = truncatePeriod(*sale.SaleDate, filter.Period)
+		}
+		if filter.GroupBy != "" {
+			key.brand = sale.Brand
+		}
+		if filter.GroupBy == AnalyticsGroupByModel {
+			key.model = sale.Model
+		}
+
+		aggregate, ok := aggregates[key]
+		if !ok {
+			aggregate = &domain.SalesAggregate{Brand: key.brand, Model: key.model}
+			if filter.Period != "" {
+				period := key.period
+				aggregate.PeriodStart = &period
+			}
+			aggregates[key] = aggregate
+			keys = append(keys, key)
+		}
+
+		aggregate.Reservations++
+		if sale.Status == domain.StatusSold {
+			aggregate.UnitsSold++
+			aggregate.Revenue += sale.Price
+			totalSeconds[key] += sale.SaleDate.Sub(sale.CreatedAt).Seconds()
+		}
+	}
+
+	// Sem agrupamento, o Postgres devolve uma linha mesmo sem vendas.
+	if filter.Period == "" && filter.GroupBy == "" && len(keys) == 0 {
+		return []*domain.SalesAggregate{{}}, nil
+	}
+
+	slices.SortFunc(keys, func(a, b aggregateKey) int {
+		return cmp.Or(a.period.Compare(b.period), cmp.Compare(a.brand, b.brand), cmp.Compare(a.model, b.model))
+	})
+
+	result := make([]*domain.SalesAggregate, 0, len(keys))
+	for _, key := range keys {
+		aggregate := aggregates[key]
+		if aggregate.UnitsSold > 0 {
+			aggregate.AvgTimeToSale = time.Duration(totalSeconds[key] / float64(aggregate.UnitsSold) * float64(time.Second))
+		}
+		result = append(result, aggregate)
+	}
+	return result, nil
+}
+
+// truncatePeriod equivale ao date_trunc do Postgres: semanas começam na segunda-feira.
+func truncatePeriod(t time.Time, period string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case AnalyticsPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case AnalyticsPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
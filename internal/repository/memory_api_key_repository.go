@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+type memoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[string]*domain.APIKey
+}
+
+func NewMemoryAPIKeyRepository() APIKeyRepository {
+	return &memoryAPIKeyRepository{
+		keys: map[string]*domain.APIKey{},
+	}
+}
+
+func (r *memoryAPIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID == key.ID || existing.KeyHash == key.KeyHash {
+			return fmt.Errorf("api key %s already exists", key.ID)
+		}
+	}
+	r.keys[key.ID] = cloneAPIKey(key)
+	onRollback(ctx, func() { r.restore(key.ID, nil) })
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			return cloneAPIKey(key), nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+func (r *memoryAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*domain.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+	slices.SortFunc(keys, func(a, b *domain.APIKey) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return ErrAPIKeyNotFound
+	}
+	previous := cloneAPIKey(key)
+	key.RevokedAt = &revokedAt
+	onRollback(ctx, func() { r.restore(id, previous) })
+	return nil
+}
+
+// restore volta a chave ao estado anterior a uma escrita desfeita; previous nil a remove.
+func (r *memoryAPIKeyRepository) restore(id string, previous *domain.APIKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous == nil {
+		delete(r.keys, id)
+		return
+	}
+	r.keys[id] = previous
+}
+
+func cloneAPIKey(key *domain.APIKey) *domain.APIKey {
+	clone := *key
+	clone.Scopes = slices.Clone(key.Scopes)
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return &clone
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+// MemorySaleRepository guarda as vendas em memória, com a mesma semântica do Postgres. Serve
+// para desenvolvimento local e testes; os dados se perdem quando o processo termina. Dentro de
+// um WithinTx do NewMemoryTxManager, as escritas são desfeitas se a transação falhar.
+type MemorySaleRepository struct {
+	mu    sync.RWMutex
+	sales map[string]*domain.Sale
+}
+
+func NewMemorySaleRepository() *MemorySaleRepository {
+	return &MemorySaleRepository{
+		sales: map[string]*domain.Sale{},
+	}
+}
+
+func (r *MemorySaleRepository) Save(ctx context.Context, sale *domain.Sale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sales[sale.ID]; ok {
+		return fmt.Errorf("sale %s already exists", sale.ID)
+	}
+	r.sales[sale.ID] = cloneSale(sale)
+	onRollback(ctx, func() { r.restore(sale.ID, nil) })
+	return nil
+}
+
+// SaveBatch grava todas as vendas ou nenhuma, como a transação do Postgres.
+func (r *MemorySaleRepository) SaveBatch(ctx context.Context, sales []*domain.Sale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool, len(sales))
+	for _, sale := range sales {
+		if _, ok := r.sales[sale.ID]; ok || seen[sale.ID] {
+			return fmt.Errorf("sale %s already exists", sale.ID)
+		}
+		seen[sale.ID] = true
+	}
+	for _, sale := range sales {
+		r.sales[sale.ID] = cloneSale(sale)
+		onRollback(ctx, func() { r.restore(sale.ID, nil) })
+	}
+	return nil
+}
+
+// Update substitui a venda pelo ID se ela ainda estiver em sale.Version, como o UPDATE
+// condicionado do Postgres.
+func (r *MemorySaleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.sales[sale.ID]
+	if !ok {
+		return ErrSaleNotFound
+	}
+	if current.Version != sale.Version {
+		return &ConcurrentModificationError{SaleID: sale.ID, Expected: sale.Version, Current: current.Version}
+	}
+	updated := cloneSale(sale)
+	updated.CreatedAt = current.CreatedAt
+	updated.Version++
+	r.sales[sale.ID] = updated
+	sale.Version++
+	onRollback(ctx, func() { r.restore(sale.ID, current) })
+	return nil
+}
+
+// restore volta a venda ao estado anterior a uma escrita desfeita; previous nil a remove.
+func (r *MemorySaleRepository) restore(id string, previous *domain.Sale) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous == nil {
+		delete(r.sales, id)
+		return
+	}
+	r.sales[id] = previous
+}
+
+func (r *MemorySaleRepository) GetByID(ctx context.Context, id string) (*domain.Sale, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sale, ok := r.sales[id]
+	if !ok {
+		return nil, ErrSaleNotFound
+	}
+	return cloneSale(sale), nil
+}
+
+func (r *MemorySaleRepository) GetByVehicleID(ctx context.Context, vehicleID string) (*domain.Sale, error) {
+	sale := r.first(func(s *domain.Sale) bool { return s.VehicleID == vehicleID })
+	if sale == nil {
+		return nil, errSaleNotFoundByVehicle
+	}
+	return sale, nil
+}
+
+func (r *MemorySaleRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Sale, error) {
+	sale := r.first(func(s *domain.Sale) bool { return s.PaymentID != "" && s.PaymentID == paymentID })
+	if sale == nil {
+		return nil, errSaleNotFoundByPayment
+	}
+	return sale, nil
+}
+
+// GetByBuyerCPF aceita o CPF com ou sem pontuação, como a implementação do Postgres.
+func (r *MemorySaleRepository) GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error) {
+	digits := domain.NormalizeCPF(buyerCPF)
+	formatted := domain.FormatCPF(digits)
+
+	sales := r.filter(func(s *domain.Sale) bool {
+		return s.BuyerCPF != nil && (*s.BuyerCPF == digits || *s.BuyerCPF == formatted)
+	})
+	// sale_date DESC NULLS LAST, created_at DESC
+	slices.SortStableFunc(sales, func(a, b *domain.Sale) int {
+		switch {
+		case a.SaleDate == nil && b.SaleDate != nil:
+			return 1
+		case a.SaleDate != nil && b.SaleDate == nil:
+			return -1
+		case a.SaleDate != nil && !a.SaleDate.Equal(*b.SaleDate):
+			return b.SaleDate.Compare(*a.SaleDate)
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	total := len(sales)
+	start := min(max(offset, 0), total)
+	end := min(start+max(limit, 0), total)
+	return sales[start:end], total, nil
+}
+
+func (r *MemorySaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	return r.byPrice(domain.StatusAvailable), nil
+}
+
+func (r *MemorySaleRepository) GetSoldByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	return r.byPrice(domain.StatusSold), nil
+}
+
+func (r *MemorySaleRepository) CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := map[domain.SaleStatus]int{}
+	for _, sale := range r.sales {
+		counts[sale.Status]++
+	}
+	return counts, nil
+}
+
+// ForEachSold percorre uma cópia das vendas, então fn pode chamar o repositório sem deadlock.
+func (r *MemorySaleRepository) ForEachSold(ctx context.Context, from, to time.Time, fn func(sale *domain.Sale) error) error {
+	sales := r.soldBetween(from, to)
+	slices.SortStableFunc(sales, func(a, b *domain.Sale) int { return a.SaleDate.Compare(*b.SaleDate) })
+
+	for _, sale := range sales {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(sale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemorySaleRepository) soldBetween(from, to time.Time) []*domain.Sale {
+	return r.filter(func(s *domain.Sale) bool {
+		return s.Status == domain.StatusSold && s.SaleDate != nil && !s.SaleDate.Before(from) && s.SaleDate.Before(to)
+	})
+}
+
+func (r *MemorySaleRepository) byPrice(status domain.SaleStatus) []*domain.Sale {
+	sales := r.filter(func(s *domain.Sale) bool { return s.Status == status })
+	slices.SortStableFunc(sales, func(a, b *domain.Sale) int {
+		return cmp.Or(cmp.Compare(a.Price, b.Price), cmp.Compare(a.ID, b.ID))
+	})
+	return sales
+}
+
+// first devolve a venda mais antiga que satisfaz match, para que o resultado não dependa da
+// ordem do map.
+func (r *MemorySaleRepository) first(match func(*domain.Sale) bool) *domain.Sale {
+	sales := r.filter(match)
+	if len(sales) == 0 {
+		return nil
+	}
+	return slices.MinFunc(sales, func(a, b *domain.Sale) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// filter devolve cópias das vendas que satisfazem match.
+func (r *MemorySaleRepository) filter(match func(*domain.Sale) bool) []*domain.Sale {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sales := []*domain.Sale{}
+	for _, sale := range r.sales {
+		if match(sale) {
+			sales = append(sales, cloneSale(sale))
+		}
+	}
+	return sales
+}
+
+// cloneSale copia a venda, incluindo os ponteiros, para que quem chama não altere o estado
+// guardado.
+func cloneSale(sale *domain.Sale) *domain.Sale {
+	clone := *sale
+	if sale.BuyerCPF != nil {
+		cpf := *sale.BuyerCPF
+		clone.BuyerCPF = &cpf
+	}
+	if sale.SaleDate != nil {
+		saleDate := *sale.SaleDate
+		clone.SaleDate = &saleDate
+	}
+	return &clone
+}
@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func Test_MemorySaleRepositoryContract(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &repositorytest.SaleRepositorySuite{New: func(t *testing.T) repository.SaleRepository {
+		return repository.NewMemorySaleRepository()
+	}})
+}
+
+type MemorySaleRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_MemorySaleRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MemorySaleRepositoryTestSuite))
+}
+
+func (suite *MemorySaleRepositoryTestSuite) Test_Isolation() {
+	suite.T().Run("should not share state with the caller", func(t *testing.T) {
+		repo := repository.NewMemorySaleRepository()
+		cpf := "12345678900"
+		sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Status: domain.StatusSold, BuyerCPF: &cpf}
+		suite.Require().NoError(repo.Save(context.Background(), sale))
+
+		sale.Status = domain.StatusCanceled
+		cpf = "00000000000"
+		found, err := repo.GetByID(context.Background(), "sale-1")
+		suite.Require().NoError(err)
+		found.Price = 1
+
+		again, err := repo.GetByID(context.Background(), "sale-1")
+		suite.NoError(err)
+		suite.Equal(domain.StatusSold, again.Status)
+		suite.Equal("12345678900", *again.BuyerCPF)
+		suite.Zero(again.Price)
+	})
+}
+
+func (suite *MemorySaleRepositoryTestSuite) Test_Concurrency() {
+	suite.T().Run("should be safe for concurrent use", func(t *testing.T) {
+		repo := repository.NewMemorySaleRepository()
+		ctx := context.Background()
+		now := time.Now()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sale := &domain.Sale{ID: fmt.Sprintf("sale-%d", i), VehicleID: fmt.Sprintf("vehicle-%d", i), Price: float64(i), Status: domain.StatusAvailable, CreatedAt: now}
+				suite.NoError(repo.Save(ctx, sale))
+				sale.Status = domain.StatusSold
+				suite.NoError(repo.Update(ctx, sale))
+				_, err := repo.GetAvailableByPrice(ctx)
+				suite.NoError(err)
+			}()
+		}
+		wg.Wait()
+
+		counts, err := repo.CountByStatus(ctx)
+		suite.NoError(err)
+		suite.Equal(map[domain.SaleStatus]int{domain.StatusSold: 20}, counts)
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryTxKey struct{}
+
+// memoryTx acumula as funções que desfazem as escritas feitas nos repositórios em memória.
+type memoryTx struct {
+	mu   sync.Mutex
+	undo []func()
+}
+
+// onRollback registra como desfazer uma escrita, se houver transação no ctx.
+func onRollback(ctx context.Context, undo func()) {
+	tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx)
+	if !ok {
+		return
+	}
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.undo = append(tx.undo, undo)
+}
+
+func (tx *memoryTx) rollback() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+}
+
+// memoryTxManager desfaz as escritas dos repositórios em memória quando fn falha. Não há
+// isolamento: as escritas ficam visíveis para outras requisições antes do fim da transação.
+type memoryTxManager struct{}
+
+func NewMemoryTxManager() TxManager {
+	return memoryTxManager{}
+}
+
+func (memoryTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		return fn(ctx)
+	}
+
+	tx := &memoryTx{}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			panic(p)
+		}
+	}()
+
+	txCtx, hooks := withAfterCommit(context.WithValue(ctx, memoryTxKey{}, tx))
+	if err := fn(txCtx); err != nil {
+		tx.rollback()
+		return err
+	}
+	hooks.run(ctx)
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryTxManagerTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	txManager repository.TxManager
+	repo      *repository.MemorySaleRepository
+}
+
+func Test_MemoryTxManagerTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MemoryTxManagerTestSuite))
+}
+
+func (suite *MemoryTxManagerTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.txManager = repository.NewMemoryTxManager()
+	suite.repo = repository.NewMemorySaleRepository()
+
+	now := time.Now()
+	existing := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Price: 100, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+	suite.Require().NoError(suite.repo.Save(suite.ctx, existing))
+}
+
+// write altera a venda existente e cria outra, como um caso de uso que grava várias coisas juntas.
+func (suite *MemoryTxManagerTestSuite) write(ctx context.Context) error {
+	sale, err := suite.repo.GetByID(ctx, "sale-1")
+	if err != nil {
+		return err
+	}
+	sale.Price = 200
+	if err := suite.repo.Update(ctx, sale); err != nil {
+		return err
+	}
+	now := time.Now()
+	return suite.repo.SaveBatch(ctx, []*domain.Sale{
+		{ID: "sale-2", VehicleID: "vehicle-2", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1},
+	})
+}
+
+func (suite *MemoryTxManagerTestSuite) assertUntouched() {
+	found, err := suite.repo.GetByID(suite.ctx, "sale-1")
+	suite.NoError(err)
+	suite.Equal(100.0, found.Price)
+	suite.Equal(1, found.Version)
+	_, err = suite.repo.GetByID(suite.ctx, "sale-2")
+	suite.ErrorIs(err, repository.ErrSaleNotFound)
+}
+
+func (suite *MemoryTxManagerTestSuite) Test_Commit() {
+	err := suite.txManager.WithinTx(suite.ctx, suite.write)
+	suite.NoError(err)
+
+	found, err := suite.repo.GetByID(suite.ctx, "sale-1")
+	suite.NoError(err)
+	suite.Equal(200.0, found.Price)
+	_, err = suite.repo.GetByID(suite.ctx, "sale-2")
+	suite.NoError(err)
+}
+
+func (suite *MemoryTxManagerTestSuite) Test_RollbackOnError() {
+	err := suite.txManager.WithinTx(suite.ctx, func(ctx context.Context) error {
+		if err := suite.write(ctx); err != nil {
+			return err
+		}
+		return errors.New("history error")
+	})
+	suite.EqualError(err, "history error")
+	suite.assertUntouched()
+}
+
+func (suite *MemoryTxManagerTestSuite) Test_RollbackOnPanic() {
+	suite.PanicsWithValue("boom", func() {
+		_ = suite.txManager.WithinTx(suite.ctx, func(ctx context.Context) error {
+			if err := suite.write(ctx); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+	suite.assertUntouched()
+}
+
+func (suite *MemoryTxManagerTestSuite) Test_Nested() {
+	err := suite.txManager.WithinTx(suite.ctx, func(ctx context.Context) error {
+		if err := suite.txManager.WithinTx(ctx, suite.write); err != nil {
+			return err
+		}
+		return errors.New("outer error")
+	})
+	suite.EqualError(err, "outer error")
+	suite.assertUntouched()
+}
+
+func (suite *MemoryTxManagerTestSuite) Test_AfterCommit() {
+	suite.T().Run("should run the hook right away without a transaction", func(t *testing.T) {
+		called := false
+		repository.AfterCommit(suite.ctx, func(context.Context) { called = true })
+		suite.True(called)
+	})
+
+	suite.T().Run("should run the hook after the commit", func(t *testing.T) {
+		called := false
+		err := suite.txManager.WithinTx(suite.ctx, func(ctx context.Context) error {
+			repository.AfterCommit(ctx, func(context.Context) { called = true })
+			suite.False(called)
+			return nil
+		})
+		suite.NoError(err)
+		suite.True(called)
+	})
+
+	suite.T().Run("should drop the hook when the transaction rolls back", func(t *testing.T) {
+		called := false
+		err := suite.txManager.WithinTx(suite.ctx, func(ctx context.Context) error {
+			repository.AfterCommit(ctx, func(context.Context) { called = true })
+			return errors.New("outer error")
+		})
+		suite.EqualError(err, "outer error")
+		suite.False(called)
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: analytics_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=analytics_repository.go -destination=./mocks/analytics_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	repository "github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AggregateSales mocks base method.
+func (m *MockAnalyticsRepository) AggregateSales(ctx context.Context, filter repository.SalesAnalyticsFilter) ([]*domain.SalesAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateSales", ctx, filter)
+	ret0, _ := ret[0].([]*domain.SalesAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateSales indicates an expected call of AggregateSales.
+func (mr *MockAnalyticsRepositoryMockRecorder) AggregateSales(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateSales", reflect.TypeOf((*MockAnalyticsRepository)(nil).AggregateSales), ctx, filter)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,35 @@ func (m *MockSaleRepository) EXPECT() *MockSaleRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByStatus mocks base method.
+func (m *MockSaleRepository) CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", ctx)
+	ret0, _ := ret[0].(map[domain.SaleStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockSaleRepositoryMockRecorder) CountByStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockSaleRepository)(nil).CountByStatus), ctx)
+}
+
+// ForEachSold mocks base method.
+func (m *MockSaleRepository) ForEachSold(ctx context.Context, from, to time.Time, fn func(*domain.Sale) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachSold", ctx, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachSold indicates an expected call of ForEachSold.
+func (mr *MockSaleRepositoryMockRecorder) ForEachSold(ctx, from, to, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachSold", reflect.TypeOf((*MockSaleRepository)(nil).ForEachSold), ctx, from, to, fn)
+}
+
 // GetAvailableByPrice mocks base method.
 func (m *MockSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +86,22 @@ func (mr *MockSaleRepositoryMockRecorder) GetAvailableByPrice(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableByPrice", reflect.TypeOf((*MockSaleRepository)(nil).GetAvailableByPrice), ctx)
 }
 
+// GetByBuyerCPF mocks base method.
+func (m *MockSaleRepository) GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBuyerCPF", ctx, buyerCPF, limit, offset)
+	ret0, _ := ret[0].([]*domain.Sale)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByBuyerCPF indicates an expected call of GetByBuyerCPF.
+func (mr *MockSaleRepositoryMockRecorder) GetByBuyerCPF(ctx, buyerCPF, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBuyerCPF", reflect.TypeOf((*MockSaleRepository)(nil).GetByBuyerCPF), ctx, buyerCPF, limit, offset)
+}
+
 // GetByID mocks base method.
 func (m *MockSaleRepository) GetByID(ctx context.Context, id string) (*domain.Sale, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +176,20 @@ func (mr *MockSaleRepositoryMockRecorder) Save(ctx, sale any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSaleRepository)(nil).Save), ctx, sale)
 }
 
+// SaveBatch mocks base method.
+func (m *MockSaleRepository) SaveBatch(ctx context.Context, sales []*domain.Sale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBatch", ctx, sales)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBatch indicates an expected call of SaveBatch.
+func (mr *MockSaleRepositoryMockRecorder) SaveBatch(ctx, sales any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBatch", reflect.TypeOf((*MockSaleRepository)(nil).SaveBatch), ctx, sales)
+}
+
 // Update mocks base method.
 func (m *MockSaleRepository) Update(ctx context.Context, sale *domain.Sale) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxQuerier é a parte do *pgxpool.Pool usada pelo repositório. Uma pgx.Tx também serve.
+type PgxQuerier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+var saleColumns = []string{"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version"}
+
+// pgxSaleRepository usa o pool nativo do pgx: os campos opcionais são lidos direto em ponteiros e
+// o SaveBatch usa COPY em vez de INSERT com várias linhas.
+type pgxSaleRepository struct {
+	db PgxQuerier
+}
+
+func NewPgxSaleRepository(db PgxQuerier) SaleRepository {
+	return &pgxSaleRepository{
+		db: db,
+	}
+}
+
+func startPgxSpan(ctx context.Context, method, operation string) (context.Context, trace.Span) {
+	return startSalesSpan(ctx, semconv.DBSystemPostgreSQL, "pgxSaleRepository."+method, operation)
+}
+
+func (r *pgxSaleRepository) Save(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := startPgxSpan(ctx, "Save", "INSERT")
+	defer span.End()
+
+	query := `INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at, version)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := pgxConn(ctx, r.db).Exec(ctx, query,
+		sale.ID,
+		sale.VehicleID,
+		sale.Brand,
+		sale.Model,
+		sale.Price,
+		string(sale.Status),
+		sale.CreatedAt,
+		sale.UpdatedAt,
+		sale.Version,
+	)
+
+	return telemetry.Error(span, err)
+}
+
+// SaveBatch grava o lote com um único COPY, que falha por inteiro se uma linha for rejeitada.
+func (r *pgxSaleRepository) SaveBatch(ctx context.Context, sales []*domain.Sale) error {
+	ctx, span := startPgxSpan(ctx, "SaveBatch", "COPY")
+	defer span.End()
+
+	if len(sales) == 0 {
+		return nil
+	}
+
+	rows := pgx.CopyFromSlice(len(sales), func(i int) ([]any, error) {
+		sale := sales[i]
+		return []any{sale.ID, sale.VehicleID, sale.Brand, sale.Model, sale.Price, string(sale.Status), sale.CreatedAt, sale.UpdatedAt, sale.Version}, nil
+	})
+	if _, err := pgxConn(ctx, r.db).CopyFrom(ctx, pgx.Identifier{"sales"}, saleColumns, rows); err != nil {
+		return telemetry.Error(span, err)
+	}
+
+	slog.DebugContext(ctx, "sales batch copied", "rows", len(sales))
+	return nil
+}
+
+func (r *pgxSaleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := startPgxSpan(ctx, "Update", "UPDATE")
+	defer span.End()
+
+	query := `UPDATE sales
+	          SET vehicle_id = $1, brand = $2, model = $3, price = $4, status = $5,
+	              payment_id = $6, buyer_cpf = $7, sale_date = $8, updated_at = $9, version = version + 1
+	          WHERE id = $10 AND version = $11`
+
+	var paymentID *string
+	if sale.PaymentID != "" {
+		paymentID = &sale.PaymentID
+	}
+
+	tag, err := pgxConn(ctx, r.db).Exec(ctx, query,
+		sale.VehicleID,
+		sale.Brand,
+		sale.Model,
+		sale.Price,
+		string(sale.Status),
+		paymentID,
+		sale.BuyerCPF,
+		sale.SaleDate,
+		sale.UpdatedAt,
+		sale.ID,
+		sale.Version,
+	)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	if tag.RowsAffected() > 0 {
+		sale.Version++
+		return nil
+	}
+
+	var current int
+	err = pgxConn(ctx, r.db).QueryRow(ctx, `SELECT version FROM sales WHERE id = $1`, sale.ID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return telemetry.Error(span, ErrSaleNotFound)
+	}
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	return telemetry.Error(span, &ConcurrentModificationError{SaleID: sale.ID, Expected: sale.Version, Current: current})
+}
+
+func (r *pgxSaleRepository) GetByID(ctx context.Context, id string) (*domain.Sale, error) {
+	ctx, span := startPgxSpan(ctx, "GetByID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE id = $1`
+
+	sale, err := scanPgxSale(pgxConn(ctx, r.db).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrSaleNotFound
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+func (r *pgxSaleRepository) GetByVehicleID(ctx context.Context, vehicleID string) (*domain.Sale, error) {
+	ctx, span := startPgxSpan(ctx, "GetByVehicleID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE vehicle_id = $1`
+
+	sale, err := scanPgxSale(pgxConn(ctx, r.db).QueryRow(ctx, query, vehicleID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = errSaleNotFoundByVehicle
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+func (r *pgxSaleRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Sale, error) {
+	ctx, span := startPgxSpan(ctx, "GetByPaymentID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE payment_id = $1`
+
+	sale, err := scanPgxSale(pgxConn(ctx, r.db).QueryRow(ctx, query, paymentID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = errSaleNotFoundByPayment
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+// GetByBuyerCPF aceita o CPF com ou sem pontuação e procura pelas duas formas, já que o
+// valor é gravado como veio na compra.
+func (r *pgxSaleRepository) GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error) {
+	ctx, span := startPgxSpan(ctx, "GetByBuyerCPF", "SELECT")
+	defer span.End()
+
+	digits := domain.NormalizeCPF(buyerCPF)
+	formatted := domain.FormatCPF(digits)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM sales WHERE buyer_cpf IN ($1, $2)`
+	if err := pgxConn(ctx, r.db).QueryRow(ctx, countQuery, digits, formatted).Scan(&total); err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE buyer_cpf IN ($1, $2)
+	          ORDER BY sale_date DESC NULLS LAST, created_at DESC
+	          LIMIT $3 OFFSET $4`
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, query, digits, formatted, limit, offset)
+	if err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+
+	sales, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Sale, error) {
+		return scanPgxSale(row)
+	})
+	if err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+	return sales, total, nil
+}
+
+func (r *pgxSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	ctx, span := startPgxSpan(ctx, "GetAvailableByPrice", "SELECT")
+	defer span.End()
+
+	sales, err := r.listByStatus(ctx, domain.StatusAvailable)
+	return sales, telemetry.Error(span, err)
+}
+
+func (r *pgxSaleRepository) GetSoldByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	ctx, span := startPgxSpan(ctx, "GetSoldByPrice", "SELECT")
+	defer span.End()
+
+	sales, err := r.listByStatus(ctx, domain.StatusSold)
+	return sales, telemetry.Error(span, err)
+}
+
+func (r *pgxSaleRepository) listByStatus(ctx context.Context, status domain.SaleStatus) ([]*domain.Sale, error) {
+	query := `SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version
+	          FROM sales
+	          WHERE status = $1
+	          ORDER BY price ASC`
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, query, string(status))
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Sale, error) {
+		var s domain.Sale
+		err := row.Scan(&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status, &s.CreatedAt, &s.UpdatedAt, &s.Version)
+		return &s, err
+	})
+}
+
+func (r *pgxSaleRepository) CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error) {
+	ctx, span := startPgxSpan(ctx, "CountByStatus", "SELECT")
+	defer span.End()
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, `SELECT status, COUNT(*) FROM sales GROUP BY status`)
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+
+	counts := map[domain.SaleStatus]int{}
+	var status domain.SaleStatus
+	var count int
+	_, err = pgx.ForEachRow(rows, []any{&status, &count}, func() error {
+		counts[status] = count
+		return nil
+	})
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+	return counts, nil
+}
+
+func (r *pgxSaleRepository) ForEachSold(ctx context.Context, from, to time.Time, fn func(sale *domain.Sale) error) error {
+	ctx, span := startPgxSpan(ctx, "ForEachSold", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE status = $1 AND sale_date >= $2 AND sale_date < $3
+	          ORDER BY sale_date ASC`
+
+	rows, err := pgxConn(ctx, r.db).Query(ctx, query, string(domain.StatusSold), from, to)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sale, err := scanPgxSale(rows)
+		if err != nil {
+			return telemetry.Error(span, err)
+		}
+		if err := fn(sale); err != nil {
+			return telemetry.Error(span, err)
+		}
+	}
+
+	return telemetry.Error(span, rows.Err())
+}
+
+func scanPgxSale(row pgx.Row) (*domain.Sale, error) {
+	var s domain.Sale
+	var paymentID *string
+
+	err := row.Scan(
+		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
+		&paymentID, &s.BuyerCPF, &s.SaleDate,
+		&s.CreatedAt, &s.UpdatedAt, &s.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentID != nil {
+		s.PaymentID = *paymentID
+	}
+	return &s, nil
+}
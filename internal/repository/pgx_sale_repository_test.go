@@ -0,0 +1,343 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry/telemetrytest"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type PgxSaleRepositoryTestSuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo repository.SaleRepository
+}
+
+func Test_PgxSaleRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PgxSaleRepositoryTestSuite))
+}
+
+func (suite *PgxSaleRepositoryTestSuite) SetupTest() {
+	mock, err := pgxmock.NewPool()
+	suite.Require().NoError(err)
+	suite.mock = mock
+	suite.repo = repository.NewPgxSaleRepository(mock)
+}
+
+func (suite *PgxSaleRepositoryTestSuite) TearDownTest() {
+	suite.NoError(suite.mock.ExpectationsWereMet())
+	suite.mock.Close()
+}
+
+var pgxSaleColumns = []string{"id", "vehicle_id", "brand", "model", "price", "status", "payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version"}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_Save() {
+	now := time.Now()
+	sale := &domain.Sale{ID: "sale-id", VehicleID: "vehicle-id", Brand: "BrandX", Model: "ModelY", Price: 10000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+
+	suite.T().Run("should save sale successfully", func(t *testing.T) {
+		suite.mock.ExpectExec(`INSERT INTO sales`).
+			WithArgs("sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "AVAILABLE", now, now, 1).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		suite.NoError(suite.repo.Save(context.Background(), sale))
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		suite.mock.ExpectExec(`INSERT INTO sales`).
+			WithArgs("sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "AVAILABLE", now, now, 1).
+			WillReturnError(errors.New("db error"))
+
+		suite.EqualError(suite.repo.Save(context.Background(), sale), "db error")
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_SaveBatch() {
+	now := time.Now()
+	sales := []*domain.Sale{
+		{ID: "sale-1", VehicleID: "vehicle-1", Brand: "Toyota", Model: "Corolla", Price: 50000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+		{ID: "sale-2", VehicleID: "vehicle-2", Brand: "Honda", Model: "Civic", Price: 60000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+	}
+
+	suite.T().Run("should copy all sales at once", func(t *testing.T) {
+		suite.mock.ExpectCopyFrom(pgx.Identifier{"sales"}, []string{"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version"}).
+			WillReturnResult(2)
+
+		suite.NoError(suite.repo.SaveBatch(context.Background(), sales))
+	})
+
+	suite.T().Run("should do nothing for an empty batch", func(t *testing.T) {
+		suite.NoError(suite.repo.SaveBatch(context.Background(), nil))
+	})
+
+	suite.T().Run("should return error when copy fails", func(t *testing.T) {
+		suite.mock.ExpectCopyFrom(pgx.Identifier{"sales"}, []string{"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version"}).
+			WillReturnError(errors.New("duplicate key"))
+
+		suite.EqualError(suite.repo.SaveBatch(context.Background(), sales), "duplicate key")
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_Update() {
+	now := time.Now()
+	buyerCPF := "12345678900"
+	saleDate := now.Add(-time.Hour)
+	paymentID := "payment-id"
+	newSale := func() *domain.Sale {
+		return &domain.Sale{ID: "sale-id", VehicleID: "vehicle-id", Brand: "BrandX", Model: "ModelY", Price: 10000, Status: domain.StatusSold, PaymentID: "payment-id", BuyerCPF: &buyerCPF, SaleDate: &saleDate, UpdatedAt: now, Version: 2}
+	}
+
+	suite.T().Run("should update sale successfully and increment the version", func(t *testing.T) {
+		sale := newSale()
+		suite.mock.ExpectExec(`UPDATE sales .+ WHERE id = \$10 AND version = \$11`).
+			WithArgs("vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD", &paymentID, &buyerCPF, &saleDate, now, "sale-id", 2).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		suite.NoError(suite.repo.Update(context.Background(), sale))
+		suite.Equal(3, sale.Version)
+	})
+
+	suite.T().Run("should write nulls for the empty optional fields", func(t *testing.T) {
+		available := &domain.Sale{ID: "sale-id", VehicleID: "vehicle-id", Brand: "BrandX", Model: "ModelY", Price: 10000, Status: domain.StatusAvailable, UpdatedAt: now, Version: 1}
+		suite.mock.ExpectExec(`UPDATE sales`).
+			WithArgs("vehicle-id", "BrandX", "ModelY", 10000.0, "AVAILABLE", (*string)(nil), (*string)(nil), (*time.Time)(nil), now, "sale-id", 1).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		suite.NoError(suite.repo.Update(context.Background(), available))
+	})
+
+	suite.T().Run("should return ErrConcurrentModification when the version changed", func(t *testing.T) {
+		sale := newSale()
+		suite.mock.ExpectExec(`UPDATE sales`).
+			WithArgs("vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD", &paymentID, &buyerCPF, &saleDate, now, "sale-id", 2).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		suite.mock.ExpectQuery(`SELECT version FROM sales WHERE id = \$1`).
+			WithArgs("sale-id").
+			WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(4))
+
+		err := suite.repo.Update(context.Background(), sale)
+
+		var conflict *repository.ConcurrentModificationError
+		suite.Require().ErrorAs(err, &conflict)
+		suite.ErrorIs(err, repository.ErrConcurrentModification)
+		suite.Equal(4, conflict.Current)
+		suite.Equal(2, sale.Version)
+	})
+
+	suite.T().Run("should return ErrSaleNotFound when the sale does not exist", func(t *testing.T) {
+		suite.mock.ExpectExec(`UPDATE sales`).
+			WithArgs("vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD", &paymentID, &buyerCPF, &saleDate, now, "sale-id", 2).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		suite.mock.ExpectQuery(`SELECT version FROM sales WHERE id = \$1`).WithArgs("sale-id").WillReturnError(pgx.ErrNoRows)
+
+		suite.ErrorIs(suite.repo.Update(context.Background(), newSale()), repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_GetByID() {
+	now := time.Now()
+	saleDate := now.Add(-time.Hour)
+
+	suite.T().Run("should get sale by id with all fields", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT (.+) FROM sales WHERE id = \$1`).
+			WithArgs("sale-id").
+			WillReturnRows(pgxmock.NewRows(pgxSaleColumns).
+				AddRow("sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, domain.StatusSold, ptr("payment-id"), ptr("12345678900"), &saleDate, now, now, 1))
+
+		sale, err := suite.repo.GetByID(context.Background(), "sale-id")
+
+		suite.NoError(err)
+		suite.Equal(domain.StatusSold, sale.Status)
+		suite.Equal("payment-id", sale.PaymentID)
+		suite.Equal("12345678900", *sale.BuyerCPF)
+		suite.Equal(saleDate, *sale.SaleDate)
+	})
+
+	suite.T().Run("should leave the optional fields empty when null", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT (.+) FROM sales WHERE id = \$1`).
+			WithArgs("sale-id").
+			WillReturnRows(pgxmock.NewRows(pgxSaleColumns).
+				AddRow("sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, domain.StatusAvailable, (*string)(nil), (*string)(nil), (*time.Time)(nil), now, now, 1))
+
+		sale, err := suite.repo.GetByID(context.Background(), "sale-id")
+
+		suite.NoError(err)
+		suite.Empty(sale.PaymentID)
+		suite.Nil(sale.BuyerCPF)
+		suite.Nil(sale.SaleDate)
+	})
+
+	suite.T().Run("should return ErrSaleNotFound when there is no row", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT (.+) FROM sales WHERE id = \$1`).WithArgs("missing").WillReturnError(pgx.ErrNoRows)
+
+		sale, err := suite.repo.GetByID(context.Background(), "missing")
+
+		suite.Nil(sale)
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_GetByVehicleIDAndPaymentID() {
+	suite.T().Run("should keep the specific not found messages", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE vehicle_id = \$1`).WithArgs("vehicle-id").WillReturnError(pgx.ErrNoRows)
+		suite.mock.ExpectQuery(`WHERE payment_id = \$1`).WithArgs("payment-id").WillReturnError(pgx.ErrNoRows)
+
+		_, vehicleErr := suite.repo.GetByVehicleID(context.Background(), "vehicle-id")
+		_, paymentErr := suite.repo.GetByPaymentID(context.Background(), "payment-id")
+
+		suite.EqualError(vehicleErr, "sale listing for the given vehicle_id not found")
+		suite.ErrorIs(vehicleErr, repository.ErrSaleNotFound)
+		suite.EqualError(paymentErr, "sale not found for the given payment_id")
+		suite.ErrorIs(paymentErr, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_GetByBuyerCPF() {
+	now := time.Now()
+
+	suite.T().Run("should return the page of sales and the total for both CPF formats", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales WHERE buyer_cpf IN \(\$1, \$2\)`).
+			WithArgs("12345678900", "123.456.789-00").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+		suite.mock.ExpectQuery(`ORDER BY sale_date DESC NULLS LAST, created_at DESC LIMIT \$3 OFFSET \$4`).
+			WithArgs("12345678900", "123.456.789-00", 2, 0).
+			WillReturnRows(pgxmock.NewRows(pgxSaleColumns).
+				AddRow("sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusSold, ptr("payment-1"), ptr("123.456.789-00"), &now, now, now, 1).
+				AddRow("sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusPendingPayment, ptr("payment-2"), ptr("12345678900"), (*time.Time)(nil), now, now, 1))
+
+		sales, total, err := suite.repo.GetByBuyerCPF(context.Background(), "123.456.789-00", 2, 0)
+
+		suite.NoError(err)
+		suite.Equal(3, total)
+		suite.Len(sales, 2)
+		suite.Nil(sales[1].SaleDate)
+	})
+
+	suite.T().Run("should return an empty slice when the buyer has no sales", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT COUNT`).WithArgs("12345678900", "123.456.789-00").WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+		suite.mock.ExpectQuery(`SELECT (.+) FROM sales`).WithArgs("12345678900", "123.456.789-00", 20, 0).WillReturnRows(pgxmock.NewRows(pgxSaleColumns))
+
+		sales, total, err := suite.repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+
+		suite.NoError(err)
+		suite.Zero(total)
+		suite.NotNil(sales)
+		suite.Empty(sales)
+	})
+
+	suite.T().Run("should return error when count fails", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT COUNT`).WithArgs("12345678900", "123.456.789-00").WillReturnError(errors.New("db error"))
+
+		sales, _, err := suite.repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+
+		suite.EqualError(err, "db error")
+		suite.Nil(sales)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_ListByPrice() {
+	now := time.Now()
+	columns := []string{"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version"}
+
+	suite.T().Run("should return available sales ordered by price", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE status = \$1 ORDER BY price ASC`).
+			WithArgs("AVAILABLE").
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", 5000.0, domain.StatusAvailable, now, now, 1).
+				AddRow("sale-2", "vehicle-2", "BrandB", "ModelB", 7000.0, domain.StatusAvailable, now, now, 2))
+
+		sales, err := suite.repo.GetAvailableByPrice(context.Background())
+
+		suite.NoError(err)
+		suite.Len(sales, 2)
+		suite.Equal("sale-1", sales[0].ID)
+		suite.Equal(2, sales[1].Version)
+	})
+
+	suite.T().Run("should return sold sales ordered by price", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE status = \$1 ORDER BY price ASC`).
+			WithArgs("SOLD").
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		sales, err := suite.repo.GetSoldByPrice(context.Background())
+
+		suite.NoError(err)
+		suite.Empty(sales)
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE status = \$1`).WithArgs("AVAILABLE").WillReturnError(errors.New("db error"))
+
+		sales, err := suite.repo.GetAvailableByPrice(context.Background())
+
+		suite.EqualError(err, "db error")
+		suite.Nil(sales)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_CountByStatus() {
+	suite.T().Run("should count sales per status", func(t *testing.T) {
+		suite.mock.ExpectQuery(`SELECT status, COUNT\(\*\) FROM sales GROUP BY status`).
+			WillReturnRows(pgxmock.NewRows([]string{"status", "count"}).
+				AddRow(domain.StatusAvailable, 3).
+				AddRow(domain.StatusSold, 2))
+
+		counts, err := suite.repo.CountByStatus(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(map[domain.SaleStatus]int{domain.StatusAvailable: 3, domain.StatusSold: 2}, counts)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_ForEachSold() {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	saleDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	suite.T().Run("should stop when fn returns error", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE status = \$1 AND sale_date >= \$2 AND sale_date < \$3 ORDER BY sale_date ASC`).
+			WithArgs("SOLD", from, to).
+			WillReturnRows(pgxmock.NewRows(pgxSaleColumns).
+				AddRow("sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusSold, ptr("payment-1"), ptr("12345678900"), &saleDate, now, now, 1).
+				AddRow("sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusSold, ptr("payment-2"), ptr("12345678900"), &saleDate, now, now, 1))
+
+		calls := 0
+		err := suite.repo.ForEachSold(context.Background(), from, to, func(sale *domain.Sale) error {
+			calls++
+			return errors.New("write error")
+		})
+
+		suite.EqualError(err, "write error")
+		suite.Equal(1, calls)
+	})
+}
+
+func (suite *PgxSaleRepositoryTestSuite) Test_Tracing() {
+	exporter := telemetrytest.Install(suite.T())
+
+	suite.T().Run("should record a span with the database attributes", func(t *testing.T) {
+		suite.mock.ExpectQuery(`WHERE vehicle_id = \$1`).WithArgs("vehicle-traced").WillReturnError(pgx.ErrNoRows)
+
+		_, err := suite.repo.GetByVehicleID(context.Background(), "vehicle-traced")
+		suite.Error(err)
+
+		spans := telemetrytest.Named(exporter, "pgxSaleRepository.GetByVehicleID")
+		suite.Require().Len(spans, 1)
+		suite.Contains(spans[0].Attributes, attribute.String("db.system", "postgresql"))
+		suite.Contains(spans[0].Attributes, attribute.String("db.operation.name", "SELECT"))
+	})
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
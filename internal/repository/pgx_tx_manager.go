@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PgxBeginner é a parte do *pgxpool.Pool usada pelo gerenciador de transações.
+type PgxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// pgxTxKey guarda no ctx a transação aberta sobre um pool, como o sqlTxKey.
+type pgxTxKey struct {
+	db any
+}
+
+// pgxConn devolve a transação de db que estiver no ctx ou, sem transação, o próprio db.
+func pgxConn(ctx context.Context, db PgxQuerier) PgxQuerier {
+	if tx, ok := ctx.Value(pgxTxKey{db}).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+type pgxTxManager struct {
+	db PgxBeginner
+}
+
+func NewPgxTxManager(db PgxBeginner) TxManager {
+	return &pgxTxManager{
+		db: db,
+	}
+}
+
+func (m *pgxTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	key := pgxTxKey{m.db}
+	if _, ok := ctx.Value(key).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollbackPgx(ctx, tx)
+			panic(p)
+		}
+	}()
+
+	txCtx, hooks := withAfterCommit(context.WithValue(ctx, key, tx))
+	if err := fn(txCtx); err != nil {
+		rollbackPgx(ctx, tx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	hooks.run(ctx)
+	return nil
+}
+
+func rollbackPgx(ctx context.Context, tx pgx.Tx) {
+	if err := tx.Rollback(ctx); err != nil {
+		slog.WarnContext(ctx, "could not roll back transaction", "error", err)
+	}
+}
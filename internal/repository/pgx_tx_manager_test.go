@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PgxTxManagerTestSuite struct {
+	suite.Suite
+	mock      pgxmock.PgxPoolIface
+	txManager repository.TxManager
+	repo      repository.SaleRepository
+}
+
+func Test_PgxTxManagerTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PgxTxManagerTestSuite))
+}
+
+func (suite *PgxTxManagerTestSuite) SetupTest() {
+	mock, err := pgxmock.NewPool()
+	suite.Require().NoError(err)
+	suite.mock = mock
+	suite.txManager = repository.NewPgxTxManager(mock)
+	suite.repo = repository.NewPgxSaleRepository(mock)
+}
+
+func (suite *PgxTxManagerTestSuite) TearDownTest() {
+	suite.NoError(suite.mock.ExpectationsWereMet())
+	suite.mock.Close()
+}
+
+func (suite *PgxTxManagerTestSuite) Test_WithinTx() {
+	now := time.Now()
+	sale := &domain.Sale{ID: "sale-id", VehicleID: "vehicle-id", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+
+	suite.T().Run("should run the repository calls in the transaction and commit", func(t *testing.T) {
+		suite.mock.ExpectBegin()
+		suite.mock.ExpectExec(`INSERT INTO sales`).
+			WithArgs("sale-id", "vehicle-id", "", "", 0.0, "AVAILABLE", now, now, 1).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		suite.mock.ExpectCommit()
+
+		err := suite.txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			return suite.repo.Save(ctx, sale)
+		})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should roll back when fn returns an error", func(t *testing.T) {
+		suite.mock.ExpectBegin()
+		suite.mock.ExpectExec(`INSERT INTO sales`).
+			WithArgs("sale-id", "vehicle-id", "", "", 0.0, "AVAILABLE", now, now, 1).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		suite.mock.ExpectRollback()
+
+		err := suite.txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := suite.repo.Save(ctx, sale); err != nil {
+				return err
+			}
+			return errors.New("outbox error")
+		})
+		suite.EqualError(err, "outbox error")
+	})
+
+	suite.T().Run("should roll back and panic again when fn panics", func(t *testing.T) {
+		suite.mock.ExpectBegin()
+		suite.mock.ExpectRollback()
+
+		suite.PanicsWithValue("boom", func() {
+			_ = suite.txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+	})
+
+	suite.T().Run("should join the transaction already in the context", func(t *testing.T) {
+		suite.mock.ExpectBegin()
+		suite.mock.ExpectCommit()
+
+		err := suite.txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			return suite.txManager.WithinTx(ctx, func(ctx context.Context) error { return nil })
+		})
+		suite.NoError(err)
+	})
+}
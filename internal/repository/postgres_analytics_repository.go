@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+type postgresAnalyticsRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAnalyticsRepository(db *sql.DB) AnalyticsRepository {
+	return &postgresAnalyticsRepository{
+		db: db,
+	}
+}
+
+func (r *postgresAnalyticsRepository) AggregateSales(ctx context.Context, filter SalesAnalyticsFilter) ([]*domain.SalesAggregate, error) {
+	periodColumn, brandColumn, modelColumn := "NULL::timestamptz", "''", "''"
+	var groupBy []string
+
+	switch filter.Period {
+	case "":
+	case AnalyticsPeriodDay, AnalyticsPeriodWeek, AnalyticsPeriodMonth:
+		periodColumn = fmt.Sprintf("date_trunc('%s', sale_date)", filter.Period)
+		groupBy = append(groupBy, periodColumn)
+	default:
+		return nil, fmt.Errorf("invalid analytics period: %s", filter.Period)
+	}
+
+	switch filter.GroupBy {
+	case "":
+	case AnalyticsGroupByBrand:
+		brandColumn = "brand"
+		groupBy = append(groupBy, brandColumn)
+	case AnalyticsGroupByModel:
+		brandColumn, modelColumn = "brand", "model"
+		groupBy = append(groupBy, brandColumn, modelColumn)
+	default:
+		return nil, fmt.Errorf("invalid analytics grouping: %s", filter.GroupBy)
+	}
+
+	query := fmt.Sprintf(`SELECT %s AS period, %s AS brand, %s AS model, 
+	          COUNT(*) FILTER (WHERE status = $4) AS units_sold, 
+	          COALESCE(SUM(price) FILTER (WHERE status = $4), 0) AS revenue, 
+	          COALESCE(AVG(EXTRACT(EPOCH FROM (sale_date - created_at))) FILTER (WHERE status = $4), 0) AS avg_seconds_to_sale, 
+	          COUNT(*) AS reservations 
+	          FROM sales 
+	          WHERE sale_date >= $1 AND sale_date < $2 AND status <> $3`, periodColumn, brandColumn, modelColumn)
+	if len(groupBy) > 0 {
+		columns := strings.Join(groupBy, ", ")
+		query += fmt.Sprintf(` 
+	          GROUP BY %s 
+	          ORDER BY %s`, columns, columns)
+	}
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, filter.From, filter.To, domain.StatusAvailable, domain.StatusSold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aggregates := []*domain.SalesAggregate{}
+	for rows.Next() {
+		var a domain.SalesAggregate
+		var period sql.NullTime
+		var avgSeconds float64
+
+		if err := rows.Scan(&period, &a.Brand, &a.Model, &a.UnitsSold, &a.Revenue, &avgSeconds, &a.Reservations); err != nil {
+			return nil, err
+		}
+
+		if period.Valid {
+			a.PeriodStart = &period.Time
+		}
+		a.AvgTimeToSale = time.Duration(avgSeconds * float64(time.Second))
+		aggregates = append(aggregates, &a)
+	}
+
+	return aggregates, rows.Err()
+}
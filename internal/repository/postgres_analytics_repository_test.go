@@ -0,0 +1,114 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresAnalyticsRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresAnalyticsRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresAnalyticsRepositoryTestSuite))
+}
+
+func (suite *PostgresAnalyticsRepositoryTestSuite) Test_AggregateSales() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresAnalyticsRepository(db)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"period", "brand", "model", "units_sold", "revenue", "avg_seconds_to_sale", "reservations"}
+
+	suite.T().Run("should aggregate the whole range without grouping", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(nil, "", "", 3, 150000.0, 7200.0, 4)
+
+		mock.ExpectQuery(`SELECT NULL::timestamptz AS period, '' AS brand, '' AS model, (.+) FROM sales WHERE sale_date >= \$1 AND sale_date < \$2 AND status <> \$3$`).
+			WithArgs(from, to, domain.StatusAvailable, domain.StatusSold).
+			WillReturnRows(rows)
+
+		aggregates, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{From: from, To: to})
+		suite.NoError(err)
+		suite.Len(aggregates, 1)
+		suite.Nil(aggregates[0].PeriodStart)
+		suite.Equal(3, aggregates[0].UnitsSold)
+		suite.Equal(150000.0, aggregates[0].Revenue)
+		suite.Equal(2*time.Hour, aggregates[0].AvgTimeToSale)
+		suite.Equal(4, aggregates[0].Reservations)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should group by period and brand/model", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(from, "Honda", "Civic", 1, 60000.0, 3600.0, 1).
+			AddRow(from, "Toyota", "Corolla", 2, 100000.0, 0.0, 3)
+
+		mock.ExpectQuery(`SELECT date_trunc\('month', sale_date\) AS period, brand AS brand, model AS model, (.+) GROUP BY date_trunc\('month', sale_date\), brand, model ORDER BY date_trunc\('month', sale_date\), brand, model`).
+			WithArgs(from, to, domain.StatusAvailable, domain.StatusSold).
+			WillReturnRows(rows)
+
+		aggregates, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{
+			From:    from,
+			To:      to,
+			Period:  repository.AnalyticsPeriodMonth,
+			GroupBy: repository.AnalyticsGroupByModel,
+		})
+		suite.NoError(err)
+		suite.Len(aggregates, 2)
+		suite.Equal(from, *aggregates[0].PeriodStart)
+		suite.Equal("Honda", aggregates[0].Brand)
+		suite.Equal("Civic", aggregates[0].Model)
+		suite.Equal(time.Hour, aggregates[0].AvgTimeToSale)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should group by brand only", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT NULL::timestamptz AS period, brand AS brand, '' AS model, (.+) GROUP BY brand ORDER BY brand`).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		aggregates, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{
+			From:    from,
+			To:      to,
+			GroupBy: repository.AnalyticsGroupByBrand,
+		})
+		suite.NoError(err)
+		suite.Empty(aggregates)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should reject unknown period and grouping", func(t *testing.T) {
+		_, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{Period: "year"})
+		suite.EqualError(err, "invalid analytics period: year")
+
+		_, err = repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{GroupBy: "color"})
+		suite.EqualError(err, "invalid analytics grouping: color")
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnError(errors.New("db error"))
+
+		_, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{From: from, To: to})
+		suite.EqualError(err, "db error")
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnRows(sqlmock.NewRows([]string{"period"}).AddRow(nil))
+
+		_, err := repo.AggregateSales(context.Background(), repository.SalesAnalyticsFilter{From: from, To: to})
+		suite.Error(err)
+	})
+}
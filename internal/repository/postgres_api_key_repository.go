@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+type postgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &postgresAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Os escopos ficam em uma coluna TEXT[] e trafegam como texto separado por vírgula,
+// já que o database/sql não converte arrays do Postgres.
+func (r *postgresAPIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	query := `INSERT INTO api_keys (id, name, prefix, key_hash, scopes, expires_at, created_at)
+	          VALUES ($1, $2, $3, $4, string_to_array($5, ','), $6, $7)`
+
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		strings.Join(key.Scopes, ","),
+		key.ExpiresAt,
+		key.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *postgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT id, name, prefix, key_hash, array_to_string(scopes, ','), expires_at, revoked_at, created_at 
+	          FROM api_keys 
+	          WHERE key_hash = $1`
+
+	key, err := scanAPIKey(sqlConn(ctx, r.db).QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *postgresAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	query := `SELECT id, name, prefix, key_hash, array_to_string(scopes, ','), expires_at, revoked_at, created_at 
+	          FROM api_keys 
+	          ORDER BY created_at ASC`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *postgresAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes string
+	var expiresAt, revokedAt sql.NullTime
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &expiresAt, &revokedAt, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,196 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresAPIKeyRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresAPIKeyRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresAPIKeyRepositoryTestSuite))
+}
+
+var apiKeyColumns = []string{"id", "name", "prefix", "key_hash", "scopes", "expires_at", "revoked_at", "created_at"}
+
+func (suite *PostgresAPIKeyRepositoryTestSuite) Test_Save() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresAPIKeyRepository(db)
+
+	expiresAt := time.Now().Add(time.Hour)
+	key := &domain.APIKey{
+		ID:        "key-1",
+		Name:      "catalog",
+		Prefix:    "sk_abcdefgh",
+		KeyHash:   "hash",
+		Scopes:    []string{"catalog-service", "payment-gateway"},
+		ExpiresAt: &expiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	suite.T().Run("should save api key successfully", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO api_keys \(id, name, prefix, key_hash, scopes, expires_at, created_at\)`).
+			WithArgs(key.ID, key.Name, key.Prefix, key.KeyHash, "catalog-service,payment-gateway", key.ExpiresAt, key.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		suite.NoError(repo.Save(context.Background(), key))
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO api_keys`).WillReturnError(errors.New("db error"))
+
+		suite.EqualError(repo.Save(context.Background(), key), "db error")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (suite *PostgresAPIKeyRepositoryTestSuite) Test_GetByHash() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresAPIKeyRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should get api key by hash successfully", func(t *testing.T) {
+		rows := sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-1", "catalog", "sk_abcdefgh", "hash", "catalog-service", now, now, now)
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+			WithArgs("hash").
+			WillReturnRows(rows)
+
+		key, err := repo.GetByHash(context.Background(), "hash")
+		suite.NoError(err)
+		suite.Equal("key-1", key.ID)
+		suite.Equal("catalog", key.Name)
+		suite.Equal([]string{"catalog-service"}, key.Scopes)
+		suite.Equal(now, *key.ExpiresAt)
+		suite.Equal(now, *key.RevokedAt)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return ErrAPIKeyNotFound when no row matches", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys`).WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+		key, err := repo.GetByHash(context.Background(), "unknown")
+		suite.ErrorIs(err, repository.ErrAPIKeyNotFound)
+		suite.Nil(key)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys`).WillReturnError(errors.New("db error"))
+
+		key, err := repo.GetByHash(context.Background(), "hash")
+		suite.EqualError(err, "db error")
+		suite.Nil(key)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (suite *PostgresAPIKeyRepositoryTestSuite) Test_List() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresAPIKeyRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should list every api key", func(t *testing.T) {
+		rows := sqlmock.NewRows(apiKeyColumns).
+			AddRow("key-1", "catalog", "sk_abcdefgh", "hash-1", "catalog-service", nil, nil, now).
+			AddRow("key-2", "payments", "sk_ijklmnop", "hash-2", "payment-gateway", now, nil, now)
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys ORDER BY created_at ASC`).WillReturnRows(rows)
+
+		keys, err := repo.List(context.Background())
+		suite.NoError(err)
+		suite.Len(keys, 2)
+		suite.Nil(keys[0].ExpiresAt)
+		suite.Nil(keys[0].RevokedAt)
+		suite.Equal("payments", keys[1].Name)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return empty slice if there are no keys", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys`).WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+		keys, err := repo.List(context.Background())
+		suite.NoError(err)
+		suite.NotNil(keys)
+		suite.Len(keys, 0)
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys`).WillReturnError(errors.New("db error"))
+
+		keys, err := repo.List(context.Background())
+		suite.EqualError(err, "db error")
+		suite.Nil(keys)
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("key-1"))
+
+		keys, err := repo.List(context.Background())
+		suite.Error(err)
+		suite.Nil(keys)
+	})
+}
+
+func (suite *PostgresAPIKeyRepositoryTestSuite) Test_Revoke() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresAPIKeyRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should revoke an active key", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE api_keys SET revoked_at = \$2 WHERE id = \$1 AND revoked_at IS NULL`).
+			WithArgs("key-1", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		suite.NoError(repo.Revoke(context.Background(), "key-1", now))
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return ErrAPIKeyNotFound for unknown or already revoked keys", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE api_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		suite.ErrorIs(repo.Revoke(context.Background(), "key-1", now), repository.ErrAPIKeyNotFound)
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE api_keys`).WillReturnError(errors.New("db error"))
+
+		suite.EqualError(repo.Revoke(context.Background(), "key-1", now), "db error")
+	})
+
+	suite.T().Run("should return error when rows affected fails", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE api_keys`).WillReturnResult(sqlmock.NewErrorResult(errors.New("result error")))
+
+		suite.EqualError(repo.Revoke(context.Background(), "key-1", now), "result error")
+	})
+}
@@ -4,10 +4,36 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const saveBatchChunkSize = 500
+
+func startSpan(ctx context.Context, method, operation string) (context.Context, trace.Span) {
+	return startSalesSpan(ctx, semconv.DBSystemPostgreSQL, "postgresSaleRepository."+method, operation)
+}
+
+// startSalesSpan abre o span de uma operação de repositório sobre a tabela sales.
+func startSalesSpan(ctx context.Context, system attribute.KeyValue, name, operation string) (context.Context, trace.Span) {
+	return telemetry.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			system,
+			semconv.DBCollectionName("sales"),
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
 type postgresSaleRepository struct {
 	db *sql.DB
 }
@@ -19,10 +45,13 @@ func NewPostgresSaleRepository(db *sql.DB) SaleRepository {
 }
 
 func (r *postgresSaleRepository) Save(ctx context.Context, sale *domain.Sale) error {
-	query := `INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	ctx, span := startSpan(ctx, "Save", "INSERT")
+	defer span.End()
 
-	_, err := r.db.ExecContext(ctx, query,
+	query := `INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at, version)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, query,
 		sale.ID,
 		sale.VehicleID,
 		sale.Brand,
@@ -31,16 +60,64 @@ func (r *postgresSaleRepository) Save(ctx context.Context, sale *domain.Sale) er
 		sale.Status,
 		sale.CreatedAt,
 		sale.UpdatedAt,
+		sale.Version,
 	)
 
-	return err
+	return telemetry.Error(span, err)
+}
+
+func (r *postgresSaleRepository) SaveBatch(ctx context.Context, sales []*domain.Sale) error {
+	ctx, span := startSpan(ctx, "SaveBatch", "INSERT")
+	defer span.End()
+
+	if len(sales) == 0 {
+		return nil
+	}
+
+	// Dentro de um WithinTx o lote entra na transação já aberta e só é gravado no commit dela.
+	err := withinSQLTx(ctx, r.db, func(ctx context.Context) error {
+		for start := 0; start < len(sales); start += saveBatchChunkSize {
+			end := min(start+saveBatchChunkSize, len(sales))
+			query, args := buildBatchInsert(sales[start:end])
+
+			if _, err := sqlConn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	slog.DebugContext(ctx, "sales batch inserted", "rows", len(sales))
+	return nil
+}
+
+func buildBatchInsert(sales []*domain.Sale) (string, []any) {
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at, version) VALUES `)
+
+	args := make([]any, 0, len(sales)*9)
+	for i, sale := range sales {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		n := i * 9
+		fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9)
+		args = append(args, sale.ID, sale.VehicleID, sale.Brand, sale.Model, sale.Price, sale.Status, sale.CreatedAt, sale.UpdatedAt, sale.Version)
+	}
+
+	return sb.String(), args
 }
 
 func (r *postgresSaleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := startSpan(ctx, "Update", "UPDATE")
+	defer span.End()
+
 	query := `UPDATE sales 
 	          SET vehicle_id = $1, brand = $2, model = $3, price = $4, status = $5, 
-	              payment_id = $6, buyer_cpf = $7, sale_date = $8, updated_at = $9
-	          WHERE id = $10`
+	              payment_id = $6, buyer_cpf = $7, sale_date = $8, updated_at = $9, version = version + 1
+	          WHERE id = $10 AND version = $11`
 
 	var paymentID, buyerCPF sql.NullString
 	var saleDate sql.NullTime
@@ -55,7 +132,7 @@ func (r *postgresSaleRepository) Update(ctx context.Context, sale *domain.Sale)
 		saleDate = sql.NullTime{Time: *sale.SaleDate, Valid: true}
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, query,
 		sale.VehicleID,
 		sale.Brand,
 		sale.Model,
@@ -66,13 +143,47 @@ func (r *postgresSaleRepository) Update(ctx context.Context, sale *domain.Sale)
 		saleDate,
 		sale.UpdatedAt,
 		sale.ID,
+		sale.Version,
 	)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
 
-	return err
+	return telemetry.Error(span, checkVersionedUpdate(ctx, sqlConn(ctx, r.db), result, sale))
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// checkVersionedUpdate confirma o UPDATE condicionado à versão. Sem linha afetada, consulta a
+// versão gravada para diferenciar uma venda inexistente de uma alteração concorrente.
+func checkVersionedUpdate(ctx context.Context, db queryRower, result sql.Result, sale *domain.Sale) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		sale.Version++
+		return nil
+	}
+
+	var current int
+	err = db.QueryRowContext(ctx, `SELECT version FROM sales WHERE id = $1`, sale.ID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSaleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return &ConcurrentModificationError{SaleID: sale.ID, Expected: sale.Version, Current: current}
 }
 
 func (r *postgresSaleRepository) GetByID(ctx context.Context, id string) (*domain.Sale, error) {
-	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at 
+	ctx, span := startSpan(ctx, "GetByID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version 
 	          FROM sales 
 	          WHERE id = $1`
 
@@ -80,18 +191,18 @@ func (r *postgresSaleRepository) GetByID(ctx context.Context, id string) (*domai
 	var paymentID, buyerCPF sql.NullString
 	var saleDate sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
 		&paymentID, &buyerCPF, &saleDate,
-		&s.CreatedAt, &s.UpdatedAt,
+		&s.CreatedAt, &s.UpdatedAt, &s.Version,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("sale not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, telemetry.Error(span, ErrSaleNotFound)
 		}
 
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	if paymentID.Valid {
@@ -108,7 +219,10 @@ func (r *postgresSaleRepository) GetByID(ctx context.Context, id string) (*domai
 }
 
 func (r *postgresSaleRepository) GetByVehicleID(ctx context.Context, vehicleID string) (*domain.Sale, error) {
-	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at 
+	ctx, span := startSpan(ctx, "GetByVehicleID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version 
 	          FROM sales 
 	          WHERE vehicle_id = $1`
 
@@ -116,17 +230,17 @@ func (r *postgresSaleRepository) GetByVehicleID(ctx context.Context, vehicleID s
 	var paymentID, buyerCPF sql.NullString
 	var saleDate sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, vehicleID).Scan(
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, query, vehicleID).Scan(
 		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
 		&paymentID, &buyerCPF, &saleDate,
-		&s.CreatedAt, &s.UpdatedAt,
+		&s.CreatedAt, &s.UpdatedAt, &s.Version,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("sale listing for the given vehicle_id not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, telemetry.Error(span, errSaleNotFoundByVehicle)
 		}
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	if paymentID.Valid {
@@ -143,7 +257,10 @@ func (r *postgresSaleRepository) GetByVehicleID(ctx context.Context, vehicleID s
 }
 
 func (r *postgresSaleRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Sale, error) {
-	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at 
+	ctx, span := startSpan(ctx, "GetByPaymentID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version 
 	          FROM sales 
 	          WHERE payment_id = $1`
 
@@ -151,17 +268,17 @@ func (r *postgresSaleRepository) GetByPaymentID(ctx context.Context, paymentID s
 	var pID, buyerCPF sql.NullString
 	var saleDate sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, paymentID).Scan(
+	err := sqlConn(ctx, r.db).QueryRowContext(ctx, query, paymentID).Scan(
 		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
 		&pID, &buyerCPF, &saleDate,
-		&s.CreatedAt, &s.UpdatedAt,
+		&s.CreatedAt, &s.UpdatedAt, &s.Version,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("sale not found for the given payment_id")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, telemetry.Error(span, errSaleNotFoundByPayment)
 		}
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	if pID.Valid {
@@ -177,23 +294,65 @@ func (r *postgresSaleRepository) GetByPaymentID(ctx context.Context, paymentID s
 	return &s, nil
 }
 
+// GetByBuyerCPF aceita o CPF com ou sem pontuação e procura pelas duas formas, já que o
+// valor é gravado como veio na compra.
+func (r *postgresSaleRepository) GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error) {
+	ctx, span := startSpan(ctx, "GetByBuyerCPF", "SELECT")
+	defer span.End()
+
+	digits := domain.NormalizeCPF(buyerCPF)
+	formatted := domain.FormatCPF(digits)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM sales WHERE buyer_cpf IN ($1, $2)`
+	if err := sqlConn(ctx, r.db).QueryRowContext(ctx, countQuery, digits, formatted).Scan(&total); err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version 
+	          FROM sales 
+	          WHERE buyer_cpf IN ($1, $2) 
+	          ORDER BY sale_date DESC NULLS LAST, created_at DESC 
+	          LIMIT $3 OFFSET $4`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, digits, formatted, limit, offset)
+	if err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	sales := []*domain.Sale{}
+	for rows.Next() {
+		sale, err := scanSale(rows)
+		if err != nil {
+			return nil, 0, telemetry.Error(span, err)
+		}
+		sales = append(sales, sale)
+	}
+
+	return sales, total, telemetry.Error(span, rows.Err())
+}
+
 func (r *postgresSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
-	query := `SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at 
+	ctx, span := startSpan(ctx, "GetAvailableByPrice", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version 
 	          FROM sales 
 	          WHERE status = $1 
 	          ORDER BY price ASC`
 
-	rows, err := r.db.QueryContext(ctx, query, domain.StatusAvailable)
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, domain.StatusAvailable)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 	defer rows.Close()
 
 	var sales []*domain.Sale
 	for rows.Next() {
 		var s domain.Sale
-		if err := rows.Scan(&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status, &s.CreatedAt, &s.UpdatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status, &s.CreatedAt, &s.UpdatedAt, &s.Version); err != nil {
+			return nil, telemetry.Error(span, err)
 		}
 		sales = append(sales, &s)
 	}
@@ -202,25 +361,112 @@ func (r *postgresSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*do
 }
 
 func (r *postgresSaleRepository) GetSoldByPrice(ctx context.Context) ([]*domain.Sale, error) {
-	query := `SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at 
+	ctx, span := startSpan(ctx, "GetSoldByPrice", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version 
 	          FROM sales 
 	          WHERE status = $1 
 	          ORDER BY price ASC`
 
-	rows, err := r.db.QueryContext(ctx, query, domain.StatusSold)
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, domain.StatusSold)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 	defer rows.Close()
 
 	var sales []*domain.Sale
 	for rows.Next() {
 		var s domain.Sale
-		if err := rows.Scan(&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status, &s.CreatedAt, &s.UpdatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status, &s.CreatedAt, &s.UpdatedAt, &s.Version); err != nil {
+			return nil, telemetry.Error(span, err)
 		}
 		sales = append(sales, &s)
 	}
 
 	return sales, nil
 }
+
+func (r *postgresSaleRepository) CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error) {
+	ctx, span := startSpan(ctx, "CountByStatus", "SELECT")
+	defer span.End()
+
+	query := `SELECT status, COUNT(*) FROM sales GROUP BY status`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	counts := map[domain.SaleStatus]int{}
+	for rows.Next() {
+		var status domain.SaleStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, telemetry.Error(span, err)
+		}
+		counts[status] = count
+	}
+
+	return counts, telemetry.Error(span, rows.Err())
+}
+
+func (r *postgresSaleRepository) ForEachSold(ctx context.Context, from, to time.Time, fn func(sale *domain.Sale) error) error {
+	ctx, span := startSpan(ctx, "ForEachSold", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version 
+	          FROM sales 
+	          WHERE status = $1 AND sale_date >= $2 AND sale_date < $3 
+	          ORDER BY sale_date ASC`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, domain.StatusSold, from, to)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sale, err := scanSale(rows)
+		if err != nil {
+			return telemetry.Error(span, err)
+		}
+		if err := fn(sale); err != nil {
+			return telemetry.Error(span, err)
+		}
+	}
+
+	return telemetry.Error(span, rows.Err())
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSale(row rowScanner) (*domain.Sale, error) {
+	var s domain.Sale
+	var paymentID, buyerCPF sql.NullString
+	var saleDate sql.NullTime
+
+	err := row.Scan(
+		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
+		&paymentID, &buyerCPF, &saleDate,
+		&s.CreatedAt, &s.UpdatedAt, &s.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentID.Valid {
+		s.PaymentID = paymentID.String
+	}
+	if buyerCPF.Valid {
+		s.BuyerCPF = &buyerCPF.String
+	}
+	if saleDate.Valid {
+		s.SaleDate = &saleDate.Time
+	}
+
+	return &s, nil
+}
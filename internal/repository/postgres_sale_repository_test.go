@@ -10,7 +10,11 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry/telemetrytest"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type PostgresSaleRepositoryTestSuite struct {
@@ -40,6 +44,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Save() {
 		Status:    "available",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   1,
 	}
 
 	suite.T().Run("should save sale successfully", func(t *testing.T) {
@@ -53,6 +58,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Save() {
 				sale.Status,
 				sale.CreatedAt,
 				sale.UpdatedAt,
+				sale.Version,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -72,6 +78,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Save() {
 				sale.Status,
 				sale.CreatedAt,
 				sale.UpdatedAt,
+				sale.Version,
 			).
 			WillReturnError(errors.New("db error"))
 
@@ -82,6 +89,61 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Save() {
 	})
 }
 
+func (suite *PostgresSaleRepositoryTestSuite) Test_SaveBatch() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresSaleRepository(db)
+
+	now := time.Now()
+	sales := []*domain.Sale{
+		{ID: "sale-1", VehicleID: "vehicle-1", Brand: "Toyota", Model: "Corolla", Price: 50000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1},
+		{ID: "sale-2", VehicleID: "vehicle-2", Brand: "Honda", Model: "Civic", Price: 60000, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1},
+	}
+
+	suite.T().Run("should insert all sales in a single multi-row statement", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO sales \(.+\) VALUES \(\$1, .+, \$9\), \(\$10, .+, \$18\)`).
+			WithArgs(
+				"sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusAvailable, now, now, 1,
+				"sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusAvailable, now, now, 1,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err = repo.SaveBatch(context.Background(), sales)
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should do nothing for an empty batch", func(t *testing.T) {
+		err = repo.SaveBatch(context.Background(), nil)
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should rollback when insert fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO sales`).WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err = repo.SaveBatch(context.Background(), sales)
+		suite.EqualError(err, "db error")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when transaction cannot begin", func(t *testing.T) {
+		mock.ExpectBegin().WillReturnError(errors.New("begin error"))
+
+		err = repo.SaveBatch(context.Background(), sales)
+		suite.EqualError(err, "begin error")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
 func (suite *PostgresSaleRepositoryTestSuite) Test_Update() {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -94,21 +156,23 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Update() {
 	now := time.Now()
 	buyerCPF := "12345678900"
 	saleDate := now.Add(-time.Hour)
-	sale := &domain.Sale{
-		ID:        "sale-id",
-		VehicleID: "vehicle-id",
-		Brand:     "BrandX",
-		Model:     "ModelY",
-		Price:     10000.0,
-		Status:    "sold",
-		PaymentID: "payment-id",
-		BuyerCPF:  &buyerCPF,
-		SaleDate:  &saleDate,
-		UpdatedAt: now,
+	newSale := func() *domain.Sale {
+		return &domain.Sale{
+			ID:        "sale-id",
+			VehicleID: "vehicle-id",
+			Brand:     "BrandX",
+			Model:     "ModelY",
+			Price:     10000.0,
+			Status:    "sold",
+			PaymentID: "payment-id",
+			BuyerCPF:  &buyerCPF,
+			SaleDate:  &saleDate,
+			UpdatedAt: now,
+			Version:   3,
+		}
 	}
-
-	suite.T().Run("should update sale successfully", func(t *testing.T) {
-		mock.ExpectExec(`UPDATE sales`).
+	expectUpdate := func(sale *domain.Sale) *sqlmock.ExpectedExec {
+		return mock.ExpectExec(`UPDATE sales .+ version = version \+ 1 WHERE id = \$10 AND version = \$11`).
 			WithArgs(
 				sale.VehicleID,
 				sale.Brand,
@@ -120,16 +184,22 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Update() {
 				sql.NullTime{Time: saleDate, Valid: true},
 				sale.UpdatedAt,
 				sale.ID,
-			).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+				3,
+			)
+	}
+
+	suite.T().Run("should update sale successfully and increment the version", func(t *testing.T) {
+		sale := newSale()
+		expectUpdate(sale).WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Update(context.Background(), sale)
 		suite.NoError(err)
+		suite.Equal(4, sale.Version)
 		suite.NoError(mock.ExpectationsWereMet())
 	})
 
 	suite.T().Run("should update sale with nil BuyerCPF and SaleDate", func(t *testing.T) {
-		saleNoCPF := *sale
+		saleNoCPF := newSale()
 		saleNoCPF.BuyerCPF = nil
 		saleNoCPF.SaleDate = nil
 
@@ -145,29 +215,44 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_Update() {
 				sql.NullTime{Valid: false},
 				saleNoCPF.UpdatedAt,
 				saleNoCPF.ID,
+				saleNoCPF.Version,
 			).
-			WillReturnResult(sqlmock.NewResult(1, 1))
+			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := repo.Update(context.Background(), &saleNoCPF)
+		err := repo.Update(context.Background(), saleNoCPF)
 		suite.NoError(err)
 		suite.NoError(mock.ExpectationsWereMet())
 	})
 
+	suite.T().Run("should return ErrConcurrentModification when the version changed", func(t *testing.T) {
+		sale := newSale()
+		expectUpdate(sale).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT version FROM sales WHERE id = \$1`).
+			WithArgs("sale-id").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(5))
+
+		err := repo.Update(context.Background(), sale)
+		suite.ErrorIs(err, repository.ErrConcurrentModification)
+		suite.EqualError(err, "sale sale-id was modified concurrently: expected version 3, found 5")
+		suite.Equal(3, sale.Version)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return ErrSaleNotFound when the sale does not exist", func(t *testing.T) {
+		sale := newSale()
+		expectUpdate(sale).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT version FROM sales WHERE id = \$1`).
+			WithArgs("sale-id").
+			WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+		err := repo.Update(context.Background(), sale)
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectExec(`UPDATE sales`).
-			WithArgs(
-				sale.VehicleID,
-				sale.Brand,
-				sale.Model,
-				sale.Price,
-				sale.Status,
-				sql.NullString{String: sale.PaymentID, Valid: true},
-				sql.NullString{String: buyerCPF, Valid: true},
-				sql.NullTime{Time: saleDate, Valid: true},
-				sale.UpdatedAt,
-				sale.ID,
-			).
-			WillReturnError(errors.New("db error"))
+		sale := newSale()
+		expectUpdate(sale).WillReturnError(errors.New("db error"))
 
 		err := repo.Update(context.Background(), sale)
 		suite.Error(err)
@@ -192,14 +277,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByID() {
 	suite.T().Run("should get sale by id successfully with all fields", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD",
-				"payment-id", buyerCPF, saleDate, now, now,
+				"payment-id", buyerCPF, saleDate, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE id = \$1`).
 			WithArgs("sale-id").
 			WillReturnRows(rows)
 
@@ -225,14 +310,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByID() {
 	suite.T().Run("should get sale by id with nil BuyerCPF and SaleDate", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "sold",
-				"payment-id", nil, nil, now, now,
+				"payment-id", nil, nil, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE id = \$1`).
 			WithArgs("sale-id").
 			WillReturnRows(rows)
 
@@ -248,10 +333,10 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByID() {
 	suite.T().Run("should return error when sale not found", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		})
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE id = \$1`).
 			WithArgs("not-found-id").
 			WillReturnRows(rows)
 
@@ -263,7 +348,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByID() {
 	})
 
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE id = \$1`).
 			WithArgs("sale-id").
 			WillReturnError(errors.New("db error"))
 
@@ -291,14 +376,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByVehicleID() {
 	suite.T().Run("should get sale by vehicle_id successfully with all fields", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD",
-				"payment-id", buyerCPF, saleDate, now, now,
+				"payment-id", buyerCPF, saleDate, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE vehicle_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE vehicle_id = \$1`).
 			WithArgs("vehicle-id").
 			WillReturnRows(rows)
 
@@ -324,14 +409,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByVehicleID() {
 	suite.T().Run("should get sale by vehicle_id with nil BuyerCPF and SaleDate", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "sold",
-				"payment-id", nil, nil, now, now,
+				"payment-id", nil, nil, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE vehicle_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE vehicle_id = \$1`).
 			WithArgs("vehicle-id").
 			WillReturnRows(rows)
 
@@ -347,10 +432,10 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByVehicleID() {
 	suite.T().Run("should return error when sale not found", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		})
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE vehicle_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE vehicle_id = \$1`).
 			WithArgs("not-found-vehicle-id").
 			WillReturnRows(rows)
 
@@ -362,7 +447,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByVehicleID() {
 	})
 
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE vehicle_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE vehicle_id = \$1`).
 			WithArgs("vehicle-id").
 			WillReturnError(errors.New("db error"))
 
@@ -390,14 +475,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByPaymentID() {
 	suite.T().Run("should get sale by payment_id successfully with all fields", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "SOLD",
-				"payment-id", buyerCPF, saleDate, now, now,
+				"payment-id", buyerCPF, saleDate, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE payment_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE payment_id = \$1`).
 			WithArgs("payment-id").
 			WillReturnRows(rows)
 
@@ -423,14 +508,14 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByPaymentID() {
 	suite.T().Run("should get sale by payment_id with nil BuyerCPF and SaleDate", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		}).
 			AddRow(
 				"sale-id", "vehicle-id", "BrandX", "ModelY", 10000.0, "sold",
-				"payment-id", nil, nil, now, now,
+				"payment-id", nil, nil, now, now, 1,
 			)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE payment_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE payment_id = \$1`).
 			WithArgs("payment-id").
 			WillReturnRows(rows)
 
@@ -446,10 +531,10 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByPaymentID() {
 	suite.T().Run("should return error when sale not found", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "vehicle_id", "brand", "model", "price", "status",
-			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at",
+			"payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version",
 		})
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE payment_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE payment_id = \$1`).
 			WithArgs("not-found-payment-id").
 			WillReturnRows(rows)
 
@@ -461,7 +546,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetByPaymentID() {
 	})
 
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at FROM sales WHERE payment_id = \$1`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version FROM sales WHERE payment_id = \$1`).
 			WithArgs("payment-id").
 			WillReturnError(errors.New("db error"))
 
@@ -486,12 +571,12 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetAvailableByPrice() {
 
 	suite.T().Run("should return available sales ordered by price", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		}).
-			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", 5000.0, "AVAILABLE", now, now).
-			AddRow("sale-2", "vehicle-2", "BrandB", "ModelB", 7000.0, "AVAILABLE", now, now)
+			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", 5000.0, "AVAILABLE", now, now, 1).
+			AddRow("sale-2", "vehicle-2", "BrandB", "ModelB", 7000.0, "AVAILABLE", now, now, 2)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("AVAILABLE").
 			WillReturnRows(rows)
 
@@ -502,15 +587,16 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetAvailableByPrice() {
 		suite.Equal(5000.0, sales[0].Price)
 		suite.Equal("sale-2", sales[1].ID)
 		suite.Equal(7000.0, sales[1].Price)
+		suite.Equal(2, sales[1].Version)
 		suite.NoError(mock.ExpectationsWereMet())
 	})
 
 	suite.T().Run("should return empty slice if no available sales", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		})
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("AVAILABLE").
 			WillReturnRows(rows)
 
@@ -521,7 +607,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetAvailableByPrice() {
 	})
 
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("AVAILABLE").
 			WillReturnError(errors.New("db error"))
 
@@ -534,11 +620,11 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetAvailableByPrice() {
 
 	suite.T().Run("should return error when scan fails", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		}).
-			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", "invalid-price", "AVAILABLE", now, now)
+			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", "invalid-price", "AVAILABLE", now, now, 1)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("AVAILABLE").
 			WillReturnRows(rows)
 
@@ -563,12 +649,12 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetSoldByPrice() {
 
 	suite.T().Run("should return sold sales ordered by price", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		}).
-			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", 8000.0, "SOLD", now, now).
-			AddRow("sale-2", "vehicle-2", "BrandB", "ModelB", 12000.0, "SOLD", now, now)
+			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", 8000.0, "SOLD", now, now, 1).
+			AddRow("sale-2", "vehicle-2", "BrandB", "ModelB", 12000.0, "SOLD", now, now, 1)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("SOLD").
 			WillReturnRows(rows)
 
@@ -584,10 +670,10 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetSoldByPrice() {
 
 	suite.T().Run("should return empty slice if no sold sales", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		})
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("SOLD").
 			WillReturnRows(rows)
 
@@ -598,7 +684,7 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetSoldByPrice() {
 	})
 
 	suite.T().Run("should return error when db fails", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("SOLD").
 			WillReturnError(errors.New("db error"))
 
@@ -611,11 +697,11 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetSoldByPrice() {
 
 	suite.T().Run("should return error when scan fails", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at",
+			"id", "vehicle_id", "brand", "model", "price", "status", "created_at", "updated_at", "version",
 		}).
-			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", "invalid-price", "SOLD", now, now)
+			AddRow("sale-1", "vehicle-1", "BrandA", "ModelA", "invalid-price", "SOLD", now, now, 1)
 
-		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at FROM sales WHERE status = \$1 ORDER BY price ASC`).
+		mock.ExpectQuery(`SELECT id, vehicle_id, brand, model, price, status, created_at, updated_at, version FROM sales WHERE status = \$1 ORDER BY price ASC`).
 			WithArgs("SOLD").
 			WillReturnRows(rows)
 
@@ -626,3 +712,227 @@ func (suite *PostgresSaleRepositoryTestSuite) Test_GetSoldByPrice() {
 		suite.NoError(mock.ExpectationsWereMet())
 	})
 }
+
+func (suite *PostgresSaleRepositoryTestSuite) Test_ForEachSold() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresSaleRepository(db)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	saleDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	columns := []string{"id", "vehicle_id", "brand", "model", "price", "status", "payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version"}
+
+	suite.T().Run("should call fn for every sold sale in the range", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow("sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusSold, "payment-1", "12345678900", saleDate, now, now, 1).
+			AddRow("sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusSold, nil, nil, nil, now, now, 1)
+
+		mock.ExpectQuery(`SELECT (.+) FROM sales WHERE status = \$1 AND sale_date >= \$2 AND sale_date < \$3 ORDER BY sale_date ASC`).
+			WithArgs(domain.StatusSold, from, to).
+			WillReturnRows(rows)
+
+		var sales []*domain.Sale
+		err = repo.ForEachSold(context.Background(), from, to, func(sale *domain.Sale) error {
+			sales = append(sales, sale)
+			return nil
+		})
+		suite.NoError(err)
+		suite.Len(sales, 2)
+		suite.Equal("payment-1", sales[0].PaymentID)
+		suite.Equal("12345678900", *sales[0].BuyerCPF)
+		suite.Equal(saleDate, *sales[0].SaleDate)
+		suite.Empty(sales[1].PaymentID)
+		suite.Nil(sales[1].BuyerCPF)
+		suite.Nil(sales[1].SaleDate)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should stop when fn returns error", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow("sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusSold, nil, nil, nil, now, now, 1).
+			AddRow("sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusSold, nil, nil, nil, now, now, 1)
+
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnRows(rows)
+
+		calls := 0
+		err = repo.ForEachSold(context.Background(), from, to, func(sale *domain.Sale) error {
+			calls++
+			return errors.New("write error")
+		})
+		suite.EqualError(err, "write error")
+		suite.Equal(1, calls)
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnError(errors.New("db error"))
+
+		err = repo.ForEachSold(context.Background(), from, to, func(sale *domain.Sale) error { return nil })
+		suite.EqualError(err, "db error")
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id"}).AddRow("sale-1")
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnRows(rows)
+
+		err = repo.ForEachSold(context.Background(), from, to, func(sale *domain.Sale) error { return nil })
+		suite.Error(err)
+	})
+}
+
+func (suite *PostgresSaleRepositoryTestSuite) Test_GetByBuyerCPF() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresSaleRepository(db)
+
+	saleDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+	columns := []string{"id", "vehicle_id", "brand", "model", "price", "status", "payment_id", "buyer_cpf", "sale_date", "created_at", "updated_at", "version"}
+
+	suite.T().Run("should return the page of sales and the total for both CPF formats", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales WHERE buyer_cpf IN \(\$1, \$2\)`).
+			WithArgs("12345678900", "123.456.789-00").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("sale-1", "vehicle-1", "Toyota", "Corolla", 50000.0, domain.StatusSold, "payment-1", "123.456.789-00", saleDate, now, now, 1).
+			AddRow("sale-2", "vehicle-2", "Honda", "Civic", 60000.0, domain.StatusPendingPayment, "payment-2", "12345678900", nil, now, now, 1)
+		mock.ExpectQuery(`SELECT (.+) FROM sales WHERE buyer_cpf IN \(\$1, \$2\) ORDER BY sale_date DESC NULLS LAST, created_at DESC LIMIT \$3 OFFSET \$4`).
+			WithArgs("12345678900", "123.456.789-00", 2, 0).
+			WillReturnRows(rows)
+
+		sales, total, err := repo.GetByBuyerCPF(context.Background(), "123.456.789-00", 2, 0)
+		suite.NoError(err)
+		suite.Equal(3, total)
+		suite.Len(sales, 2)
+		suite.Equal("sale-1", sales[0].ID)
+		suite.Equal(saleDate, *sales[0].SaleDate)
+		suite.Nil(sales[1].SaleDate)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return empty slice if buyer has no sales", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnRows(sqlmock.NewRows(columns))
+
+		sales, total, err := repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+		suite.NoError(err)
+		suite.Zero(total)
+		suite.NotNil(sales)
+		suite.Len(sales, 0)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when count fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales`).WillReturnError(errors.New("db error"))
+
+		sales, total, err := repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+		suite.EqualError(err, "db error")
+		suite.Nil(sales)
+		suite.Zero(total)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnError(errors.New("db error"))
+
+		sales, _, err := repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+		suite.EqualError(err, "db error")
+		suite.Nil(sales)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sales`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`SELECT (.+) FROM sales`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("sale-1"))
+
+		sales, _, err := repo.GetByBuyerCPF(context.Background(), "12345678900", 20, 0)
+		suite.Error(err)
+		suite.Nil(sales)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (suite *PostgresSaleRepositoryTestSuite) Test_CountByStatus() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresSaleRepository(db)
+
+	suite.T().Run("should count sales per status", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("AVAILABLE", 3).
+			AddRow("SOLD", 2)
+		mock.ExpectQuery(`SELECT status, COUNT\(\*\) FROM sales GROUP BY status`).WillReturnRows(rows)
+
+		counts, err := repo.CountByStatus(context.Background())
+		suite.NoError(err)
+		suite.Equal(map[domain.SaleStatus]int{domain.StatusAvailable: 3, domain.StatusSold: 2}, counts)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when db fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT status`).WillReturnError(errors.New("db error"))
+
+		counts, err := repo.CountByStatus(context.Background())
+		suite.EqualError(err, "db error")
+		suite.Nil(counts)
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT status`).WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("SOLD", "many"))
+
+		counts, err := repo.CountByStatus(context.Background())
+		suite.Error(err)
+		suite.Nil(counts)
+	})
+}
+
+func (suite *PostgresSaleRepositoryTestSuite) Test_Tracing() {
+	exporter := telemetrytest.Install(suite.T())
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresSaleRepository(db)
+
+	suite.T().Run("should record a child span per query with the database attributes", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM sales WHERE vehicle_id = \$1`).
+			WithArgs("vehicle-traced").
+			WillReturnError(sql.ErrNoRows)
+
+		ctx, parent := telemetry.Start(context.Background(), "parent")
+		_, err := repo.GetByVehicleID(ctx, "vehicle-traced")
+		parent.End()
+		suite.Error(err)
+
+		spans := telemetrytest.Named(exporter, "postgresSaleRepository.GetByVehicleID")
+		suite.Require().Len(spans, 1)
+		span := spans[0]
+		suite.Equal(parent.SpanContext().SpanID(), span.Parent.SpanID())
+		suite.Equal(codes.Error, span.Status.Code)
+		suite.Contains(span.Attributes, attribute.String("db.system", "postgresql"))
+		suite.Contains(span.Attributes, attribute.String("db.collection.name", "sales"))
+		suite.Contains(span.Attributes, attribute.String("db.operation.name", "SELECT"))
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,62 @@
+package repositorytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/db/migrations"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+var schemaSeq atomic.Int64
+
+// PostgresSchema cria em baseURL um schema só para t, com as migrações aplicadas, e o apaga no
+// fim do teste. A URL devolvida usa o schema pelo search_path, então os testes podem rodar em
+// paralelo no mesmo banco.
+func PostgresSchema(t *testing.T, baseURL string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	admin, err := sql.Open("pgx", baseURL)
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	schema := fmt.Sprintf("test_%d_%d", os.Getpid(), schemaSeq.Add(1))
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatalf("could not create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		defer admin.Close()
+		if _, err := admin.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("could not drop schema %s: %v", schema, err)
+		}
+	})
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		t.Fatalf("database URL must be a postgres:// URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	// date_trunc segue o fuso da sessão; os relatórios assumem UTC.
+	query.Set("timezone", "UTC")
+	u.RawQuery = query.Encode()
+
+	db, err := sql.Open("pgx", u.String())
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	defer db.Close()
+	if err := migrations.Apply(ctx, db); err != nil {
+		t.Fatalf("could not apply migrations: %v", err)
+	}
+
+	return u.String()
+}
@@ -0,0 +1,379 @@
+// Package repositorytest reúne o contrato que toda implementação de repository.SaleRepository
+// deve cumprir. Cada implementação roda a mesma suíte no próprio teste.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+// SaleRepositorySuite é o contrato de repository.SaleRepository. New deve devolver um
+// repositório vazio a cada chamada.
+type SaleRepositorySuite struct {
+	suite.Suite
+	New func(t *testing.T) repository.SaleRepository
+
+	ctx  context.Context
+	repo repository.SaleRepository
+	base time.Time
+}
+
+func (suite *SaleRepositorySuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.repo = suite.New(suite.T())
+	// Postgres guarda microssegundos; datas mais precisas não voltariam iguais.
+	suite.base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *SaleRepositorySuite) sale(n int, status domain.SaleStatus, price float64) *domain.Sale {
+	created := suite.base.Add(time.Duration(n) * time.Minute)
+	return &domain.Sale{
+		ID:        fmt.Sprintf("sale-%d", n),
+		VehicleID: fmt.Sprintf("vehicle-%d", n),
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     price,
+		Status:    status,
+		CreatedAt: created,
+		UpdatedAt: created,
+		Version:   1,
+	}
+}
+
+func (suite *SaleRepositorySuite) sold(n int, price float64, cpf string, saleDate time.Time) *domain.Sale {
+	sale := suite.sale(n, domain.StatusSold, price)
+	sale.PaymentID = fmt.Sprintf("payment-%d", n)
+	sale.BuyerCPF = &cpf
+	sale.SaleDate = &saleDate
+	return sale
+}
+
+func (suite *SaleRepositorySuite) save(sales ...*domain.Sale) {
+	for _, sale := range sales {
+		suite.Require().NoError(suite.repo.Save(suite.ctx, sale))
+	}
+}
+
+func (suite *SaleRepositorySuite) assertSale(expected, actual *domain.Sale) {
+	suite.Require().NotNil(actual)
+	suite.Equal(expected.ID, actual.ID)
+	suite.Equal(expected.VehicleID, actual.VehicleID)
+	suite.Equal(expected.Brand, actual.Brand)
+	suite.Equal(expected.Model, actual.Model)
+	suite.Equal(expected.Price, actual.Price)
+	suite.Equal(expected.Status, actual.Status)
+	suite.Equal(expected.PaymentID, actual.PaymentID)
+	suite.Equal(expected.BuyerCPF, actual.BuyerCPF)
+	suite.True(expected.CreatedAt.Equal(actual.CreatedAt), "created_at: %v != %v", expected.CreatedAt, actual.CreatedAt)
+	suite.True(expected.UpdatedAt.Equal(actual.UpdatedAt), "updated_at: %v != %v", expected.UpdatedAt, actual.UpdatedAt)
+	suite.Equal(expected.Version, actual.Version)
+	if expected.SaleDate == nil {
+		suite.Nil(actual.SaleDate)
+	} else if suite.NotNil(actual.SaleDate) {
+		suite.True(expected.SaleDate.Equal(*actual.SaleDate), "sale_date: %v != %v", *expected.SaleDate, *actual.SaleDate)
+	}
+}
+
+func ids(sales []*domain.Sale) []string {
+	result := make([]string, 0, len(sales))
+	for _, sale := range sales {
+		result = append(result, sale.ID)
+	}
+	return result
+}
+
+func (suite *SaleRepositorySuite) Test_SaveAndGet() {
+	sale := suite.sale(1, domain.StatusAvailable, 50000.5)
+	suite.save(sale)
+
+	suite.T().Run("should find the sale by id", func(t *testing.T) {
+		found, err := suite.repo.GetByID(suite.ctx, sale.ID)
+
+		suite.NoError(err)
+		suite.assertSale(sale, found)
+	})
+
+	suite.T().Run("should find the sale by vehicle id", func(t *testing.T) {
+		found, err := suite.repo.GetByVehicleID(suite.ctx, sale.VehicleID)
+
+		suite.NoError(err)
+		suite.assertSale(sale, found)
+	})
+
+	suite.T().Run("should reject a second sale with the same id", func(t *testing.T) {
+		suite.Error(suite.repo.Save(suite.ctx, sale))
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_NotFound() {
+	suite.T().Run("should return ErrSaleNotFound for an unknown id", func(t *testing.T) {
+		sale, err := suite.repo.GetByID(suite.ctx, "missing")
+
+		suite.Nil(sale)
+		suite.EqualError(err, "sale not found")
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+
+	suite.T().Run("should return ErrSaleNotFound for an unknown vehicle id", func(t *testing.T) {
+		sale, err := suite.repo.GetByVehicleID(suite.ctx, "missing")
+
+		suite.Nil(sale)
+		suite.EqualError(err, "sale listing for the given vehicle_id not found")
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+
+	suite.T().Run("should return ErrSaleNotFound for an unknown payment id", func(t *testing.T) {
+		sale, err := suite.repo.GetByPaymentID(suite.ctx, "missing")
+
+		suite.Nil(sale)
+		suite.EqualError(err, "sale not found for the given payment_id")
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_Update() {
+	sale := suite.sale(1, domain.StatusAvailable, 50000)
+	suite.save(sale)
+
+	suite.T().Run("should persist the purchase fields", func(t *testing.T) {
+		updated := suite.sold(1, 48000, "123.456.789-00", suite.base.Add(time.Hour))
+		updated.UpdatedAt = suite.base.Add(time.Hour)
+
+		suite.NoError(suite.repo.Update(suite.ctx, updated))
+		suite.Equal(2, updated.Version)
+
+		found, err := suite.repo.GetByPaymentID(suite.ctx, "payment-1")
+		suite.NoError(err)
+		suite.assertSale(updated, found)
+	})
+
+	suite.T().Run("should clear the purchase fields", func(t *testing.T) {
+		canceled := suite.sale(1, domain.StatusAvailable, 48000)
+		canceled.Version = 2
+
+		suite.NoError(suite.repo.Update(suite.ctx, canceled))
+
+		found, err := suite.repo.GetByID(suite.ctx, sale.ID)
+		suite.NoError(err)
+		suite.assertSale(canceled, found)
+	})
+
+	suite.T().Run("should reject a sale read before the last update", func(t *testing.T) {
+		stale := suite.sale(1, domain.StatusCanceled, 1)
+
+		err := suite.repo.Update(suite.ctx, stale)
+
+		suite.ErrorIs(err, repository.ErrConcurrentModification)
+		var conflict *repository.ConcurrentModificationError
+		suite.Require().ErrorAs(err, &conflict)
+		suite.Equal(1, conflict.Expected)
+		suite.Equal(3, conflict.Current)
+		suite.Equal(1, stale.Version)
+
+		found, err := suite.repo.GetByID(suite.ctx, sale.ID)
+		suite.NoError(err)
+		suite.Equal(domain.StatusAvailable, found.Status)
+		suite.Equal(3, found.Version)
+	})
+
+	suite.T().Run("should return ErrSaleNotFound for an unknown id", func(t *testing.T) {
+		err := suite.repo.Update(suite.ctx, suite.sale(9, domain.StatusAvailable, 1))
+
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_SaveBatch() {
+	suite.T().Run("should save every sale", func(t *testing.T) {
+		batch := []*domain.Sale{
+			suite.sale(1, domain.StatusAvailable, 30000),
+			suite.sale(2, domain.StatusAvailable, 10000),
+			suite.sale(3, domain.StatusAvailable, 20000),
+		}
+
+		suite.NoError(suite.repo.SaveBatch(suite.ctx, batch))
+
+		sales, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Equal([]string{"sale-2", "sale-3", "sale-1"}, ids(sales))
+	})
+
+	suite.T().Run("should accept an empty batch", func(t *testing.T) {
+		suite.NoError(suite.repo.SaveBatch(suite.ctx, nil))
+	})
+
+	suite.T().Run("should save nothing when one sale is rejected", func(t *testing.T) {
+		batch := []*domain.Sale{suite.sale(4, domain.StatusAvailable, 40000), suite.sale(1, domain.StatusAvailable, 50000)}
+
+		suite.Error(suite.repo.SaveBatch(suite.ctx, batch))
+
+		_, err := suite.repo.GetByID(suite.ctx, "sale-4")
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_ListByPrice() {
+	saleDate := suite.base.Add(time.Hour)
+	suite.save(
+		suite.sale(1, domain.StatusAvailable, 30000),
+		suite.sale(2, domain.StatusPendingPayment, 5000),
+		suite.sold(3, 25000, "12345678900", saleDate),
+		suite.sale(4, domain.StatusAvailable, 10000),
+		suite.sold(5, 15000, "12345678900", saleDate),
+		suite.sale(6, domain.StatusCanceled, 1000),
+	)
+
+	suite.T().Run("should list only available sales, cheapest first", func(t *testing.T) {
+		sales, err := suite.repo.GetAvailableByPrice(suite.ctx)
+
+		suite.NoError(err)
+		suite.Equal([]string{"sale-4", "sale-1"}, ids(sales))
+	})
+
+	suite.T().Run("should list only sold sales, cheapest first", func(t *testing.T) {
+		sales, err := suite.repo.GetSoldByPrice(suite.ctx)
+
+		suite.NoError(err)
+		suite.Equal([]string{"sale-5", "sale-3"}, ids(sales))
+	})
+
+	suite.T().Run("should count sales per status", func(t *testing.T) {
+		counts, err := suite.repo.CountByStatus(suite.ctx)
+
+		suite.NoError(err)
+		suite.Equal(map[domain.SaleStatus]int{
+			domain.StatusAvailable:      2,
+			domain.StatusPendingPayment: 1,
+			domain.StatusSold:           2,
+			domain.StatusCanceled:       1,
+		}, counts)
+	})
+
+	suite.T().Run("should return the current version of each sale", func(t *testing.T) {
+		updated, err := suite.repo.GetByID(suite.ctx, "sale-4")
+		suite.Require().NoError(err)
+		updated.Model = "Corolla Cross"
+		suite.Require().NoError(suite.repo.Update(suite.ctx, updated))
+
+		sales, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Require().Len(sales, 2)
+		suite.assertSale(updated, sales[0])
+		suite.Equal(1, sales[1].Version)
+
+		sold, err := suite.repo.GetSoldByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Require().Len(sold, 2)
+		suite.Equal(1, sold[0].Version)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_EmptyRepository() {
+	suite.T().Run("should return no sales and no counts", func(t *testing.T) {
+		available, err := suite.repo.GetAvailableByPrice(suite.ctx)
+		suite.NoError(err)
+		suite.Empty(available)
+
+		counts, err := suite.repo.CountByStatus(suite.ctx)
+		suite.NoError(err)
+		suite.Empty(counts)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_GetByBuyerCPF() {
+	pending := suite.sale(4, domain.StatusPendingPayment, 40000)
+	pending.PaymentID = "payment-4"
+	pendingCPF := "12345678900"
+	pending.BuyerCPF = &pendingCPF
+
+	suite.save(
+		suite.sale(1, domain.StatusAvailable, 10000),
+		suite.sale(2, domain.StatusAvailable, 20000),
+		suite.sale(3, domain.StatusAvailable, 30000),
+		suite.sale(4, domain.StatusAvailable, 40000),
+		suite.sale(5, domain.StatusAvailable, 50000),
+	)
+	for _, sale := range []*domain.Sale{
+		suite.sold(1, 10000, "123.456.789-00", suite.base.Add(1*time.Hour)),
+		suite.sold(2, 20000, "12345678900", suite.base.Add(3*time.Hour)),
+		suite.sold(3, 30000, "123.456.789-00", suite.base.Add(2*time.Hour)),
+		pending,
+		suite.sold(5, 50000, "98765432100", suite.base.Add(4*time.Hour)),
+	} {
+		suite.Require().NoError(suite.repo.Update(suite.ctx, sale))
+	}
+
+	suite.T().Run("should match both CPF formats, newest sale first and pending last", func(t *testing.T) {
+		sales, total, err := suite.repo.GetByBuyerCPF(suite.ctx, "123.456.789-00", 10, 0)
+
+		suite.NoError(err)
+		suite.Equal(4, total)
+		suite.Equal([]string{"sale-2", "sale-3", "sale-1", "sale-4"}, ids(sales))
+	})
+
+	suite.T().Run("should page with limit and offset while counting every sale", func(t *testing.T) {
+		sales, total, err := suite.repo.GetByBuyerCPF(suite.ctx, "12345678900", 2, 1)
+
+		suite.NoError(err)
+		suite.Equal(4, total)
+		suite.Equal([]string{"sale-3", "sale-1"}, ids(sales))
+	})
+
+	suite.T().Run("should return an empty page for an unknown buyer", func(t *testing.T) {
+		sales, total, err := suite.repo.GetByBuyerCPF(suite.ctx, "11122233344", 10, 0)
+
+		suite.NoError(err)
+		suite.Zero(total)
+		suite.NotNil(sales)
+		suite.Empty(sales)
+	})
+}
+
+func (suite *SaleRepositorySuite) Test_ForEachSold() {
+	from := suite.base
+	to := suite.base.Add(24 * time.Hour)
+
+	suite.save(
+		suite.sale(1, domain.StatusAvailable, 10000),
+		suite.sale(2, domain.StatusAvailable, 20000),
+		suite.sale(3, domain.StatusAvailable, 30000),
+		suite.sale(4, domain.StatusAvailable, 40000),
+	)
+	for _, sale := range []*domain.Sale{
+		suite.sold(1, 10000, "12345678900", from.Add(5*time.Hour)),
+		suite.sold(2, 20000, "12345678900", from),
+		suite.sold(3, 30000, "12345678900", to),
+	} {
+		suite.Require().NoError(suite.repo.Update(suite.ctx, sale))
+	}
+
+	suite.T().Run("should visit the sold sales in the half-open range ordered by sale date", func(t *testing.T) {
+		var visited []*domain.Sale
+		err := suite.repo.ForEachSold(suite.ctx, from, to, func(sale *domain.Sale) error {
+			visited = append(visited, sale)
+			return nil
+		})
+
+		suite.NoError(err)
+		suite.Equal([]string{"sale-2", "sale-1"}, ids(visited))
+		suite.Equal("payment-2", visited[0].PaymentID)
+	})
+
+	suite.T().Run("should stop at the first error from fn", func(t *testing.T) {
+		calls := 0
+		err := suite.repo.ForEachSold(suite.ctx, from, to, func(sale *domain.Sale) error {
+			calls++
+			return errors.New("write error")
+		})
+
+		suite.EqualError(err, "write error")
+		suite.Equal(1, calls)
+	})
+}
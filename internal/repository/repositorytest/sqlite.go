@@ -0,0 +1,28 @@
+package repositorytest
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/db/migrations"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/database"
+)
+
+// OpenSQLite cria um banco SQLite novo em um diretório temporário, com as migrações aplicadas.
+// Cada chamada tem o próprio arquivo, então os testes podem rodar em paralelo.
+func OpenSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "showcase.db")
+	db, err := database.OpenSQLite(context.Background(), database.Config{DSN: "file:" + path})
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := migrations.ApplySQLite(context.Background(), db); err != nil {
+		t.Fatalf("could not apply migrations: %v", err)
+	}
+	return db
+}
@@ -2,17 +2,61 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 )
 
+// ErrSaleNotFound é devolvido, direta ou indiretamente, por todas as buscas de uma venda só.
+// Use errors.Is para reconhecê-lo; as mensagens de busca por veículo e por pagamento são mais específicas.
+var ErrSaleNotFound = errors.New("sale not found")
+
+var (
+	errSaleNotFoundByVehicle = saleNotFoundError("sale listing for the given vehicle_id not found")
+	errSaleNotFoundByPayment = saleNotFoundError("sale not found for the given payment_id")
+)
+
+type saleNotFoundError string
+
+func (e saleNotFoundError) Error() string { return string(e) }
+
+func (e saleNotFoundError) Is(target error) bool { return target == ErrSaleNotFound }
+
+// ErrConcurrentModification é devolvido pelo Update quando a venda mudou desde que foi lida. O
+// erro concreto é um *ConcurrentModificationError; use errors.Is para reconhecê-lo.
+var ErrConcurrentModification = errors.New("sale was modified concurrently")
+
+// ConcurrentModificationError informa a versão que o Update esperava e a que estava gravada.
+type ConcurrentModificationError struct {
+	SaleID   string
+	Expected int
+	Current  int
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("sale %s was modified concurrently: expected version %d, found %d", e.SaleID, e.Expected, e.Current)
+}
+
+func (e *ConcurrentModificationError) Is(target error) bool {
+	return target == ErrConcurrentModification
+}
+
 //go:generate mockgen -source=sale_repository.go -destination=./mocks/sale_repository_mock.go -package=mocks
 type SaleRepository interface {
 	Save(ctx context.Context, sale *domain.Sale) error
+	SaveBatch(ctx context.Context, sales []*domain.Sale) error
+	// Update só grava se a venda ainda estiver em sale.Version; nesse caso incrementa a versão no
+	// banco e em sale. Caso contrário devolve ErrConcurrentModification, ou ErrSaleNotFound se o
+	// ID não existir.
 	Update(ctx context.Context, sale *domain.Sale) error
 	GetByID(ctx context.Context, id string) (*domain.Sale, error)
 	GetByVehicleID(ctx context.Context, vehicleID string) (*domain.Sale, error)
 	GetByPaymentID(ctx context.Context, paymentID string) (*domain.Sale, error)
+	GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error)
 	GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error)
 	GetSoldByPrice(ctx context.Context) ([]*domain.Sale, error)
+	CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error)
+	ForEachSold(ctx context.Context, from, to time.Time, fn func(sale *domain.Sale) error) error
 }
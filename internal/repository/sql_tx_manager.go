@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+// sqlTxKey guarda no ctx a transação aberta sobre um *sql.DB; a chave inclui o banco para que um
+// repositório nunca use a transação de outra conexão.
+type sqlTxKey struct {
+	db *sql.DB
+}
+
+// sqlExecutor é o que *sql.DB e *sql.Tx têm em comum e os repositórios usam.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// sqlConn devolve a transação de db que estiver no ctx ou, sem transação, o próprio db.
+func sqlConn(ctx context.Context, db *sql.DB) sqlExecutor {
+	if tx, ok := ctx.Value(sqlTxKey{db}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// sqlTxManager abre transações de database/sql; serve para o Postgres e para o SQLite.
+type sqlTxManager struct {
+	db *sql.DB
+}
+
+func NewSQLTxManager(db *sql.DB) TxManager {
+	return &sqlTxManager{
+		db: db,
+	}
+}
+
+func (m *sqlTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinSQLTx(ctx, m.db, fn)
+}
+
+func withinSQLTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(sqlTxKey{db}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollbackSQL(ctx, tx)
+			panic(p)
+		}
+	}()
+
+	txCtx, hooks := withAfterCommit(context.WithValue(ctx, sqlTxKey{db}, tx))
+	if err := fn(txCtx); err != nil {
+		rollbackSQL(ctx, tx)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	hooks.run(ctx)
+	return nil
+}
+
+func rollbackSQL(ctx context.Context, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		slog.WarnContext(ctx, "could not roll back transaction", "error", err)
+	}
+}
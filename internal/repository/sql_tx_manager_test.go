@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+type SQLTxManagerTestSuite struct {
+	suite.Suite
+}
+
+func Test_SQLTxManagerTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SQLTxManagerTestSuite))
+}
+
+func (suite *SQLTxManagerTestSuite) Test_WithinTx() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	txManager := repository.NewSQLTxManager(db)
+	repo := repository.NewPostgresSaleRepository(db)
+	now := time.Now()
+	sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+
+	suite.T().Run("should run the repository calls in the transaction and commit", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO sales`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO sales`).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := repo.Save(ctx, sale); err != nil {
+				return err
+			}
+			// O SaveBatch entra na transação aberta em vez de abrir outra.
+			return repo.SaveBatch(ctx, []*domain.Sale{sale, sale})
+		})
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should roll back when fn returns an error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO sales`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := repo.Save(ctx, sale); err != nil {
+				return err
+			}
+			return errors.New("outbox error")
+		})
+		suite.EqualError(err, "outbox error")
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should roll back and panic again when fn panics", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		suite.PanicsWithValue("boom", func() {
+			_ = txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should join the transaction already in the context", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO sales`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			return txManager.WithinTx(ctx, func(ctx context.Context) error {
+				return repo.Save(ctx, sale)
+			})
+		})
+		suite.NoError(err)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should run after commit hooks only once the outer transaction commits", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		var calls []string
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+				repository.AfterCommit(ctx, func(context.Context) { calls = append(calls, "inner") })
+				return nil
+			})
+			repository.AfterCommit(ctx, func(context.Context) { calls = append(calls, "outer") })
+			suite.Empty(calls)
+			return err
+		})
+		suite.NoError(err)
+		suite.Equal([]string{"inner", "outer"}, calls)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should drop after commit hooks when the commit fails", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectCommit().WillReturnError(errors.New("commit failed"))
+
+		called := false
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			repository.AfterCommit(ctx, func(context.Context) { called = true })
+			return nil
+		})
+		suite.EqualError(err, "commit failed")
+		suite.False(called)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+
+	suite.T().Run("should return error when the transaction cannot begin", func(t *testing.T) {
+		mock.ExpectBegin().WillReturnError(errors.New("db down"))
+
+		called := false
+		err := txManager.WithinTx(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		suite.EqualError(err, "db down")
+		suite.False(called)
+		suite.NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (suite *SQLTxManagerTestSuite) Test_SQLiteRollback() {
+	suite.T().Run("should undo every write made inside a failed transaction", func(t *testing.T) {
+		db := repositorytest.OpenSQLite(t)
+		txManager := repository.NewSQLTxManager(db)
+		repo := repository.NewSQLiteSaleRepository(db)
+		ctx := context.Background()
+		now := time.Now()
+
+		existing := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Price: 100, Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+		suite.Require().NoError(repo.Save(ctx, existing))
+
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			sale, err := repo.GetByID(ctx, "sale-1")
+			if err != nil {
+				return err
+			}
+			sale.Price = 200
+			if err := repo.Update(ctx, sale); err != nil {
+				return err
+			}
+			created := &domain.Sale{ID: "sale-2", VehicleID: "vehicle-2", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+			if err := repo.Save(ctx, created); err != nil {
+				return err
+			}
+			return errors.New("history error")
+		})
+		suite.EqualError(err, "history error")
+
+		found, err := repo.GetByID(ctx, "sale-1")
+		suite.NoError(err)
+		suite.Equal(100.0, found.Price)
+		suite.Equal(1, found.Version)
+		_, err = repo.GetByID(ctx, "sale-2")
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+// sqlitePeriodColumns trunca sale_date como o date_trunc do Postgres, no mesmo formato de texto
+// das outras datas. date(x, '-6 days', 'weekday 1') é a segunda-feira da semana de x.
+var sqlitePeriodColumns = map[string]string{
+	AnalyticsPeriodDay:   `date(sale_date) || 'T00:00:00.000000Z'`,
+	AnalyticsPeriodWeek:  `date(sale_date, '-6 days', 'weekday 1') || 'T00:00:00.000000Z'`,
+	AnalyticsPeriodMonth: `date(sale_date, 'start of month') || 'T00:00:00.000000Z'`,
+}
+
+type sqliteAnalyticsRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteAnalyticsRepository(db *sql.DB) AnalyticsRepository {
+	return &sqliteAnalyticsRepository{
+		db: db,
+	}
+}
+
+func (r *sqliteAnalyticsRepository) AggregateSales(ctx context.Context, filter SalesAnalyticsFilter) ([]*domain.SalesAggregate, error) {
+	periodColumn, brandColumn, modelColumn := "NULL", "''", "''"
+	var groupBy []string
+
+	switch filter.Period {
+	case "":
+	case AnalyticsPeriodDay, AnalyticsPeriodWeek, AnalyticsPeriodMonth:
+		periodColumn = sqlitePeriodColumns[filter.Period]
+		groupBy = append(groupBy, "period")
+	default:
+		return nil, fmt.Errorf("invalid analytics period: %s", filter.Period)
+	}
+
+	switch filter.GroupBy {
+	case "":
+	case AnalyticsGroupByBrand:
+		brandColumn = "brand"
+		groupBy = append(groupBy, brandColumn)
+	case AnalyticsGroupByModel:
+		brandColumn, modelColumn = "brand", "model"
+		groupBy = append(groupBy, brandColumn, modelColumn)
+	default:
+		return nil, fmt.Errorf("invalid analytics grouping: %s", filter.GroupBy)
+	}
+
+	query := fmt.Sprintf(`SELECT %s AS period, %s AS brand, %s AS model,
+	          COUNT(*) FILTER (WHERE status = $4) AS units_sold,
+	          COALESCE(SUM(price) FILTER (WHERE status = $4), 0) AS revenue,
+	          COALESCE(AVG(unixepoch(sale_date, 'subsec') - unixepoch(created_at, 'subsec')) FILTER (WHERE status = $4), 0) AS avg_seconds_to_sale,
+	          COUNT(*) AS reservations
+	          FROM sales
+	          WHERE sale_date >= $1 AND sale_date < $2 AND status <> $3`, periodColumn, brandColumn, modelColumn)
+	if len(groupBy) > 0 {
+		columns := strings.Join(groupBy, ", ")
+		query += fmt.Sprintf(`
+	          GROUP BY %s
+	          ORDER BY %s`, columns, columns)
+	}
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query,
+		formatSQLiteTime(filter.From), formatSQLiteTime(filter.To), string(domain.StatusAvailable), string(domain.StatusSold))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aggregates := []*domain.SalesAggregate{}
+	for rows.Next() {
+		var a domain.SalesAggregate
+		var period sql.NullString
+		var avgSeconds float64
+
+		if err := rows.Scan(&period, &a.Brand, &a.Model, &a.UnitsSold, &a.Revenue, &avgSeconds, &a.Reservations); err != nil {
+			return nil, err
+		}
+
+		if a.PeriodStart, err = parseSQLiteNullTime(period); err != nil {
+			return nil, err
+		}
+		a.AvgTimeToSale = time.Duration(avgSeconds * float64(time.Second))
+
+		aggregates = append(aggregates, &a)
+	}
+
+	return aggregates, rows.Err()
+}
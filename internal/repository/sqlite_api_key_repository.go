@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+type sqliteAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &sqliteAPIKeyRepository{
+		db: db,
+	}
+}
+
+// Os escopos ficam em uma coluna TEXT separados por vírgula, no mesmo formato em que trafegam
+// no repositório do Postgres.
+func (r *sqliteAPIKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	query := `INSERT INTO api_keys (id, name, prefix, key_hash, scopes, expires_at, created_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		strings.Join(key.Scopes, ","),
+		formatSQLiteNullTime(key.ExpiresAt),
+		formatSQLiteTime(key.CreatedAt),
+	)
+
+	return err
+}
+
+func (r *sqliteAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT id, name, prefix, key_hash, scopes, expires_at, revoked_at, created_at
+	          FROM api_keys
+	          WHERE key_hash = $1`
+
+	key, err := scanSQLiteAPIKey(sqlConn(ctx, r.db).QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *sqliteAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	query := `SELECT id, name, prefix, key_hash, scopes, expires_at, revoked_at, created_at
+	          FROM api_keys
+	          ORDER BY created_at ASC, id ASC`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanSQLiteAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *sqliteAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, query, id, formatSQLiteTime(revokedAt))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanSQLiteAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes, createdAt string
+	var expiresAt, revokedAt sql.NullString
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &expiresAt, &revokedAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if key.CreatedAt, err = parseSQLiteTime(createdAt); err != nil {
+		return nil, err
+	}
+	if key.ExpiresAt, err = parseSQLiteNullTime(expiresAt); err != nil {
+		return nil, err
+	}
+	if key.RevokedAt, err = parseSQLiteNullTime(revokedAt); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
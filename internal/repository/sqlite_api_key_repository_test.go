@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+type SQLiteAPIKeyRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_SQLiteAPIKeyRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SQLiteAPIKeyRepositoryTestSuite))
+}
+
+func (suite *SQLiteAPIKeyRepositoryTestSuite) Test_Lifecycle() {
+	ctx := context.Background()
+	repo := repository.NewSQLiteAPIKeyRepository(repositorytest.OpenSQLite(suite.T()))
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.AddDate(0, 1, 0)
+	key := &domain.APIKey{
+		ID: "key-1", Name: "payments", Prefix: "sk_abc", KeyHash: "hash-1",
+		Scopes: []string{"payment-gateway", "sales:read"}, ExpiresAt: &expires, CreatedAt: now,
+	}
+
+	suite.T().Run("should save and find the key by hash", func(t *testing.T) {
+		suite.NoError(repo.Save(ctx, key))
+
+		found, err := repo.GetByHash(ctx, "hash-1")
+
+		suite.NoError(err)
+		suite.Equal(key, found)
+	})
+
+	suite.T().Run("should reject a duplicated hash", func(t *testing.T) {
+		suite.ErrorContains(repo.Save(ctx, &domain.APIKey{ID: "key-2", KeyHash: "hash-1", CreatedAt: now}), "UNIQUE constraint failed")
+	})
+
+	suite.T().Run("should return ErrAPIKeyNotFound for an unknown hash", func(t *testing.T) {
+		_, err := repo.GetByHash(ctx, "missing")
+
+		suite.ErrorIs(err, repository.ErrAPIKeyNotFound)
+	})
+
+	suite.T().Run("should revoke only once", func(t *testing.T) {
+		suite.NoError(repo.Revoke(ctx, "key-1", now.Add(time.Hour)))
+		suite.ErrorIs(repo.Revoke(ctx, "key-1", now.Add(2*time.Hour)), repository.ErrAPIKeyNotFound)
+		suite.ErrorIs(repo.Revoke(ctx, "missing", now), repository.ErrAPIKeyNotFound)
+
+		keys, err := repo.List(ctx)
+		suite.NoError(err)
+		suite.Require().Len(keys, 1)
+		suite.Equal(now.Add(time.Hour), *keys[0].RevokedAt)
+	})
+}
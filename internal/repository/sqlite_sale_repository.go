@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqliteTimeLayout tem largura fixa, então comparar o texto equivale a comparar as datas. A
+// precisão de microssegundos é a mesma do TIMESTAMPTZ do Postgres.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000Z"
+
+// sqliteSaleRepository guarda as vendas em um arquivo SQLite, para instalações de um nó só que
+// não têm um servidor Postgres.
+type sqliteSaleRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteSaleRepository(db *sql.DB) SaleRepository {
+	return &sqliteSaleRepository{
+		db: db,
+	}
+}
+
+func startSQLiteSpan(ctx context.Context, method, operation string) (context.Context, trace.Span) {
+	return startSalesSpan(ctx, semconv.DBSystemSqlite, "sqliteSaleRepository."+method, operation)
+}
+
+func (r *sqliteSaleRepository) Save(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := startSQLiteSpan(ctx, "Save", "INSERT")
+	defer span.End()
+
+	_, err := sqlConn(ctx, r.db).ExecContext(ctx, sqliteInsertSale, sqliteSaleArgs(sale)...)
+	return telemetry.Error(span, err)
+}
+
+const sqliteInsertSale = `INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at, version)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+func sqliteSaleArgs(sale *domain.Sale) []any {
+	return []any{
+		sale.ID,
+		sale.VehicleID,
+		sale.Brand,
+		sale.Model,
+		sale.Price,
+		string(sale.Status),
+		formatSQLiteTime(sale.CreatedAt),
+		formatSQLiteTime(sale.UpdatedAt),
+		sale.Version,
+	}
+}
+
+// SaveBatch grava o lote em uma transação com um INSERT preparado; no SQLite isso é tão rápido
+// quanto um INSERT com várias linhas e não esbarra no limite de parâmetros.
+func (r *sqliteSaleRepository) SaveBatch(ctx context.Context, sales []*domain.Sale) error {
+	ctx, span := startSQLiteSpan(ctx, "SaveBatch", "INSERT")
+	defer span.End()
+
+	if len(sales) == 0 {
+		return nil
+	}
+
+	err := withinSQLTx(ctx, r.db, func(ctx context.Context) error {
+		stmt, err := sqlConn(ctx, r.db).PrepareContext(ctx, sqliteInsertSale)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, sale := range sales {
+			if _, err := stmt.ExecContext(ctx, sqliteSaleArgs(sale)...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	slog.DebugContext(ctx, "sales batch inserted", "rows", len(sales))
+	return nil
+}
+
+func (r *sqliteSaleRepository) Update(ctx context.Context, sale *domain.Sale) error {
+	ctx, span := startSQLiteSpan(ctx, "Update", "UPDATE")
+	defer span.End()
+
+	query := `UPDATE sales
+	          SET vehicle_id = $1, brand = $2, model = $3, price = $4, status = $5,
+	              payment_id = $6, buyer_cpf = $7, sale_date = $8, updated_at = $9, version = version + 1
+	          WHERE id = $10 AND version = $11`
+
+	var paymentID sql.NullString
+	if sale.PaymentID != "" {
+		paymentID = sql.NullString{String: sale.PaymentID, Valid: true}
+	}
+
+	result, err := sqlConn(ctx, r.db).ExecContext(ctx, query,
+		sale.VehicleID,
+		sale.Brand,
+		sale.Model,
+		sale.Price,
+		string(sale.Status),
+		paymentID,
+		sale.BuyerCPF,
+		formatSQLiteNullTime(sale.SaleDate),
+		formatSQLiteTime(sale.UpdatedAt),
+		sale.ID,
+		sale.Version,
+	)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+
+	return telemetry.Error(span, checkVersionedUpdate(ctx, sqlConn(ctx, r.db), result, sale))
+}
+
+func (r *sqliteSaleRepository) GetByID(ctx context.Context, id string) (*domain.Sale, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetByID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE id = $1`
+
+	sale, err := scanSQLiteSale(sqlConn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrSaleNotFound
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+func (r *sqliteSaleRepository) GetByVehicleID(ctx context.Context, vehicleID string) (*domain.Sale, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetByVehicleID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE vehicle_id = $1
+	          ORDER BY created_at ASC, id ASC
+	          LIMIT 1`
+
+	sale, err := scanSQLiteSale(sqlConn(ctx, r.db).QueryRowContext(ctx, query, vehicleID))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = errSaleNotFoundByVehicle
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+func (r *sqliteSaleRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Sale, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetByPaymentID", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE payment_id = $1
+	          ORDER BY created_at ASC, id ASC
+	          LIMIT 1`
+
+	sale, err := scanSQLiteSale(sqlConn(ctx, r.db).QueryRowContext(ctx, query, paymentID))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = errSaleNotFoundByPayment
+	}
+	return sale, telemetry.Error(span, err)
+}
+
+// GetByBuyerCPF aceita o CPF com ou sem pontuação e procura pelas duas formas, já que o
+// valor é gravado como veio na compra.
+func (r *sqliteSaleRepository) GetByBuyerCPF(ctx context.Context, buyerCPF string, limit, offset int) ([]*domain.Sale, int, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetByBuyerCPF", "SELECT")
+	defer span.End()
+
+	digits := domain.NormalizeCPF(buyerCPF)
+	formatted := domain.FormatCPF(digits)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM sales WHERE buyer_cpf IN ($1, $2)`
+	if err := sqlConn(ctx, r.db).QueryRowContext(ctx, countQuery, digits, formatted).Scan(&total); err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE buyer_cpf IN ($1, $2)
+	          ORDER BY sale_date DESC NULLS LAST, created_at DESC
+	          LIMIT $3 OFFSET $4`
+
+	sales, err := r.query(ctx, query, digits, formatted, limit, offset)
+	if err != nil {
+		return nil, 0, telemetry.Error(span, err)
+	}
+	return sales, total, nil
+}
+
+func (r *sqliteSaleRepository) GetAvailableByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetAvailableByPrice", "SELECT")
+	defer span.End()
+
+	sales, err := r.listByStatus(ctx, domain.StatusAvailable)
+	return sales, telemetry.Error(span, err)
+}
+
+func (r *sqliteSaleRepository) GetSoldByPrice(ctx context.Context) ([]*domain.Sale, error) {
+	ctx, span := startSQLiteSpan(ctx, "GetSoldByPrice", "SELECT")
+	defer span.End()
+
+	sales, err := r.listByStatus(ctx, domain.StatusSold)
+	return sales, telemetry.Error(span, err)
+}
+
+func (r *sqliteSaleRepository) listByStatus(ctx context.Context, status domain.SaleStatus) ([]*domain.Sale, error) {
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE status = $1
+	          ORDER BY price ASC, id ASC`
+
+	sales, err := r.query(ctx, query, string(status))
+	if len(sales) == 0 {
+		// Mesmo resultado do Postgres, que devolve nil quando não há linhas.
+		return nil, err
+	}
+	return sales, err
+}
+
+func (r *sqliteSaleRepository) CountByStatus(ctx context.Context) (map[domain.SaleStatus]int, error) {
+	ctx, span := startSQLiteSpan(ctx, "CountByStatus", "SELECT")
+	defer span.End()
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, `SELECT status, COUNT(*) FROM sales GROUP BY status`)
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	counts := map[domain.SaleStatus]int{}
+	for rows.Next() {
+		var status domain.SaleStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, telemetry.Error(span, err)
+		}
+		counts[status] = count
+	}
+
+	return counts, telemetry.Error(span, rows.Err())
+}
+
+func (r *sqliteSaleRepository) ForEachSold(ctx context.Context, from, to time.Time, fn func(sale *domain.Sale) error) error {
+	ctx, span := startSQLiteSpan(ctx, "ForEachSold", "SELECT")
+	defer span.End()
+
+	query := `SELECT id, vehicle_id, brand, model, price, status, payment_id, buyer_cpf, sale_date, created_at, updated_at, version
+	          FROM sales
+	          WHERE status = $1 AND sale_date >= $2 AND sale_date < $3
+	          ORDER BY sale_date ASC`
+
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, string(domain.StatusSold), formatSQLiteTime(from), formatSQLiteTime(to))
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sale, err := scanSQLiteSale(rows)
+		if err != nil {
+			return telemetry.Error(span, err)
+		}
+		if err := fn(sale); err != nil {
+			return telemetry.Error(span, err)
+		}
+	}
+
+	return telemetry.Error(span, rows.Err())
+}
+
+func (r *sqliteSaleRepository) query(ctx context.Context, query string, args ...any) ([]*domain.Sale, error) {
+	rows, err := sqlConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := []*domain.Sale{}
+	for rows.Next() {
+		sale, err := scanSQLiteSale(rows)
+		if err != nil {
+			return nil, err
+		}
+		sales = append(sales, sale)
+	}
+
+	return sales, rows.Err()
+}
+
+func scanSQLiteSale(row rowScanner) (*domain.Sale, error) {
+	var s domain.Sale
+	var paymentID, buyerCPF, saleDate sql.NullString
+	var createdAt, updatedAt string
+
+	err := row.Scan(
+		&s.ID, &s.VehicleID, &s.Brand, &s.Model, &s.Price, &s.Status,
+		&paymentID, &buyerCPF, &saleDate,
+		&createdAt, &updatedAt, &s.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.CreatedAt, err = parseSQLiteTime(createdAt); err != nil {
+		return nil, err
+	}
+	if s.UpdatedAt, err = parseSQLiteTime(updatedAt); err != nil {
+		return nil, err
+	}
+	if s.SaleDate, err = parseSQLiteNullTime(saleDate); err != nil {
+		return nil, err
+	}
+	if paymentID.Valid {
+		s.PaymentID = paymentID.String
+	}
+	if buyerCPF.Valid {
+		s.BuyerCPF = &buyerCPF.String
+	}
+
+	return &s, nil
+}
+
+// formatSQLiteTime arredonda para microssegundos, como o Postgres faz ao gravar.
+func formatSQLiteTime(t time.Time) string {
+	return t.Round(time.Microsecond).UTC().Format(sqliteTimeLayout)
+}
+
+func formatSQLiteNullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatSQLiteTime(*t), Valid: true}
+}
+
+func parseSQLiteTime(value string) (time.Time, error) {
+	return time.Parse(sqliteTimeLayout, value)
+}
+
+func parseSQLiteNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	t, err := parseSQLiteTime(value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,101 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func Test_SQLiteSaleRepositoryContract(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, &repositorytest.SaleRepositorySuite{New: func(t *testing.T) repository.SaleRepository {
+		return repository.NewSQLiteSaleRepository(repositorytest.OpenSQLite(t))
+	}})
+}
+
+type SQLiteSaleRepositoryTestSuite struct {
+	suite.Suite
+	db   *sql.DB
+	repo repository.SaleRepository
+}
+
+func Test_SQLiteSaleRepositoryTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(SQLiteSaleRepositoryTestSuite))
+}
+
+func (suite *SQLiteSaleRepositoryTestSuite) SetupTest() {
+	suite.db = repositorytest.OpenSQLite(suite.T())
+	suite.repo = repository.NewSQLiteSaleRepository(suite.db)
+}
+
+func (suite *SQLiteSaleRepositoryTestSuite) Test_Timestamps() {
+	suite.T().Run("should store timestamps as fixed width UTC text", func(t *testing.T) {
+		created := time.Date(2026, 3, 1, 9, 30, 0, 123456789, time.FixedZone("BRT", -3*60*60))
+		sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Status: domain.StatusAvailable, CreatedAt: created, UpdatedAt: created}
+		suite.Require().NoError(suite.repo.Save(context.Background(), sale))
+
+		var stored string
+		err := suite.db.QueryRow(`SELECT created_at FROM sales WHERE id = 'sale-1'`).Scan(&stored)
+		suite.NoError(err)
+		suite.Equal("2026-03-01T12:30:00.123457Z", stored)
+
+		found, err := suite.repo.GetByID(context.Background(), "sale-1")
+		suite.NoError(err)
+		suite.True(created.Round(time.Microsecond).Equal(found.CreatedAt))
+		suite.Equal(time.UTC, found.CreatedAt.Location())
+	})
+
+	suite.T().Run("should reject a timestamp in another format", func(t *testing.T) {
+		_, err := suite.db.Exec(`INSERT INTO sales (id, vehicle_id, brand, model, price, status, created_at, updated_at)
+		          VALUES ('sale-2', 'vehicle-2', '', '', 0, 'AVAILABLE', '2026-03-01 12:00:00', '2026-03-01 12:00:00')`)
+
+		suite.ErrorContains(err, "CHECK constraint failed")
+	})
+}
+
+func (suite *SQLiteSaleRepositoryTestSuite) Test_StatusConstraint() {
+	suite.T().Run("should reject an unknown status", func(t *testing.T) {
+		sale := &domain.Sale{ID: "sale-1", VehicleID: "vehicle-1", Status: "RESERVED"}
+
+		err := suite.repo.Save(context.Background(), sale)
+
+		suite.ErrorContains(err, "CHECK constraint failed")
+	})
+}
+
+func (suite *SQLiteSaleRepositoryTestSuite) Test_ConcurrentWrites() {
+	suite.T().Run("should wait for the write lock instead of failing", func(t *testing.T) {
+		now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sales := []*domain.Sale{
+					{ID: fmt.Sprintf("sale-%d-a", i), VehicleID: "vehicle", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+					{ID: fmt.Sprintf("sale-%d-b", i), VehicleID: "vehicle", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now},
+				}
+				errs <- suite.repo.SaveBatch(context.Background(), sales)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			suite.NoError(err)
+		}
+		counts, err := suite.repo.CountByStatus(context.Background())
+		suite.NoError(err)
+		suite.Equal(40, counts[domain.StatusAvailable])
+	})
+}
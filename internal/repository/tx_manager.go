@@ -0,0 +1,14 @@
+package repository
+
+import "context"
+
+// TxManager executa uma função dentro de uma transação. A transação viaja no ctx recebido por
+// fn, e os repositórios do mesmo banco a usam automaticamente quando recebem esse ctx. Se fn
+// devolver erro ou entrar em pânico, tudo é desfeito; o pânico segue adiante depois do rollback.
+// Um WithinTx chamado dentro de outro participa da transação já aberta, e o que for agendado com
+// AfterCommit roda depois do commit da mais externa.
+//
+//go:generate mockgen -source=tx_manager.go -destination=./mocks/tx_manager_mock.go -package=mocks
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
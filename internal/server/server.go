@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// DrainDelay é o tempo entre a prontidão falhar e o servidor parar de aceitar conexões,
+	// para que o balanceador perceba a mudança antes.
+	DrainDelay time.Duration
+	// ShutdownTimeout limita a espera pelas requisições em andamento e pelos passos de OnStop.
+	ShutdownTimeout time.Duration
+}
+
+type stopStep struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type Server struct {
+	cfg     Config
+	http    *http.Server
+	onDrain []func()
+	stops   []stopStep
+}
+
+func New(cfg Config, handler http.Handler) *Server {
+	return &Server{
+		cfg: cfg,
+		http: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+		},
+	}
+}
+
+// OnDrain registra uma função chamada assim que o encerramento começa, antes do DrainDelay.
+func (s *Server) OnDrain(fn func()) {
+	s.onDrain = append(s.onDrain, fn)
+}
+
+// OnShutdown registra uma função chamada quando o servidor para de aceitar conexões, para
+// encerrar conexões longas como os streams SSE.
+func (s *Server) OnShutdown(fn func()) {
+	s.http.RegisterOnShutdown(fn)
+}
+
+// OnStop registra um passo executado depois que as requisições em andamento terminaram. Os passos
+// rodam na ordem de registro e um erro não impede os seguintes.
+func (s *Server) OnStop(name string, fn func(ctx context.Context) error) {
+	s.stops = append(s.stops, stopStep{name: name, fn: fn})
+}
+
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve atende em listener até ctx ser cancelado e então executa o encerramento: OnDrain,
+// DrainDelay, fim das requisições em andamento e os passos de OnStop.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.http.Serve(listener)
+	}()
+	slog.InfoContext(ctx, "server started", "addr", listener.Addr().String())
+
+	select {
+	case err := <-serveErr:
+		stopCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+		defer cancel()
+		return errors.Join(err, s.stop(stopCtx))
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown started", "drain_delay", s.cfg.DrainDelay.String())
+	for _, fn := range s.onDrain {
+		fn()
+	}
+	time.Sleep(s.cfg.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+		s.http.Close()
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+	}
+	slog.Info("http server stopped")
+
+	errs = append(errs, s.stop(shutdownCtx))
+	return errors.Join(errs...)
+}
+
+func (s *Server) stop(ctx context.Context) error {
+	var errs []error
+	for _, step := range s.stops {
+		if err := step.fn(ctx); err != nil {
+			slog.Warn("could not stop component", "component", step.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+			continue
+		}
+		slog.Info("component stopped", "component", step.name)
+	}
+	return errors.Join(errs...)
+}
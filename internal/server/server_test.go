@@ -0,0 +1,195 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/server"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerSuite struct {
+	suite.Suite
+}
+
+func Test_ServerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ServerSuite))
+}
+
+type sequence struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (s *sequence) add(step string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, step)
+}
+
+func (s *sequence) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.steps...)
+}
+
+func testConfig() server.Config {
+	return server.Config{
+		ReadHeaderTimeout: time.Second,
+		ReadTimeout:       time.Second,
+		WriteTimeout:      time.Second,
+		IdleTimeout:       time.Second,
+		DrainDelay:        50 * time.Millisecond,
+		ShutdownTimeout:   time.Second,
+	}
+}
+
+func (suite *ServerSuite) serve(srv *server.Server, ctx context.Context) (string, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, listener)
+	}()
+	return "http://" + listener.Addr().String(), done
+}
+
+func (suite *ServerSuite) Test_Shutdown() {
+	suite.T().Run("should drain, finish in-flight requests and then stop components in order", func(t *testing.T) {
+		seq := &sequence{}
+		started := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				close(started)
+				time.Sleep(150 * time.Millisecond)
+				seq.add("request finished")
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		srv := server.New(testConfig(), handler)
+		srv.OnDrain(func() { seq.add("drain") })
+		srv.OnShutdown(func() { seq.add("http shutdown") })
+		srv.OnStop("workers", func(context.Context) error { seq.add("workers"); return nil })
+		srv.OnStop("database", func(context.Context) error { seq.add("database"); return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		url, done := suite.serve(srv, ctx)
+
+		slow := make(chan int, 1)
+		go func() {
+			resp, err := http.Get(url + "/slow")
+			if err != nil {
+				slow <- 0
+				return
+			}
+			resp.Body.Close()
+			slow <- resp.StatusCode
+		}()
+		<-started
+		cancel()
+
+		// Durante o DrainDelay o servidor ainda aceita requisições novas.
+		time.Sleep(10 * time.Millisecond)
+		resp, err := http.Get(url + "/fast")
+		suite.Require().NoError(err)
+		resp.Body.Close()
+		suite.Equal(http.StatusOK, resp.StatusCode)
+
+		suite.NoError(<-done)
+		suite.Equal(http.StatusOK, <-slow)
+		suite.Equal([]string{"drain", "http shutdown", "request finished", "workers", "database"}, seq.get())
+
+		_, err = http.Get(url + "/fast")
+		suite.Error(err)
+	})
+
+	suite.T().Run("should run every stop step and join their errors", func(t *testing.T) {
+		seq := &sequence{}
+		srv := server.New(testConfig(), http.NotFoundHandler())
+		srv.OnStop("workers", func(context.Context) error { seq.add("workers"); return errors.New("still running") })
+		srv.OnStop("database", func(context.Context) error { seq.add("database"); return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, done := suite.serve(srv, ctx)
+		cancel()
+
+		err := <-done
+		suite.EqualError(err, "workers: still running")
+		suite.Equal([]string{"workers", "database"}, seq.get())
+	})
+
+	suite.T().Run("should give up on requests that outlive the shutdown timeout", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})
+
+		cfg := testConfig()
+		cfg.DrainDelay = 0
+		cfg.ShutdownTimeout = 50 * time.Millisecond
+		stopped := false
+		srv := server.New(cfg, handler)
+		srv.OnStop("database", func(context.Context) error { stopped = true; return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		url, done := suite.serve(srv, ctx)
+		go http.Get(url)
+		<-started
+		cancel()
+
+		err := <-done
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.True(stopped)
+	})
+
+	suite.T().Run("should stop components when the listener fails", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		suite.Require().NoError(err)
+		listener.Close()
+
+		stopped := false
+		srv := server.New(testConfig(), http.NotFoundHandler())
+		srv.OnStop("database", func(context.Context) error { stopped = true; return nil })
+
+		err = srv.Serve(context.Background(), listener)
+		suite.Error(err)
+		suite.True(stopped)
+	})
+}
+
+func (suite *ServerSuite) Test_Timeouts() {
+	suite.T().Run("should close connections that never finish sending headers", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.ReadHeaderTimeout = 50 * time.Millisecond
+		srv := server.New(cfg, http.NotFoundHandler())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		url, _ := suite.serve(srv, ctx)
+
+		conn, err := net.Dial("tcp", url[len("http://"):])
+		suite.Require().NoError(err)
+		defer conn.Close()
+		fmt.Fprint(conn, "GET / HTTP/1.1\r\nHost: localhost\r\n")
+
+		suite.NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+		start := time.Now()
+		_, err = io.ReadAll(bufio.NewReader(conn))
+		suite.NoError(err)
+		suite.Less(time.Since(start), 500*time.Millisecond)
+	})
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Workers acompanha as goroutines de fundo para que o encerramento espere por elas.
+type Workers struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWorkers() *Workers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Workers{ctx: ctx, cancel: cancel}
+}
+
+// Go executa run em uma goroutine com um contexto cancelado por Stop.
+func (w *Workers) Go(name string, run func(ctx context.Context)) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		run(w.ctx)
+		slog.Debug("worker finished", "worker", name)
+	}()
+}
+
+// Stop cancela os workers e espera que terminem, no máximo até ctx expirar.
+func (w *Workers) Stop(ctx context.Context) error {
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/server"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkersSuite struct {
+	suite.Suite
+}
+
+func Test_WorkersSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WorkersSuite))
+}
+
+func (suite *WorkersSuite) Test_Stop() {
+	suite.T().Run("should cancel the workers and wait for them", func(t *testing.T) {
+		workers := server.NewWorkers()
+		finished := make(chan struct{})
+		workers.Go("listener", func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			close(finished)
+		})
+
+		suite.NoError(workers.Stop(context.Background()))
+
+		select {
+		case <-finished:
+		default:
+			suite.Fail("Stop returned before the worker finished")
+		}
+	})
+
+	suite.T().Run("should give up when a worker ignores cancellation", func(t *testing.T) {
+		workers := server.NewWorkers()
+		release := make(chan struct{})
+		defer close(release)
+		workers.Go("stuck", func(ctx context.Context) {
+			<-release
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		suite.ErrorIs(workers.Stop(ctx), context.DeadlineExceeded)
+	})
+}
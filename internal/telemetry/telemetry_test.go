@@ -0,0 +1,120 @@
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry/telemetrytest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TelemetrySuite struct {
+	suite.Suite
+}
+
+func Test_TelemetrySuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(TelemetrySuite))
+}
+
+func (suite *TelemetrySuite) Test_Setup() {
+	suite.T().Run("should reject an unknown exporter", func(t *testing.T) {
+		_, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: "zipkin"})
+
+		suite.ErrorContains(err, `unknown trace exporter "zipkin"`)
+	})
+
+	suite.T().Run("should only configure propagation with the none exporter", func(t *testing.T) {
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: telemetry.ExporterNone})
+
+		suite.NoError(err)
+		suite.NoError(shutdown(context.Background()))
+	})
+
+	suite.T().Run("should write finished spans with the stdout exporter", func(t *testing.T) {
+		var buf bytes.Buffer
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{
+			Exporter:    telemetry.ExporterStdout,
+			ServiceName: "showcase-test",
+			SampleRatio: 1,
+			Stdout:      &buf,
+		})
+		suite.NoError(err)
+
+		_, span := telemetry.Start(context.Background(), "stdout-span")
+		span.End()
+		suite.NoError(shutdown(context.Background()))
+
+		suite.Contains(buf.String(), `"Name":"stdout-span"`)
+		suite.Contains(buf.String(), "showcase-test")
+	})
+}
+
+func (suite *TelemetrySuite) Test_Error() {
+	exporter := telemetrytest.Install(suite.T())
+
+	suite.T().Run("should mark the span as failed and return the same error", func(t *testing.T) {
+		expected := errors.New("db down")
+		_, span := telemetry.Start(context.Background(), "failing-span")
+
+		err := telemetry.Error(span, expected)
+		span.End()
+
+		suite.Same(expected, err)
+		spans := telemetrytest.Named(exporter, "failing-span")
+		suite.Require().Len(spans, 1)
+		suite.Equal(codes.Error, spans[0].Status.Code)
+		suite.Equal("db down", spans[0].Status.Description)
+	})
+
+	suite.T().Run("should keep the span untouched when there is no error", func(t *testing.T) {
+		_, span := telemetry.Start(context.Background(), "ok-span")
+
+		err := telemetry.Error(span, nil)
+		span.End()
+
+		suite.NoError(err)
+		spans := telemetrytest.Named(exporter, "ok-span")
+		suite.Require().Len(spans, 1)
+		suite.Equal(codes.Unset, spans[0].Status.Code)
+	})
+}
+
+func (suite *TelemetrySuite) Test_Transport() {
+	exporter := telemetrytest.Install(suite.T())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	suite.T().Run("should send traceparent and record a client span", func(t *testing.T) {
+		ctx, parent := telemetry.Start(context.Background(), "outgoing-parent")
+		client := &http.Client{Transport: telemetry.NewTransport(nil)}
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/jwks.json", nil)
+		resp, err := client.Do(req)
+		suite.Require().NoError(err)
+		resp.Body.Close()
+		parent.End()
+
+		spans := telemetrytest.Named(exporter, "HTTP GET")
+		suite.Require().Len(spans, 1)
+		span := spans[0]
+		suite.Equal(trace.SpanKindClient, span.SpanKind)
+		suite.Equal(parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+		suite.Equal(parent.SpanContext().SpanID(), span.Parent.SpanID())
+		suite.Equal(codes.Error, span.Status.Code)
+		suite.Contains(traceparent, span.SpanContext.TraceID().String())
+		suite.Contains(traceparent, span.SpanContext.SpanID().String())
+	})
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+)
+
+//go:generate mockgen -source=analytics_usecase.go -destination=./mocks/analytics_usecase_mock.go -package=mocks
+type AnalyticsUseCaseInterface interface {
+	SalesAnalytics(ctx context.Context, input dto.InputSalesAnalyticsDTO) (*dto.OutputSalesAnalyticsDTO, error)
+}
+
+type analyticsUseCase struct {
+	repo repository.AnalyticsRepository
+}
+
+func NewAnalyticsUseCase(repo repository.AnalyticsRepository) AnalyticsUseCaseInterface {
+	return &analyticsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *analyticsUseCase) SalesAnalytics(ctx context.Context, input dto.InputSalesAnalyticsDTO) (*dto.OutputSalesAnalyticsDTO, error) {
+	aggregates, err := uc.repo.AggregateSales(ctx, repository.SalesAnalyticsFilter{
+		From:    input.From,
+		To:      input.To,
+		Period:  input.Period,
+		GroupBy: input.GroupBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total := &domain.SalesAggregate{}
+	var totalTimeToSale time.Duration
+
+	output := &dto.OutputSalesAnalyticsDTO{
+		From:    input.From,
+		To:      input.To,
+		Period:  input.Period,
+		GroupBy: input.GroupBy,
+		Groups:  make([]*dto.OutputSalesAggregateDTO, 0, len(aggregates)),
+	}
+
+	for _, aggregate := range aggregates {
+		output.Groups = append(output.Groups, toSalesAggregateDTO(aggregate))
+
+		total.UnitsSold += aggregate.UnitsSold
+		total.Revenue += aggregate.Revenue
+		total.Reservations += aggregate.Reservations
+		totalTimeToSale += aggregate.AvgTimeToSale * time.Duration(aggregate.UnitsSold)
+	}
+
+	if total.UnitsSold > 0 {
+		total.AvgTimeToSale = totalTimeToSale / time.Duration(total.UnitsSold)
+	}
+	output.Totals = toSalesAggregateDTO(total)
+
+	return output, nil
+}
+
+func toSalesAggregateDTO(aggregate *domain.SalesAggregate) *dto.OutputSalesAggregateDTO {
+	output := &dto.OutputSalesAggregateDTO{
+		PeriodStart:        aggregate.PeriodStart,
+		Brand:              aggregate.Brand,
+		Model:              aggregate.Model,
+		UnitsSold:          aggregate.UnitsSold,
+		Revenue:            aggregate.Revenue,
+		AverageHoursToSale: aggregate.AvgTimeToSale.Hours(),
+		Reservations:       aggregate.Reservations,
+	}
+
+	if aggregate.UnitsSold > 0 {
+		output.AverageTicket = aggregate.Revenue / float64(aggregate.UnitsSold)
+	}
+	if aggregate.Reservations > 0 {
+		output.ConversionRate = float64(aggregate.UnitsSold) / float64(aggregate.Reservations)
+	}
+
+	return output
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AnalyticsUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	repository *mocks.MockAnalyticsRepository
+}
+
+func (suite *AnalyticsUseCaseSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.ctx = context.Background()
+	suite.repository = mocks.NewMockAnalyticsRepository(ctrl)
+}
+
+func Test_AnalyticsUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(AnalyticsUseCaseSuite))
+}
+
+func (suite *AnalyticsUseCaseSuite) Test_SalesAnalytics() {
+	input := dto.InputSalesAnalyticsDTO{
+		From:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		GroupBy: repository.AnalyticsGroupByBrand,
+	}
+	filter := repository.SalesAnalyticsFilter{From: input.From, To: input.To, GroupBy: input.GroupBy}
+
+	suite.T().Run("should compute derived metrics per group and totals", func(t *testing.T) {
+		usecase := usecase.NewAnalyticsUseCase(suite.repository)
+		suite.repository.EXPECT().AggregateSales(suite.ctx, filter).Return([]*domain.SalesAggregate{
+			{Brand: "Honda", UnitsSold: 1, Revenue: 60000, AvgTimeToSale: 10 * time.Hour, Reservations: 2},
+			{Brand: "Toyota", UnitsSold: 3, Revenue: 150000, AvgTimeToSale: 2 * time.Hour, Reservations: 3},
+			{Brand: "Ford", UnitsSold: 0, Revenue: 0, Reservations: 1},
+		}, nil)
+
+		output, err := usecase.SalesAnalytics(suite.ctx, input)
+		suite.NoError(err)
+		suite.Equal(input.From, output.From)
+		suite.Equal("brand", output.GroupBy)
+		suite.Len(output.Groups, 3)
+
+		suite.Equal("Honda", output.Groups[0].Brand)
+		suite.Equal(60000.0, output.Groups[0].AverageTicket)
+		suite.Equal(10.0, output.Groups[0].AverageHoursToSale)
+		suite.Equal(0.5, output.Groups[0].ConversionRate)
+
+		suite.Equal(0.0, output.Groups[2].AverageTicket)
+		suite.Equal(0.0, output.Groups[2].ConversionRate)
+
+		suite.Equal(4, output.Totals.UnitsSold)
+		suite.Equal(210000.0, output.Totals.Revenue)
+		suite.Equal(52500.0, output.Totals.AverageTicket)
+		suite.Equal(4.0, output.Totals.AverageHoursToSale)
+		suite.Equal(6, output.Totals.Reservations)
+		suite.InDelta(0.666, output.Totals.ConversionRate, 0.001)
+	})
+
+	suite.T().Run("should return zeroed totals when there are no sales", func(t *testing.T) {
+		usecase := usecase.NewAnalyticsUseCase(suite.repository)
+		suite.repository.EXPECT().AggregateSales(suite.ctx, filter).Return([]*domain.SalesAggregate{}, nil)
+
+		output, err := usecase.SalesAnalytics(suite.ctx, input)
+		suite.NoError(err)
+		suite.Empty(output.Groups)
+		suite.NotNil(output.Groups)
+		suite.Equal(0, output.Totals.UnitsSold)
+		suite.Equal(0.0, output.Totals.AverageHoursToSale)
+	})
+
+	suite.T().Run("should return error if repo.AggregateSales fails", func(t *testing.T) {
+		usecase := usecase.NewAnalyticsUseCase(suite.repository)
+		suite.repository.EXPECT().AggregateSales(suite.ctx, filter).Return(nil, errors.New("db error"))
+
+		output, err := usecase.SalesAnalytics(suite.ctx, input)
+		suite.Error(err)
+		suite.Nil(output)
+	})
+}
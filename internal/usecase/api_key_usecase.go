@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+//go:generate mockgen -source=api_key_usecase.go -destination=./mocks/api_key_usecase_mock.go -package=mocks
+type APIKeyUseCaseInterface interface {
+	Create(ctx context.Context, input dto.InputCreateAPIKeyDTO) (*dto.OutputCreateAPIKeyDTO, error)
+	List(ctx context.Context) ([]*dto.OutputAPIKeyDTO, error)
+	Revoke(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, key string) (*dto.OutputAPIKeyDTO, error)
+}
+
+type apiKeyUseCase struct {
+	repo repository.APIKeyRepository
+}
+
+func NewAPIKeyUseCase(repo repository.APIKeyRepository) APIKeyUseCaseInterface {
+	return &apiKeyUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *apiKeyUseCase) Create(ctx context.Context, input dto.InputCreateAPIKeyDTO) (*dto.OutputCreateAPIKeyDTO, error) {
+	key, plain, err := domain.NewAPIKey(input.Name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.OutputCreateAPIKeyDTO{
+		OutputAPIKeyDTO: *toAPIKeyDTO(key),
+		Key:             plain,
+	}, nil
+}
+
+func (uc *apiKeyUseCase) List(ctx context.Context) ([]*dto.OutputAPIKeyDTO, error) {
+	keys, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]*dto.OutputAPIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, toAPIKeyDTO(key))
+	}
+
+	return output, nil
+}
+
+func (uc *apiKeyUseCase) Revoke(ctx context.Context, id string) error {
+	return uc.repo.Revoke(ctx, id, time.Now())
+}
+
+// Authenticate devolve ErrInvalidAPIKey tanto para chaves desconhecidas quanto para chaves
+// expiradas ou revogadas, sem revelar qual foi o caso.
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, key string) (*dto.OutputAPIKeyDTO, error) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	stored, err := uc.repo.GetByHash(ctx, domain.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if !stored.IsActive(time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	return toAPIKeyDTO(stored), nil
+}
+
+func toAPIKeyDTO(key *domain.APIKey) *dto.OutputAPIKeyDTO {
+	return &dto.OutputAPIKeyDTO{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+		RevokedAt: key.RevokedAt,
+		CreatedAt: key.CreatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type APIKeyUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	repository *mocks.MockAPIKeyRepository
+	useCase    usecase.APIKeyUseCaseInterface
+}
+
+func (suite *APIKeyUseCaseSuite) SetupTest() {
+	ctrl := gomock.NewController(suite.T())
+	suite.ctx = context.Background()
+	suite.repository = mocks.NewMockAPIKeyRepository(ctrl)
+	suite.useCase = usecase.NewAPIKeyUseCase(suite.repository)
+}
+
+func Test_APIKeyUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(APIKeyUseCaseSuite))
+}
+
+func (suite *APIKeyUseCaseSuite) Test_Create() {
+	input := dto.InputCreateAPIKeyDTO{Name: "catalog", Scopes: []string{domain.APIKeyScopeCatalogService}}
+
+	suite.T().Run("should store only the hash and return the plain key once", func(t *testing.T) {
+		var saved *domain.APIKey
+		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.APIKey) error {
+			saved = key
+			return nil
+		})
+
+		output, err := suite.useCase.Create(suite.ctx, input)
+		suite.NoError(err)
+		suite.Equal("catalog", output.Name)
+		suite.Equal(saved.ID, output.ID)
+		suite.Equal(domain.HashAPIKey(output.Key), saved.KeyHash)
+	})
+
+	suite.T().Run("should return error for invalid input", func(t *testing.T) {
+		output, err := suite.useCase.Create(suite.ctx, dto.InputCreateAPIKeyDTO{Name: "catalog", Scopes: []string{"admin"}})
+		suite.Error(err)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error if repo.Save fails", func(t *testing.T) {
+		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).Return(errors.New("db error"))
+
+		output, err := suite.useCase.Create(suite.ctx, input)
+		suite.EqualError(err, "db error")
+		suite.Nil(output)
+	})
+}
+
+func (suite *APIKeyUseCaseSuite) Test_List() {
+	suite.T().Run("should list keys without their hashes", func(t *testing.T) {
+		suite.repository.EXPECT().List(suite.ctx).Return([]*domain.APIKey{
+			{ID: "key-1", Name: "catalog", Prefix: "sk_abcdefgh", KeyHash: "hash", Scopes: []string{"catalog-service"}},
+		}, nil)
+
+		output, err := suite.useCase.List(suite.ctx)
+		suite.NoError(err)
+		suite.Len(output, 1)
+		suite.Equal("key-1", output[0].ID)
+		suite.Equal("sk_abcdefgh", output[0].Prefix)
+		suite.Equal([]string{"catalog-service"}, output[0].Scopes)
+	})
+
+	suite.T().Run("should return error if repo.List fails", func(t *testing.T) {
+		suite.repository.EXPECT().List(suite.ctx).Return(nil, errors.New("db error"))
+
+		output, err := suite.useCase.List(suite.ctx)
+		suite.Error(err)
+		suite.Nil(output)
+	})
+}
+
+func (suite *APIKeyUseCaseSuite) Test_Revoke() {
+	suite.T().Run("should revoke the key", func(t *testing.T) {
+		suite.repository.EXPECT().Revoke(suite.ctx, "key-1", gomock.Any()).Return(nil)
+
+		suite.NoError(suite.useCase.Revoke(suite.ctx, "key-1"))
+	})
+
+	suite.T().Run("should return error if repo.Revoke fails", func(t *testing.T) {
+		suite.repository.EXPECT().Revoke(suite.ctx, "key-1", gomock.Any()).Return(repository.ErrAPIKeyNotFound)
+
+		suite.ErrorIs(suite.useCase.Revoke(suite.ctx, "key-1"), repository.ErrAPIKeyNotFound)
+	})
+}
+
+func (suite *APIKeyUseCaseSuite) Test_Authenticate() {
+	plain := "sk_secret"
+	hash := domain.HashAPIKey(plain)
+	past := time.Now().Add(-time.Hour)
+
+	suite.T().Run("should return the active key", func(t *testing.T) {
+		suite.repository.EXPECT().GetByHash(suite.ctx, hash).Return(&domain.APIKey{ID: "key-1", Name: "catalog", Scopes: []string{"catalog-service"}}, nil)
+
+		output, err := suite.useCase.Authenticate(suite.ctx, plain)
+		suite.NoError(err)
+		suite.Equal("catalog", output.Name)
+	})
+
+	suite.T().Run("should reject keys without the prefix without querying", func(t *testing.T) {
+		output, err := suite.useCase.Authenticate(suite.ctx, "secret")
+		suite.ErrorIs(err, usecase.ErrInvalidAPIKey)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should reject unknown keys", func(t *testing.T) {
+		suite.repository.EXPECT().GetByHash(suite.ctx, hash).Return(nil, repository.ErrAPIKeyNotFound)
+
+		_, err := suite.useCase.Authenticate(suite.ctx, plain)
+		suite.ErrorIs(err, usecase.ErrInvalidAPIKey)
+	})
+
+	suite.T().Run("should reject revoked keys", func(t *testing.T) {
+		suite.repository.EXPECT().GetByHash(suite.ctx, hash).Return(&domain.APIKey{ID: "key-1", RevokedAt: &past}, nil)
+
+		_, err := suite.useCase.Authenticate(suite.ctx, plain)
+		suite.ErrorIs(err, usecase.ErrInvalidAPIKey)
+	})
+
+	suite.T().Run("should reject expired keys", func(t *testing.T) {
+		suite.repository.EXPECT().GetByHash(suite.ctx, hash).Return(&domain.APIKey{ID: "key-1", ExpiresAt: &past}, nil)
+
+		_, err := suite.useCase.Authenticate(suite.ctx, plain)
+		suite.ErrorIs(err, usecase.ErrInvalidAPIKey)
+	})
+
+	suite.T().Run("should return error if repo.GetByHash fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByHash(suite.ctx, hash).Return(nil, errors.New("db error"))
+
+		_, err := suite.useCase.Authenticate(suite.ctx, plain)
+		suite.EqualError(err, "db error")
+	})
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: analytics_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=analytics_usecase.go -destination=./mocks/analytics_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsUseCaseInterface is a mock of AnalyticsUseCaseInterface interface.
+type MockAnalyticsUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsUseCaseInterfaceMockRecorder is the mock recorder for MockAnalyticsUseCaseInterface.
+type MockAnalyticsUseCaseInterfaceMockRecorder struct {
+	mock *MockAnalyticsUseCaseInterface
+}
+
+// NewMockAnalyticsUseCaseInterface creates a new mock instance.
+func NewMockAnalyticsUseCaseInterface(ctrl *gomock.Controller) *MockAnalyticsUseCaseInterface {
+	mock := &MockAnalyticsUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsUseCaseInterface) EXPECT() *MockAnalyticsUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SalesAnalytics mocks base method.
+func (m *MockAnalyticsUseCaseInterface) SalesAnalytics(ctx context.Context, input dto.InputSalesAnalyticsDTO) (*dto.OutputSalesAnalyticsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesAnalytics", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputSalesAnalyticsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesAnalytics indicates an expected call of SalesAnalytics.
+func (mr *MockAnalyticsUseCaseInterfaceMockRecorder) SalesAnalytics(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesAnalytics", reflect.TypeOf((*MockAnalyticsUseCaseInterface)(nil).SalesAnalytics), ctx, input)
+}
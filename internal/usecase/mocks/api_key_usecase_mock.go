@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_key_usecase.go -destination=./mocks/api_key_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyUseCaseInterface is a mock of APIKeyUseCaseInterface interface.
+type MockAPIKeyUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyUseCaseInterfaceMockRecorder is the mock recorder for MockAPIKeyUseCaseInterface.
+type MockAPIKeyUseCaseInterfaceMockRecorder struct {
+	mock *MockAPIKeyUseCaseInterface
+}
+
+// NewMockAPIKeyUseCaseInterface creates a new mock instance.
+func NewMockAPIKeyUseCaseInterface(ctrl *gomock.Controller) *MockAPIKeyUseCaseInterface {
+	mock := &MockAPIKeyUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyUseCaseInterface) EXPECT() *MockAPIKeyUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeyUseCaseInterface) Authenticate(ctx context.Context, key string) (*dto.OutputAPIKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(*dto.OutputAPIKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyUseCaseInterfaceMockRecorder) Authenticate(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeyUseCaseInterface)(nil).Authenticate), ctx, key)
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyUseCaseInterface) Create(ctx context.Context, input dto.InputCreateAPIKeyDTO) (*dto.OutputCreateAPIKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputCreateAPIKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyUseCaseInterfaceMockRecorder) Create(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyUseCaseInterface)(nil).Create), ctx, input)
+}
+
+// List mocks base method.
+func (m *MockAPIKeyUseCaseInterface) List(ctx context.Context) ([]*dto.OutputAPIKeyDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*dto.OutputAPIKeyDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIKeyUseCaseInterfaceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIKeyUseCaseInterface)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyUseCaseInterface) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyUseCaseInterfaceMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyUseCaseInterface)(nil).Revoke), ctx, id)
+}
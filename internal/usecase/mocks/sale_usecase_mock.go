@@ -14,6 +14,8 @@ import (
 	reflect "reflect"
 
 	dto "github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	importer "github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	report "github.com/NicolasNSC/showcase-service-fiap/internal/report"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,21 @@ func (m *MockSaleUseCaseInterface) EXPECT() *MockSaleUseCaseInterfaceMockRecorde
 	return m.recorder
 }
 
+// BulkCreateListings mocks base method.
+func (m *MockSaleUseCaseInterface) BulkCreateListings(ctx context.Context, reader importer.ListingReader, input dto.InputBulkImportDTO) (*dto.OutputBulkImportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateListings", ctx, reader, input)
+	ret0, _ := ret[0].(*dto.OutputBulkImportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateListings indicates an expected call of BulkCreateListings.
+func (mr *MockSaleUseCaseInterfaceMockRecorder) BulkCreateListings(ctx, reader, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateListings", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).BulkCreateListings), ctx, reader, input)
+}
+
 // CreateListing mocks base method.
 func (m *MockSaleUseCaseInterface) CreateListing(ctx context.Context, input *dto.InputCreateListingDTO) (*dto.OutputCreateListingDTO, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +73,35 @@ func (mr *MockSaleUseCaseInterfaceMockRecorder) CreateListing(ctx, input any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).CreateListing), ctx, input)
 }
 
+// ExportSoldReport mocks base method.
+func (m *MockSaleUseCaseInterface) ExportSoldReport(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSoldReport", ctx, input, writer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSoldReport indicates an expected call of ExportSoldReport.
+func (mr *MockSaleUseCaseInterfaceMockRecorder) ExportSoldReport(ctx, input, writer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSoldReport", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).ExportSoldReport), ctx, input, writer)
+}
+
+// GetListing mocks base method.
+func (m *MockSaleUseCaseInterface) GetListing(ctx context.Context, vehicleID string) (*dto.OutputListingDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListing", ctx, vehicleID)
+	ret0, _ := ret[0].(*dto.OutputListingDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListing indicates an expected call of GetListing.
+func (mr *MockSaleUseCaseInterfaceMockRecorder) GetListing(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListing", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).GetListing), ctx, vehicleID)
+}
+
 // HandlePaymentWebhook mocks base method.
 func (m *MockSaleUseCaseInterface) HandlePaymentWebhook(ctx context.Context, input *dto.InputWebhookDTO) error {
 	m.ctrl.T.Helper()
@@ -85,6 +131,21 @@ func (mr *MockSaleUseCaseInterfaceMockRecorder) ListAvailable(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailable", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).ListAvailable), ctx)
 }
 
+// ListBuyerSales mocks base method.
+func (m *MockSaleUseCaseInterface) ListBuyerSales(ctx context.Context, input dto.InputBuyerSalesDTO) (*dto.OutputBuyerSalesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBuyerSales", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputBuyerSalesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBuyerSales indicates an expected call of ListBuyerSales.
+func (mr *MockSaleUseCaseInterfaceMockRecorder) ListBuyerSales(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuyerSales", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).ListBuyerSales), ctx, input)
+}
+
 // ListSold mocks base method.
 func (m *MockSaleUseCaseInterface) ListSold(ctx context.Context) ([]*dto.OutputSaleItemDTO, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +161,21 @@ func (mr *MockSaleUseCaseInterfaceMockRecorder) ListSold(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSold", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).ListSold), ctx)
 }
 
+// PatchListing mocks base method.
+func (m *MockSaleUseCaseInterface) PatchListing(ctx context.Context, vehicleID string, input dto.InputPatchListingDTO) (*dto.OutputListingDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchListing", ctx, vehicleID, input)
+	ret0, _ := ret[0].(*dto.OutputListingDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchListing indicates an expected call of PatchListing.
+func (mr *MockSaleUseCaseInterfaceMockRecorder) PatchListing(ctx, vehicleID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchListing", reflect.TypeOf((*MockSaleUseCaseInterface)(nil).PatchListing), ctx, vehicleID, input)
+}
+
 // Purchase mocks base method.
 func (m *MockSaleUseCaseInterface) Purchase(ctx context.Context, saleID string, input dto.InputPurchaseDTO) (*dto.OutputPurchaseDTO, error) {
 	m.ctrl.T.Helper()
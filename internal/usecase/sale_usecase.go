@@ -2,46 +2,117 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/mergepatch"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate mockgen -source=sale_usecase.go -destination=./mocks/sale_usecase_mock.go -package=mocks
 type SaleUseCaseInterface interface {
 	CreateListing(ctx context.Context, input *dto.InputCreateListingDTO) (*dto.OutputCreateListingDTO, error)
+	BulkCreateListings(ctx context.Context, reader importer.ListingReader, input dto.InputBulkImportDTO) (*dto.OutputBulkImportDTO, error)
 	UpdateListing(ctx context.Context, vehicleID string, input *dto.InputUpdateListingDTO) error
+	GetListing(ctx context.Context, vehicleID string) (*dto.OutputListingDTO, error)
+	PatchListing(ctx context.Context, vehicleID string, input dto.InputPatchListingDTO) (*dto.OutputListingDTO, error)
 	Purchase(ctx context.Context, saleID string, input dto.InputPurchaseDTO) (*dto.OutputPurchaseDTO, error)
 	HandlePaymentWebhook(ctx context.Context, input *dto.InputWebhookDTO) error
 	ListAvailable(ctx context.Context) ([]*dto.OutputSaleItemDTO, error)
 	ListSold(ctx context.Context) ([]*dto.OutputSaleItemDTO, error)
+	ListBuyerSales(ctx context.Context, input dto.InputBuyerSalesDTO) (*dto.OutputBuyerSalesDTO, error)
+	ExportSoldReport(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error
 }
 
+const (
+	bulkImportBatchSize   = 500
+	defaultBuyerSalesPage = 20
+	maxBuyerSalesPageSize = 100
+	maxConflictRetries    = 3
+)
+
+var (
+	ErrInvalidBuyerCPF = errors.New("buyer_cpf must have 11 digits")
+	ErrInvalidListing  = errors.New("invalid listing")
+	ErrListingModified = errors.New("listing was modified since it was read")
+
+	// Estados que impedem a compra ou o webhook; a requisição é válida, mas a venda não está no
+	// status esperado.
+	ErrSaleNotAvailable      = errors.New("sale is not available for purchase")
+	ErrSaleNotPendingPayment = errors.New("sale is not in pending payment status")
+	ErrInvalidPaymentStatus  = errors.New("invalid payment status received from webhook")
+)
+
 type saleUseCase struct {
-	repo repository.SaleRepository
+	repo      repository.SaleRepository
+	tx        repository.TxManager
+	publisher events.Publisher
+	metrics   metrics.Recorder
 }
 
-func NewSaleUseCase(repo repository.SaleRepository) SaleUseCaseInterface {
+// NewSaleUseCase recebe o TxManager do mesmo banco de repo; as leituras e escritas que precisam
+// andar juntas rodam dentro de tx.WithinTx.
+func NewSaleUseCase(repo repository.SaleRepository, tx repository.TxManager, publisher events.Publisher, recorder metrics.Recorder) SaleUseCaseInterface {
 	return &saleUseCase{
-		repo: repo,
+		repo:      repo,
+		tx:        tx,
+		publisher: publisher,
+		metrics:   recorder,
 	}
 }
 
+// publish notifica as mudanças depois do commit da transação do ctx, se houver uma, para que
+// ninguém veja eventos de escritas desfeitas. Os eventos das vendas saem juntos, e uma falha aqui
+// não desfaz a operação.
+func (uc *saleUseCase) publish(ctx context.Context, eventType events.Type, sales ...*domain.Sale) {
+	if len(sales) == 0 {
+		return
+	}
+	batch := make([]events.Event, len(sales))
+	for i, sale := range sales {
+		batch[i] = events.NewSaleEvent(eventType, sale)
+	}
+
+	repository.AfterCommit(ctx, func(ctx context.Context) {
+		if err := uc.publisher.Publish(ctx, batch...); err != nil {
+			slog.WarnContext(ctx, "could not publish events", "event_type", eventType, "count", len(batch), "error", err)
+		}
+	})
+}
+
 func (uc *saleUseCase) CreateListing(ctx context.Context, input *dto.InputCreateListingDTO) (*dto.OutputCreateListingDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.CreateListing")
+	defer span.End()
+
 	sale, err := domain.NewSale(input.VehicleID, input.Brand, input.Model, input.Price)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	err = uc.repo.Save(ctx, sale)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
+	slog.InfoContext(ctx, "listing created", "sale_id", sale.ID, "vehicle_id", sale.VehicleID)
+	uc.metrics.ListingsCreated(1)
+	uc.publish(ctx, events.TypeListingCreated, sale)
 
 	output := &dto.OutputCreateListingDTO{
 		SaleID:    sale.ID,
@@ -52,41 +123,308 @@ func (uc *saleUseCase) CreateListing(ctx context.Context, input *dto.InputCreate
 	return output, nil
 }
 
+func (uc *saleUseCase) BulkCreateListings(ctx context.Context, reader importer.ListingReader, input dto.InputBulkImportDTO) (*dto.OutputBulkImportDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.BulkCreateListings")
+	defer span.End()
+
+	output := &dto.OutputBulkImportDTO{
+		DryRun: input.DryRun,
+		Atomic: input.Atomic,
+		Rows:   []*dto.OutputBulkImportRowDTO{},
+	}
+
+	var pendingSales []*domain.Sale
+	var pendingRows []*dto.OutputBulkImportRowDTO
+
+	flush := func() {
+		if len(pendingSales) == 0 {
+			return
+		}
+
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.repo.SaveBatch(ctx, pendingSales); err != nil {
+				return err
+			}
+			uc.publish(ctx, events.TypeListingCreated, pendingSales...)
+			return nil
+		})
+		switch {
+		case err == nil:
+			for _, row := range pendingRows {
+				row.Status = dto.BulkRowCreated
+			}
+			uc.metrics.ListingsCreated(len(pendingSales))
+		case input.Atomic:
+			slog.ErrorContext(ctx, "could not save listing batch", "rows", len(pendingSales), "error", err)
+			for _, row := range pendingRows {
+				row.Status = dto.BulkRowFailed
+				row.Error = err.Error()
+			}
+		default:
+			// O lote é desfeito por inteiro; gravar linha a linha mostra quais linhas falharam
+			// sem perder as boas.
+			slog.WarnContext(ctx, "listing batch failed, saving rows one by one", "rows", len(pendingSales), "error", err)
+			var created []*domain.Sale
+			for i, row := range pendingRows {
+				if err := uc.repo.Save(ctx, pendingSales[i]); err != nil {
+					row.Status = dto.BulkRowFailed
+					row.Error = err.Error()
+					continue
+				}
+				row.Status = dto.BulkRowCreated
+				created = append(created, pendingSales[i])
+			}
+			if len(created) > 0 {
+				uc.metrics.ListingsCreated(len(created))
+				uc.publish(ctx, events.TypeListingCreated, created...)
+			}
+		}
+
+		pendingSales = nil
+		pendingRows = nil
+	}
+
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, telemetry.Error(span, err)
+		}
+
+		result := &dto.OutputBulkImportRowDTO{Line: row.Line, Status: dto.BulkRowValid}
+		output.Rows = append(output.Rows, result)
+
+		if row.Err != nil {
+			result.Status = dto.BulkRowFailed
+			result.Error = row.Err.Error()
+			continue
+		}
+
+		result.VehicleID = row.Input.VehicleID
+		sale, err := domain.NewSale(row.Input.VehicleID, row.Input.Brand, row.Input.Model, row.Input.Price)
+		if err != nil {
+			result.Status = dto.BulkRowFailed
+			result.Error = err.Error()
+			continue
+		}
+		result.SaleID = sale.ID
+
+		if input.DryRun {
+			continue
+		}
+
+		pendingSales = append(pendingSales, sale)
+		pendingRows = append(pendingRows, result)
+		if !input.Atomic && len(pendingSales) >= bulkImportBatchSize {
+			flush()
+		}
+	}
+
+	invalidRows := countBulkRows(output.Rows, dto.BulkRowFailed)
+	if input.Atomic && invalidRows > 0 {
+		for _, row := range pendingRows {
+			row.Status = dto.BulkRowNotApplied
+			row.Error = fmt.Sprintf("import aborted: %d row(s) failed validation", invalidRows)
+		}
+		pendingSales = nil
+		pendingRows = nil
+	}
+	flush()
+
+	output.Total = len(output.Rows)
+	output.Created = countBulkRows(output.Rows, dto.BulkRowCreated)
+	output.Failed = countBulkRows(output.Rows, dto.BulkRowFailed)
+	slog.InfoContext(ctx, "bulk import finished",
+		"dry_run", input.DryRun, "atomic", input.Atomic,
+		"total", output.Total, "created", output.Created, "failed", output.Failed,
+	)
+
+	return output, nil
+}
+
+func countBulkRows(rows []*dto.OutputBulkImportRowDTO, status string) int {
+	count := 0
+	for _, row := range rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
 func (uc *saleUseCase) UpdateListing(ctx context.Context, vehicleID string, input *dto.InputUpdateListingDTO) error {
-	sale, err := uc.repo.GetByVehicleID(ctx, vehicleID)
+	ctx, span := telemetry.Start(ctx, "saleUseCase.UpdateListing", trace.WithAttributes(attribute.String("vehicle.id", vehicleID)))
+	defer span.End()
+
+	sale, err := uc.updateWithRetry(ctx, "UpdateListing", func(ctx context.Context) (*domain.Sale, error) {
+		return uc.loadListing(ctx, vehicleID)
+	}, func(sale *domain.Sale) error {
+		if err := sale.UpdateListing(input.Brand, input.Model, input.Price, time.Now()); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidListing, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return telemetry.Error(span, err)
 	}
+	uc.publish(ctx, events.TypeListingUpdated, sale)
+
+	return nil
+}
 
-	sale.Brand = input.Brand
-	sale.Model = input.Model
-	sale.Price = input.Price
-	sale.UpdatedAt = time.Now()
+func (uc *saleUseCase) GetListing(ctx context.Context, vehicleID string) (*dto.OutputListingDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.GetListing", trace.WithAttributes(attribute.String("vehicle.id", vehicleID)))
+	defer span.End()
 
-	return uc.repo.Update(ctx, sale)
+	sale, err := uc.loadListing(ctx, vehicleID)
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+
+	return toListingOutput(sale), nil
 }
 
-func (uc *saleUseCase) Purchase(ctx context.Context, saleID string, input dto.InputPurchaseDTO) (*dto.OutputPurchaseDTO, error) {
-	sale, err := uc.repo.GetByID(ctx, saleID)
+// PatchListing aplica o merge patch sobre os dados atuais do anúncio e valida o resultado com as
+// regras do DTO e do domínio; campos ausentes no patch ficam como estão. Com IfMatch, o patch só
+// é aplicado se o anúncio ainda estiver na versão que o cliente leu.
+func (uc *saleUseCase) PatchListing(ctx context.Context, vehicleID string, input dto.InputPatchListingDTO) (*dto.OutputListingDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.PatchListing", trace.WithAttributes(attribute.String("vehicle.id", vehicleID)))
+	defer span.End()
+
+	// Numa nova tentativa o If-Match é conferido com a versão relida, então um patch condicionado
+	// nunca é aplicado sobre uma versão diferente da que o cliente viu.
+	sale, err := uc.updateWithRetry(ctx, "PatchListing", func(ctx context.Context) (*domain.Sale, error) {
+		return uc.loadListing(ctx, vehicleID)
+	}, func(sale *domain.Sale) error {
+		if input.IfMatch != "" && !matchesETag(input.IfMatch, listingETag(sale)) {
+			slog.WarnContext(ctx, "listing patch rejected: stale If-Match", "sale_id", sale.ID, "if_match", input.IfMatch)
+			return ErrListingModified
+		}
+
+		current, err := json.Marshal(dto.InputUpdateListingDTO{Brand: sale.Brand, Model: sale.Model, Price: sale.Price})
+		if err != nil {
+			return err
+		}
+		merged, err := mergepatch.Apply(current, input.Patch)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidListing, err)
+		}
+
+		var listing dto.InputUpdateListingDTO
+		if err := validation.JSON(merged, &listing); err != nil {
+			return err
+		}
+
+		if err := sale.UpdateListing(listing.Brand, listing.Model, listing.Price, time.Now()); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidListing, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
+	slog.InfoContext(ctx, "listing patched", "sale_id", sale.ID, "vehicle_id", sale.VehicleID)
+	uc.publish(ctx, events.TypeListingUpdated, sale)
+
+	return toListingOutput(sale), nil
+}
 
-	if sale.Status != domain.StatusAvailable {
-		return nil, errors.New("sale is not available for purchase")
+func (uc *saleUseCase) loadListing(ctx context.Context, vehicleID string) (*domain.Sale, error) {
+	return uc.repo.GetByVehicleID(ctx, vehicleID)
+}
+
+func toListingOutput(sale *domain.Sale) *dto.OutputListingDTO {
+	return &dto.OutputListingDTO{
+		SaleID:    sale.ID,
+		VehicleID: sale.VehicleID,
+		Brand:     sale.Brand,
+		Model:     sale.Model,
+		Price:     sale.Price,
+		Status:    string(sale.Status),
+		UpdatedAt: sale.UpdatedAt,
+		ETag:      listingETag(sale),
 	}
+}
+
+// listingETag identifica a versão do anúncio que o cliente leu.
+func listingETag(sale *domain.Sale) string {
+	return `"` + strconv.Itoa(sale.Version) + `"`
+}
+
+// matchesETag confere um header If-Match (RFC 9110) com a ETag atual. A comparação é forte:
+// ETags fracas (W/"...") nunca batem.
+func matchesETag(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// updateWithRetry lê a venda com load, aplica change e grava com Update, tudo na mesma
+// transação; load deve usar o ctx que recebe. Se outra requisição alterou a venda entre a
+// leitura e a gravação, o ciclo é refeito numa nova transação com a venda relida, até
+// maxConflictRetries vezes. Só serve para operações seguras de repetir: change precisa conferir
+// as regras de novo a cada chamada, porque o estado pode ter mudado.
+func (uc *saleUseCase) updateWithRetry(ctx context.Context, operation string, load func(ctx context.Context) (*domain.Sale, error), change func(sale *domain.Sale) error) (*domain.Sale, error) {
+	for attempt := 1; ; attempt++ {
+		var sale *domain.Sale
+		changed := false
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			if sale, err = load(ctx); err != nil {
+				return err
+			}
+			if err := change(sale); err != nil {
+				return err
+			}
+			changed = true
+			return uc.repo.Update(ctx, sale)
+		})
+		if !changed {
+			return nil, err
+		}
+		if errors.Is(err, repository.ErrConcurrentModification) && attempt <= maxConflictRetries {
+			slog.WarnContext(ctx, "sale modified concurrently, retrying", "operation", operation, "sale_id", sale.ID, "attempt", attempt, "error", err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return sale, nil
+	}
+}
+
+func (uc *saleUseCase) Purchase(ctx context.Context, saleID string, input dto.InputPurchaseDTO) (*dto.OutputPurchaseDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.Purchase", trace.WithAttributes(attribute.String("sale.id", saleID)))
+	defer span.End()
 
-	now := time.Now()
-	sale.Status = domain.StatusPendingPayment
-	sale.BuyerCPF = &input.BuyerCPF
-	sale.SaleDate = &now
-	sale.PaymentID = uuid.New().String()
-	sale.UpdatedAt = now
+	sale, err := uc.updateWithRetry(ctx, "Purchase", func(ctx context.Context) (*domain.Sale, error) {
+		return uc.repo.GetByID(ctx, saleID)
+	}, func(sale *domain.Sale) error {
+		if sale.Status != domain.StatusAvailable {
+			slog.WarnContext(ctx, "purchase rejected: sale is not available", "sale_id", sale.ID, "status", sale.Status)
+			return ErrSaleNotAvailable
+		}
 
-	err = uc.repo.Update(ctx, sale)
+		now := time.Now()
+		sale.Status = domain.StatusPendingPayment
+		sale.BuyerCPF = &input.BuyerCPF
+		sale.SaleDate = &now
+		sale.PaymentID = uuid.New().String()
+		sale.UpdatedAt = now
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
+	slog.InfoContext(ctx, "sale reserved", "sale_id", sale.ID, "payment_id", sale.PaymentID, "buyer_cpf", input.BuyerCPF)
+	uc.metrics.PurchaseInitiated()
+	uc.publish(ctx, events.TypeListingReserved, sale)
 
 	output := &dto.OutputPurchaseDTO{
 		PaymentID: sale.PaymentID,
@@ -96,33 +434,52 @@ func (uc *saleUseCase) Purchase(ctx context.Context, saleID string, input dto.In
 }
 
 func (uc *saleUseCase) HandlePaymentWebhook(ctx context.Context, input *dto.InputWebhookDTO) error {
-	sale, err := uc.repo.GetByPaymentID(ctx, input.PaymentID)
-	if err != nil {
-		return err
-	}
+	ctx, span := telemetry.Start(ctx, "saleUseCase.HandlePaymentWebhook", trace.WithAttributes(attribute.String("payment.id", input.PaymentID)))
+	defer span.End()
 
-	if sale.Status != domain.StatusPendingPayment {
-		return errors.New("sale is not in pending payment status")
-	}
+	var eventType events.Type
+	var paymentResult string
+	sale, err := uc.updateWithRetry(ctx, "HandlePaymentWebhook", func(ctx context.Context) (*domain.Sale, error) {
+		return uc.repo.GetByPaymentID(ctx, input.PaymentID)
+	}, func(sale *domain.Sale) error {
+		if sale.Status != domain.StatusPendingPayment {
+			slog.WarnContext(ctx, "webhook rejected: sale is not pending payment", "sale_id", sale.ID, "status", sale.Status)
+			return ErrSaleNotPendingPayment
+		}
 
-	switch strings.ToUpper(input.Status) {
-	case "APPROVED", "EFETUADO":
-		sale.Status = domain.StatusSold
-	case "CANCELED", "CANCELADO":
-		sale.Status = domain.StatusCanceled
-	default:
-		return errors.New("invalid payment status received from webhook")
+		switch strings.ToUpper(input.Status) {
+		case "APPROVED", "EFETUADO":
+			sale.Status = domain.StatusSold
+			eventType = events.TypeListingSold
+			paymentResult = metrics.PaymentApproved
+		case "CANCELED", "CANCELADO":
+			sale.Status = domain.StatusCanceled
+			eventType = events.TypeListingCanceled
+			paymentResult = metrics.PaymentCanceled
+		default:
+			slog.WarnContext(ctx, "webhook rejected: invalid payment status", "payment_id", input.PaymentID, "payment_status", input.Status)
+			return ErrInvalidPaymentStatus
+		}
+		sale.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return telemetry.Error(span, err)
 	}
+	slog.InfoContext(ctx, "payment status applied", "sale_id", sale.ID, "payment_id", sale.PaymentID, "status", sale.Status)
+	uc.metrics.PaymentProcessed(paymentResult)
+	uc.publish(ctx, eventType, sale)
 
-	sale.UpdatedAt = time.Now()
-
-	return uc.repo.Update(ctx, sale)
+	return nil
 }
 
 func (uc *saleUseCase) ListAvailable(ctx context.Context) ([]*dto.OutputSaleItemDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.ListAvailable")
+	defer span.End()
+
 	sales, err := uc.repo.GetAvailableByPrice(ctx)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	var output []*dto.OutputSaleItemDTO
@@ -140,9 +497,12 @@ func (uc *saleUseCase) ListAvailable(ctx context.Context) ([]*dto.OutputSaleItem
 }
 
 func (uc *saleUseCase) ListSold(ctx context.Context) ([]*dto.OutputSaleItemDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.ListSold")
+	defer span.End()
+
 	sales, err := uc.repo.GetSoldByPrice(ctx)
 	if err != nil {
-		return nil, err
+		return nil, telemetry.Error(span, err)
 	}
 
 	var output []*dto.OutputSaleItemDTO
@@ -158,3 +518,81 @@ func (uc *saleUseCase) ListSold(ctx context.Context) ([]*dto.OutputSaleItemDTO,
 
 	return output, nil
 }
+
+func (uc *saleUseCase) ListBuyerSales(ctx context.Context, input dto.InputBuyerSalesDTO) (*dto.OutputBuyerSalesDTO, error) {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.ListBuyerSales")
+	defer span.End()
+
+	if len(domain.NormalizeCPF(input.BuyerCPF)) != 11 {
+		return nil, telemetry.Error(span, ErrInvalidBuyerCPF)
+	}
+
+	page := max(input.Page, 1)
+	pageSize := input.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultBuyerSalesPage
+	}
+	pageSize = min(pageSize, maxBuyerSalesPageSize)
+
+	sales, total, err := uc.repo.GetByBuyerCPF(ctx, input.BuyerCPF, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, telemetry.Error(span, err)
+	}
+
+	output := &dto.OutputBuyerSalesDTO{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+		Items:    make([]*dto.OutputBuyerSaleItemDTO, 0, len(sales)),
+	}
+	for _, sale := range sales {
+		item := &dto.OutputBuyerSaleItemDTO{
+			SaleID:    sale.ID,
+			VehicleID: sale.VehicleID,
+			Brand:     sale.Brand,
+			Model:     sale.Model,
+			Price:     sale.Price,
+			Status:    string(sale.Status),
+			PaymentID: sale.PaymentID,
+			SaleDate:  sale.SaleDate,
+			CreatedAt: sale.CreatedAt,
+			UpdatedAt: sale.UpdatedAt,
+		}
+		if sale.BuyerCPF != nil {
+			item.BuyerCPF = domain.MaskCPF(*sale.BuyerCPF)
+		}
+		output.Items = append(output.Items, item)
+	}
+
+	return output, nil
+}
+
+func (uc *saleUseCase) ExportSoldReport(ctx context.Context, input dto.InputSalesReportDTO, writer report.Writer) error {
+	ctx, span := telemetry.Start(ctx, "saleUseCase.ExportSoldReport")
+	defer span.End()
+
+	columns, err := report.SalesColumns(input.Columns)
+	if err != nil {
+		return telemetry.Error(span, err)
+	}
+
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = column.Name
+	}
+	if err := writer.WriteRow(values); err != nil {
+		return telemetry.Error(span, err)
+	}
+
+	if input.UnmaskCPF {
+		slog.InfoContext(ctx, "exporting sales report with unmasked buyer CPF", "from", input.From, "to", input.To)
+	}
+
+	err = uc.repo.ForEachSold(ctx, input.From, input.To, func(sale *domain.Sale) error {
+		for i, column := range columns {
+			values[i] = column.Value(sale, !input.UnmaskCPF)
+		}
+		return writer.WriteRow(values)
+	})
+	return telemetry.Error(span, err)
+}
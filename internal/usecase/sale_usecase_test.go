@@ -1,16 +1,30 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/dto"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/events"
+	eventMocks "github.com/NicolasNSC/showcase-service-fiap/internal/events/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/importer"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/metrics"
+	metricMocks "github.com/NicolasNSC/showcase-service-fiap/internal/metrics/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/report"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/repository"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/telemetry/telemetrytest"
 	"github.com/NicolasNSC/showcase-service-fiap/internal/usecase"
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
 
@@ -19,12 +33,30 @@ type SaleUseCaseSuite struct {
 
 	ctx        context.Context
 	repository *mocks.MockSaleRepository
+	tx         repository.TxManager
+	publisher  *eventMocks.MockPublisher
+	recorder   *metricMocks.MockRecorder
 }
 
 func (suite *SaleUseCaseSuite) SetupTest() {
 	ctrl := gomock.NewController(suite.T())
 	suite.ctx = context.Background()
 	suite.repository = mocks.NewMockSaleRepository(ctrl)
+	suite.tx = repository.NewMemoryTxManager()
+	suite.publisher = eventMocks.NewMockPublisher(ctrl)
+	suite.recorder = metricMocks.NewMockRecorder(ctrl)
+}
+
+func eventOfType(eventType events.Type) gomock.Matcher {
+	return gomock.Cond(func(event events.Event) bool {
+		return event.Type == eventType && event.ID != "" && event.SaleID != ""
+	})
+}
+
+func saleForVehicle(vehicleID string) gomock.Matcher {
+	return gomock.Cond(func(sale *domain.Sale) bool {
+		return sale.VehicleID == vehicleID
+	})
 }
 
 func Test_SaleUseCaseSuite(t *testing.T) {
@@ -41,9 +73,11 @@ func (suite *SaleUseCaseSuite) Test_CreateListing() {
 	}
 
 	suite.T().Run("should create listing successfully", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
-		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCreated)).Return(nil)
+		suite.recorder.EXPECT().ListingsCreated(1)
 
 		output, err := usecase.CreateListing(suite.ctx, input)
 		suite.NoError(err)
@@ -53,8 +87,20 @@ func (suite *SaleUseCaseSuite) Test_CreateListing() {
 		suite.WithinDuration(time.Now(), output.CreatedAt, time.Second)
 	})
 
+	suite.T().Run("should not fail when the event cannot be published", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("notify error"))
+		suite.recorder.EXPECT().ListingsCreated(1)
+
+		output, err := usecase.CreateListing(suite.ctx, input)
+		suite.NoError(err)
+		suite.NotNil(output)
+	})
+
 	suite.T().Run("should return error when domain.NewSale fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
 		input := &dto.InputCreateListingDTO{
 			VehicleID: "",
@@ -69,9 +115,9 @@ func (suite *SaleUseCaseSuite) Test_CreateListing() {
 	})
 
 	suite.T().Run("should return error when repo.Save fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
-		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).Return(errors.New("db error"))
+		suite.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 
 		output, err := usecase.CreateListing(suite.ctx, input)
 		suite.Error(err)
@@ -79,6 +125,110 @@ func (suite *SaleUseCaseSuite) Test_CreateListing() {
 	})
 }
 
+func (suite *SaleUseCaseSuite) Test_BulkCreateListings() {
+	csvInput := "vehicle_id,brand,model,price\nvehicle-1,Toyota,Corolla,50000\nvehicle-2,,Civic,60000\nvehicle-3,Ford,Ka,abc\nvehicle-4,Honda,Fit,40000\n"
+
+	suite.T().Run("should insert valid rows and report invalid ones", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2)).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCreated), eventOfType(events.TypeListingCreated)).Return(nil)
+		suite.recorder.EXPECT().ListingsCreated(2)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(csvInput)), dto.InputBulkImportDTO{})
+		suite.NoError(err)
+		suite.Equal(4, output.Total)
+		suite.Equal(2, output.Created)
+		suite.Equal(2, output.Failed)
+		suite.Equal(dto.BulkRowCreated, output.Rows[0].Status)
+		suite.NotEmpty(output.Rows[0].SaleID)
+		suite.Equal(dto.BulkRowFailed, output.Rows[1].Status)
+		suite.Equal("brand and model are required for listing", output.Rows[1].Error)
+		suite.Equal("vehicle-2", output.Rows[1].VehicleID)
+		suite.Equal(dto.BulkRowFailed, output.Rows[2].Status)
+		suite.Equal("price must be a number", output.Rows[2].Error)
+		suite.Equal(dto.BulkRowCreated, output.Rows[3].Status)
+	})
+
+	suite.T().Run("should only validate rows on dry run", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(csvInput)), dto.InputBulkImportDTO{DryRun: true})
+		suite.NoError(err)
+		suite.True(output.DryRun)
+		suite.Equal(0, output.Created)
+		suite.Equal(2, output.Failed)
+		suite.Equal(dto.BulkRowValid, output.Rows[0].Status)
+		suite.Equal(dto.BulkRowValid, output.Rows[3].Status)
+	})
+
+	suite.T().Run("should not insert anything in atomic mode when a row is invalid", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(csvInput)), dto.InputBulkImportDTO{Atomic: true})
+		suite.NoError(err)
+		suite.Equal(0, output.Created)
+		suite.Equal(2, output.Failed)
+		suite.Equal(dto.BulkRowNotApplied, output.Rows[0].Status)
+		suite.Equal("import aborted: 2 row(s) failed validation", output.Rows[0].Error)
+	})
+
+	suite.T().Run("should insert every row at once in atomic mode", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		input := "vehicle_id,brand,model,price\nvehicle-1,Toyota,Corolla,50000\nvehicle-2,Honda,Civic,60000\n"
+
+		suite.repository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2)).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCreated), eventOfType(events.TypeListingCreated)).Return(nil)
+		suite.recorder.EXPECT().ListingsCreated(2)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(input)), dto.InputBulkImportDTO{Atomic: true})
+		suite.NoError(err)
+		suite.Equal(2, output.Created)
+		suite.Equal(0, output.Failed)
+	})
+
+	suite.T().Run("should save rows one by one when repo.SaveBatch fails", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2)).Return(errors.New("duplicate key"))
+		gomock.InOrder(
+			suite.repository.EXPECT().Save(gomock.Any(), saleForVehicle("vehicle-1")).Return(errors.New("duplicate key")),
+			suite.repository.EXPECT().Save(gomock.Any(), saleForVehicle("vehicle-4")).Return(nil),
+		)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCreated)).Return(nil)
+		suite.recorder.EXPECT().ListingsCreated(1)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(csvInput)), dto.InputBulkImportDTO{})
+		suite.NoError(err)
+		suite.Equal(1, output.Created)
+		suite.Equal(3, output.Failed)
+		suite.Equal(dto.BulkRowFailed, output.Rows[0].Status)
+		suite.Equal("duplicate key", output.Rows[0].Error)
+		suite.Equal(dto.BulkRowCreated, output.Rows[3].Status)
+	})
+
+	suite.T().Run("should mark every row as failed when the atomic batch fails", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		input := "vehicle_id,brand,model,price\nvehicle-1,Toyota,Corolla,50000\nvehicle-2,Honda,Civic,60000\n"
+
+		suite.repository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2)).Return(errors.New("db error"))
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader(input)), dto.InputBulkImportDTO{Atomic: true})
+		suite.NoError(err)
+		suite.Equal(0, output.Created)
+		suite.Equal(2, output.Failed)
+		suite.Equal("db error", output.Rows[1].Error)
+	})
+
+	suite.T().Run("should return error when input is malformed", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		output, err := usecase.BulkCreateListings(suite.ctx, importer.NewCSVListingReader(strings.NewReader("brand\nToyota\n")), dto.InputBulkImportDTO{})
+		suite.True(errors.Is(err, importer.ErrInvalidInput))
+		suite.Nil(output)
+	})
+}
+
 func (suite *SaleUseCaseSuite) Test_UpdateListing() {
 	vehicleID := "fc338f17-9fe8-40d1-8232-461fb1ecd080"
 	existingSale := &domain.Sale{
@@ -98,33 +248,292 @@ func (suite *SaleUseCaseSuite) Test_UpdateListing() {
 	}
 
 	suite.T().Run("should update listing successfully", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
-		suite.repository.EXPECT().GetByVehicleID(suite.ctx, vehicleID).Return(existingSale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
 
 		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
 		suite.NoError(err)
 	})
 
 	suite.T().Run("should return error when repo.GetByVehicleID fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
-		suite.repository.EXPECT().GetByVehicleID(suite.ctx, vehicleID).Return(nil, errors.New("not found"))
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(nil, errors.New("not found"))
 
 		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
 		suite.Error(err)
 	})
 
 	suite.T().Run("should return error when repo.Update fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 
-		suite.repository.EXPECT().GetByVehicleID(suite.ctx, vehicleID).Return(existingSale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(errors.New("db error"))
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 
 		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
 		suite.Error(err)
 	})
+
+	suite.T().Run("should read the listing again and retry after a conflict", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		conflict := &repository.ConcurrentModificationError{SaleID: "sale-123", Expected: 1, Current: 2}
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale, nil).Times(2)
+		gomock.InOrder(
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(conflict),
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+		)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
+
+		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should give up after repeated conflicts", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		conflict := &repository.ConcurrentModificationError{SaleID: "sale-123", Expected: 1, Current: 2}
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale, nil).Times(4)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(conflict).Times(4)
+
+		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
+		suite.ErrorIs(err, repository.ErrConcurrentModification)
+	})
+
+	suite.T().Run("should read and update the listing inside the same transaction", func(t *testing.T) {
+		tx := mocks.NewMockTxManager(gomock.NewController(t))
+		usecase := usecase.NewSaleUseCase(suite.repository, tx, suite.publisher, suite.recorder)
+		inTx := gomock.Cond(func(ctx context.Context) bool { return ctx.Value(txKey{}) == "tx-1" })
+
+		tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(context.WithValue(ctx, txKey{}, "tx-1"))
+		})
+		suite.repository.EXPECT().GetByVehicleID(inTx, vehicleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(inTx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
+
+		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should not publish when the transaction cannot be committed", func(t *testing.T) {
+		tx := mocks.NewMockTxManager(gomock.NewController(t))
+		usecase := usecase.NewSaleUseCase(suite.repository, tx, suite.publisher, suite.recorder)
+
+		tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errors.New("commit failed")
+		})
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := usecase.UpdateListing(suite.ctx, vehicleID, input)
+		suite.EqualError(err, "commit failed")
+	})
+}
+
+type txKey struct{}
+
+func (suite *SaleUseCaseSuite) Test_GetListing() {
+	vehicleID := "fc338f17-9fe8-40d1-8232-461fb1ecd080"
+
+	suite.T().Run("should return the listing with its ETag", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(&domain.Sale{
+			ID:        "sale-123",
+			VehicleID: vehicleID,
+			Brand:     "Toyota",
+			Model:     "Corolla",
+			Price:     50000,
+			Status:    domain.StatusAvailable,
+			UpdatedAt: updatedAt,
+			Version:   7,
+		}, nil)
+
+		output, err := usecase.GetListing(suite.ctx, vehicleID)
+		suite.NoError(err)
+		suite.Equal("sale-123", output.SaleID)
+		suite.Equal("Corolla", output.Model)
+		suite.Equal(`"7"`, output.ETag)
+	})
+
+	suite.T().Run("should return error when the listing does not exist", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(nil, repository.ErrSaleNotFound)
+
+		output, err := usecase.GetListing(suite.ctx, vehicleID)
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+		suite.Nil(output)
+	})
+}
+
+func (suite *SaleUseCaseSuite) Test_PatchListing() {
+	vehicleID := "fc338f17-9fe8-40d1-8232-461fb1ecd080"
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	etag := `"3"`
+	existingSale := func() *domain.Sale {
+		return &domain.Sale{
+			ID:        "sale-123",
+			VehicleID: vehicleID,
+			Brand:     "Toyota",
+			Model:     "Corolla",
+			Price:     50000,
+			Status:    domain.StatusAvailable,
+			CreatedAt: updatedAt,
+			UpdatedAt: updatedAt,
+			Version:   3,
+		}
+	}
+
+	suite.T().Run("should change only the fields present in the patch", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Cond(func(sale *domain.Sale) bool {
+			return sale.Brand == "Toyota" && sale.Model == "Corolla" && sale.Price == 47500 && sale.UpdatedAt.After(updatedAt)
+		})).DoAndReturn(func(_ context.Context, sale *domain.Sale) error {
+			sale.Version++
+			return nil
+		})
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
+
+		output, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch:   []byte(`{"price": 47500}`),
+			IfMatch: etag,
+		})
+		suite.NoError(err)
+		suite.Equal(47500.0, output.Price)
+		suite.Equal("Toyota", output.Brand)
+		suite.Equal(`"4"`, output.ETag)
+	})
+
+	suite.T().Run("should accept a wildcard If-Match", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
+
+		output, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch:   []byte(`{"model": "Corolla Cross"}`),
+			IfMatch: "*",
+		})
+		suite.NoError(err)
+		suite.Equal("Corolla Cross", output.Model)
+	})
+
+	suite.T().Run("should reject a stale If-Match without updating", func(t *testing.T) {
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+
+		output, err := uc.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch:   []byte(`{"price": 47500}`),
+			IfMatch: `"1"`,
+		})
+		suite.ErrorIs(err, usecase.ErrListingModified)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should reapply a patch without If-Match on the sale read again after a conflict", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		changed := existingSale()
+		changed.Model = "Corolla Cross"
+		changed.Version = 4
+
+		gomock.InOrder(
+			suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil),
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&repository.ConcurrentModificationError{SaleID: "sale-123", Expected: 3, Current: 4}),
+			suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(changed, nil),
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Cond(func(sale *domain.Sale) bool {
+				return sale.Model == "Corolla Cross" && sale.Price == 47500 && sale.Version == 4
+			})).Return(nil),
+		)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingUpdated)).Return(nil)
+
+		output, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch: []byte(`{"price": 47500}`),
+		})
+		suite.NoError(err)
+		suite.Equal("Corolla Cross", output.Model)
+	})
+
+	suite.T().Run("should not retry a conditional patch after a conflict", func(t *testing.T) {
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		changed := existingSale()
+		changed.Version = 4
+
+		gomock.InOrder(
+			suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil),
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&repository.ConcurrentModificationError{SaleID: "sale-123", Expected: 3, Current: 4}),
+			suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(changed, nil),
+		)
+
+		output, err := uc.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch:   []byte(`{"price": 47500}`),
+			IfMatch: etag,
+		})
+		suite.ErrorIs(err, usecase.ErrListingModified)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should report the fields a null removes", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+
+		output, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch: []byte(`{"brand": null}`),
+		})
+		var fields validation.Errors
+		suite.True(errors.As(err, &fields))
+		suite.Equal("brand", fields[0].Field)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should reject a patch with a field of the wrong type", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+
+		_, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch: []byte(`{"price": "cheap"}`),
+		})
+		var fields validation.Errors
+		suite.True(errors.As(err, &fields))
+		suite.Equal("price", fields[0].Field)
+	})
+
+	suite.T().Run("should return error when the listing does not exist", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(nil, repository.ErrSaleNotFound)
+
+		_, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch: []byte(`{"price": 47500}`),
+		})
+		suite.ErrorIs(err, repository.ErrSaleNotFound)
+	})
+
+	suite.T().Run("should return error when repo.Update fails", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		suite.repository.EXPECT().GetByVehicleID(gomock.Any(), vehicleID).Return(existingSale(), nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		_, err := usecase.PatchListing(suite.ctx, vehicleID, dto.InputPatchListingDTO{
+			Patch: []byte(`{"price": 47500}`),
+		})
+		suite.Error(err)
+	})
 }
 
 func (suite *SaleUseCaseSuite) Test_Purchase() {
@@ -147,9 +556,11 @@ func (suite *SaleUseCaseSuite) Test_Purchase() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetByID(suite.ctx, saleID).Return(existingSale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingReserved)).Return(nil)
+		suite.recorder.EXPECT().PurchaseInitiated()
 
 		output, err := usecase.Purchase(suite.ctx, saleID, input)
 		suite.NoError(err)
@@ -158,8 +569,8 @@ func (suite *SaleUseCaseSuite) Test_Purchase() {
 	})
 
 	suite.T().Run("should return error if repo.GetByID fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetByID(suite.ctx, saleID).Return(nil, errors.New("not found"))
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(nil, errors.New("not found"))
 
 		output, err := usecase.Purchase(suite.ctx, saleID, input)
 		suite.Error(err)
@@ -179,11 +590,11 @@ func (suite *SaleUseCaseSuite) Test_Purchase() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetByID(suite.ctx, saleID).Return(notAvailableSale, nil)
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(notAvailableSale, nil)
 
-		output, err := usecase.Purchase(suite.ctx, saleID, input)
-		suite.Error(err)
+		output, err := uc.Purchase(suite.ctx, saleID, input)
+		suite.ErrorIs(err, usecase.ErrSaleNotAvailable)
 		suite.Nil(output)
 	})
 
@@ -200,14 +611,31 @@ func (suite *SaleUseCaseSuite) Test_Purchase() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetByID(suite.ctx, saleID).Return(existingSale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(errors.New("update failed"))
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(existingSale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
 
 		output, err := usecase.Purchase(suite.ctx, saleID, input)
 		suite.Error(err)
 		suite.Nil(output)
 	})
+
+	suite.T().Run("should not reserve a sale another buyer reserved concurrently", func(t *testing.T) {
+		now := time.Now()
+		available := &domain.Sale{ID: saleID, VehicleID: "vehicle-1", Status: domain.StatusAvailable, CreatedAt: now, UpdatedAt: now, Version: 1}
+		reserved := &domain.Sale{ID: saleID, VehicleID: "vehicle-1", Status: domain.StatusPendingPayment, CreatedAt: now, UpdatedAt: now, Version: 2}
+
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		gomock.InOrder(
+			suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(available, nil),
+			suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&repository.ConcurrentModificationError{SaleID: saleID, Expected: 1, Current: 2}),
+			suite.repository.EXPECT().GetByID(gomock.Any(), saleID).Return(reserved, nil),
+		)
+
+		output, err := usecase.Purchase(suite.ctx, saleID, input)
+		suite.EqualError(err, "sale is not available for purchase")
+		suite.Nil(output)
+	})
 }
 
 func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
@@ -227,13 +655,15 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "APPROVED",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingSold)).Return(nil)
+		suite.recorder.EXPECT().PaymentProcessed(metrics.PaymentApproved)
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.NoError(err)
@@ -253,13 +683,15 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "EFETUADO",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingSold)).Return(nil)
+		suite.recorder.EXPECT().PaymentProcessed(metrics.PaymentApproved)
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.NoError(err)
@@ -279,13 +711,15 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "CANCELED",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCanceled)).Return(nil)
+		suite.recorder.EXPECT().PaymentProcessed(metrics.PaymentCanceled)
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.NoError(err)
@@ -305,13 +739,15 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "CANCELADO",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), eventOfType(events.TypeListingCanceled)).Return(nil)
+		suite.recorder.EXPECT().PaymentProcessed(metrics.PaymentCanceled)
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.NoError(err)
@@ -331,25 +767,24 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "INVALID_STATUS",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
 
-		err := usecase.HandlePaymentWebhook(suite.ctx, input)
-		suite.Error(err)
-		suite.Contains(err.Error(), "invalid payment status")
+		err := uc.HandlePaymentWebhook(suite.ctx, input)
+		suite.ErrorIs(err, usecase.ErrInvalidPaymentStatus)
 	})
 
 	suite.T().Run("should return error if GetByPaymentID fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "APPROVED",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(nil, errors.New("not found"))
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(nil, errors.New("not found"))
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.Error(err)
@@ -370,16 +805,15 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "APPROVED",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
 
-		err := usecase.HandlePaymentWebhook(suite.ctx, input)
-		suite.Error(err)
-		suite.Contains(err.Error(), "sale is not in pending payment status")
+		err := uc.HandlePaymentWebhook(suite.ctx, input)
+		suite.ErrorIs(err, usecase.ErrSaleNotPendingPayment)
 	})
 
 	suite.T().Run("should return error if repo.Update fails", func(t *testing.T) {
@@ -396,13 +830,13 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 			UpdatedAt: now.Add(-time.Hour),
 		}
 
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		input := &dto.InputWebhookDTO{
 			PaymentID: paymentID,
 			Status:    "APPROVED",
 		}
-		suite.repository.EXPECT().GetByPaymentID(suite.ctx, paymentID).Return(sale, nil)
-		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(errors.New("update error"))
+		suite.repository.EXPECT().GetByPaymentID(gomock.Any(), paymentID).Return(sale, nil)
+		suite.repository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
 
 		err := usecase.HandlePaymentWebhook(suite.ctx, input)
 		suite.Error(err)
@@ -412,7 +846,7 @@ func (suite *SaleUseCaseSuite) Test_HandlePaymentWebhook() {
 
 func (suite *SaleUseCaseSuite) Test_ListAvailable() {
 	suite.T().Run("should return available listings ordered by price", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		sales := []*domain.Sale{
 			{
 				ID:        "sale-1",
@@ -429,7 +863,7 @@ func (suite *SaleUseCaseSuite) Test_ListAvailable() {
 				Price:     60000,
 			},
 		}
-		suite.repository.EXPECT().GetAvailableByPrice(suite.ctx).Return(sales, nil)
+		suite.repository.EXPECT().GetAvailableByPrice(gomock.Any()).Return(sales, nil)
 
 		output, err := usecase.ListAvailable(suite.ctx)
 		suite.NoError(err)
@@ -443,8 +877,8 @@ func (suite *SaleUseCaseSuite) Test_ListAvailable() {
 	})
 
 	suite.T().Run("should return error if repo.GetAvailableByPrice fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetAvailableByPrice(suite.ctx).Return(nil, errors.New("db error"))
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetAvailableByPrice(gomock.Any()).Return(nil, errors.New("db error"))
 
 		output, err := usecase.ListAvailable(suite.ctx)
 		suite.Error(err)
@@ -454,7 +888,7 @@ func (suite *SaleUseCaseSuite) Test_ListAvailable() {
 
 func (suite *SaleUseCaseSuite) Test_ListSold() {
 	suite.T().Run("should return sold listings ordered by price", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
 		sales := []*domain.Sale{
 			{
 				ID:        "sale-1",
@@ -471,7 +905,7 @@ func (suite *SaleUseCaseSuite) Test_ListSold() {
 				Price:     60000,
 			},
 		}
-		suite.repository.EXPECT().GetSoldByPrice(suite.ctx).Return(sales, nil)
+		suite.repository.EXPECT().GetSoldByPrice(gomock.Any()).Return(sales, nil)
 
 		output, err := usecase.ListSold(suite.ctx)
 		suite.NoError(err)
@@ -485,11 +919,163 @@ func (suite *SaleUseCaseSuite) Test_ListSold() {
 	})
 
 	suite.T().Run("should return error if repo.GetSoldByPrice fails", func(t *testing.T) {
-		usecase := usecase.NewSaleUseCase(suite.repository)
-		suite.repository.EXPECT().GetSoldByPrice(suite.ctx).Return(nil, errors.New("db error"))
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetSoldByPrice(gomock.Any()).Return(nil, errors.New("db error"))
 
 		output, err := usecase.ListSold(suite.ctx)
 		suite.Error(err)
 		suite.Nil(output)
 	})
 }
+
+func (suite *SaleUseCaseSuite) Test_ListBuyerSales() {
+	buyerCPF := "123.456.789-00"
+
+	suite.T().Run("should return the masked purchase history with defaults", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		saleDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+		sales := []*domain.Sale{
+			{
+				ID:        "sale-1",
+				VehicleID: "vehicle-1",
+				Brand:     "Toyota",
+				Model:     "Corolla",
+				Price:     50000,
+				Status:    domain.StatusSold,
+				PaymentID: "payment-1",
+				BuyerCPF:  &buyerCPF,
+				SaleDate:  &saleDate,
+			},
+		}
+		suite.repository.EXPECT().GetByBuyerCPF(gomock.Any(), buyerCPF, 20, 0).Return(sales, 1, nil)
+
+		output, err := usecase.ListBuyerSales(suite.ctx, dto.InputBuyerSalesDTO{BuyerCPF: buyerCPF})
+		suite.NoError(err)
+		suite.Equal(1, output.Page)
+		suite.Equal(20, output.PageSize)
+		suite.Equal(1, output.Total)
+		suite.Len(output.Items, 1)
+		suite.Equal("sale-1", output.Items[0].SaleID)
+		suite.Equal("SOLD", output.Items[0].Status)
+		suite.Equal("payment-1", output.Items[0].PaymentID)
+		suite.Equal("***.456.789-**", output.Items[0].BuyerCPF)
+		suite.Equal(saleDate, *output.Items[0].SaleDate)
+	})
+
+	suite.T().Run("should compute offset and cap page size", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByBuyerCPF(gomock.Any(), buyerCPF, 100, 200).Return([]*domain.Sale{}, 0, nil)
+
+		output, err := usecase.ListBuyerSales(suite.ctx, dto.InputBuyerSalesDTO{BuyerCPF: buyerCPF, Page: 3, PageSize: 500})
+		suite.NoError(err)
+		suite.Equal(3, output.Page)
+		suite.Equal(100, output.PageSize)
+		suite.NotNil(output.Items)
+		suite.Len(output.Items, 0)
+	})
+
+	suite.T().Run("should return error for invalid CPF", func(t *testing.T) {
+		uc := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		output, err := uc.ListBuyerSales(suite.ctx, dto.InputBuyerSalesDTO{BuyerCPF: "123"})
+		suite.ErrorIs(err, usecase.ErrInvalidBuyerCPF)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error if repo.GetByBuyerCPF fails", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().GetByBuyerCPF(gomock.Any(), buyerCPF, 20, 0).Return(nil, 0, errors.New("db error"))
+
+		output, err := usecase.ListBuyerSales(suite.ctx, dto.InputBuyerSalesDTO{BuyerCPF: buyerCPF})
+		suite.Error(err)
+		suite.Nil(output)
+	})
+}
+
+func (suite *SaleUseCaseSuite) Test_ExportSoldReport() {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	buyerCPF := "12345678900"
+	sale := &domain.Sale{
+		ID:        "sale-1",
+		VehicleID: "vehicle-1",
+		Brand:     "Toyota",
+		Model:     "Corolla",
+		Price:     50000,
+		Status:    domain.StatusSold,
+		PaymentID: "payment-1",
+		BuyerCPF:  &buyerCPF,
+	}
+	forEach := func(ctx context.Context, from, to time.Time, fn func(*domain.Sale) error) error {
+		return fn(sale)
+	}
+
+	suite.T().Run("should write the header and one row per sale with masked cpf", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().ForEachSold(gomock.Any(), from, to, gomock.Any()).DoAndReturn(forEach)
+
+		var buf bytes.Buffer
+		writer := report.NewCSVWriter(&buf)
+		input := dto.InputSalesReportDTO{From: from, To: to, Columns: []string{"sale_id", "price", "buyer_cpf"}}
+
+		err := usecase.ExportSoldReport(suite.ctx, input, writer)
+		suite.NoError(err)
+		suite.NoError(writer.Close())
+		suite.Equal("sale_id,price,buyer_cpf\nsale-1,50000.00,***.456.789-**\n", buf.String())
+	})
+
+	suite.T().Run("should show the full cpf when unmasked", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().ForEachSold(gomock.Any(), from, to, gomock.Any()).DoAndReturn(forEach)
+
+		var buf bytes.Buffer
+		writer := report.NewCSVWriter(&buf)
+		input := dto.InputSalesReportDTO{From: from, To: to, Columns: []string{"buyer_cpf"}, UnmaskCPF: true}
+
+		err := usecase.ExportSoldReport(suite.ctx, input, writer)
+		suite.NoError(err)
+		suite.NoError(writer.Close())
+		suite.Equal("buyer_cpf\n12345678900\n", buf.String())
+	})
+
+	suite.T().Run("should return error for unknown columns", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		var buf bytes.Buffer
+		err := usecase.ExportSoldReport(suite.ctx, dto.InputSalesReportDTO{Columns: []string{"secret"}}, report.NewCSVWriter(&buf))
+		suite.True(errors.Is(err, report.ErrUnknownColumn))
+		suite.Zero(buf.Len())
+	})
+
+	suite.T().Run("should return error if repo.ForEachSold fails", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+		suite.repository.EXPECT().ForEachSold(gomock.Any(), from, to, gomock.Any()).Return(errors.New("db error"))
+
+		var buf bytes.Buffer
+		err := usecase.ExportSoldReport(suite.ctx, dto.InputSalesReportDTO{From: from, To: to}, report.NewCSVWriter(&buf))
+		suite.EqualError(err, "db error")
+	})
+}
+
+func (suite *SaleUseCaseSuite) Test_Tracing() {
+	exporter := telemetrytest.Install(suite.T())
+
+	suite.T().Run("should pass the use case span down to the repository and record failures", func(t *testing.T) {
+		usecase := usecase.NewSaleUseCase(suite.repository, suite.tx, suite.publisher, suite.recorder)
+
+		var repositorySpan trace.SpanContext
+		suite.repository.EXPECT().GetByID(gomock.Any(), "sale-traced").DoAndReturn(func(ctx context.Context, id string) (*domain.Sale, error) {
+			repositorySpan = trace.SpanContextFromContext(ctx)
+			return nil, errors.New("sale not found")
+		})
+
+		_, err := usecase.Purchase(suite.ctx, "sale-traced", dto.InputPurchaseDTO{BuyerCPF: "12345678900"})
+		suite.Error(err)
+
+		spans := telemetrytest.Named(exporter, "saleUseCase.Purchase")
+		suite.Require().Len(spans, 1)
+		suite.Equal(spans[0].SpanContext.SpanID(), repositorySpan.SpanID())
+		suite.Equal(codes.Error, spans[0].Status.Code)
+		suite.Contains(spans[0].Attributes, attribute.String("sale.id", "sale-traced"))
+	})
+}
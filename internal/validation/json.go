@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrMalformedJSON é devolvido por JSON quando data não é um documento JSON válido.
+var ErrMalformedJSON = errors.New("malformed JSON")
+
+// JSON decodifica data em v recusando campos desconhecidos e valida v com Struct. Campos
+// desconhecidos e valores do tipo errado viram Errors, como as regras da tag validate.
+func JSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if fieldErr, ok := fieldError(err); ok {
+			return Errors{fieldErr}
+		}
+		return errors.Join(ErrMalformedJSON, err)
+	}
+	return Struct(v)
+}
+
+func fieldError(err error) (FieldError, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return FieldError{Field: typeErr.Field, Message: "must be " + jsonTypeName(typeErr.Type)}, true
+	}
+
+	// O encoding/json não tem um tipo para campo desconhecido, só a mensagem.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return FieldError{Field: strings.Trim(field, `"`), Message: "is not a known field"}, true
+	}
+	return FieldError{}, false
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
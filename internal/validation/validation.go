@@ -0,0 +1,166 @@
+// Package validation confere structs com regras declaradas na tag validate dos campos, por
+// exemplo `validate:"required,max=100"`. Os erros usam o nome do campo na tag json, o mesmo que
+// o cliente enviou.
+//
+// Regras:
+//   - required: string não vazia (ignorando espaços), número diferente de zero, ponteiro, slice ou
+//     map não nulo e não vazio.
+//   - min=N, max=N: tamanho em caracteres para strings, valor para números, tamanho para slices.
+//   - gt=N: número estritamente maior que N.
+//   - oneof=A B C: string igual a uma das opções, sem diferenciar maiúsculas.
+//   - cpf: 11 dígitos, com ou sem pontuação.
+//
+// Com exceção de required, as regras só valem para campos preenchidos.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/domain"
+)
+
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Errors lista todos os campos inválidos de uma struct, na ordem em que foram declarados.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Field+": "+err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Struct valida v, uma struct ou ponteiro para struct, e devolve Errors quando algum campo não
+// cumpre as regras. Uma regra desconhecida ou mal escrita é erro de programação e causa panic.
+func Struct(v any) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validation: %T is not a struct", v))
+	}
+
+	var errs Errors
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		name := jsonName(field)
+		for _, rule := range strings.Split(tag, ",") {
+			if message := check(rule, value.Field(i)); message != "" {
+				errs = append(errs, FieldError{Field: name, Message: message})
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// check devolve a mensagem de erro de rule para value, ou vazio quando value é válido.
+func check(rule string, value reflect.Value) string {
+	name, param, _ := strings.Cut(rule, "=")
+
+	if name == "required" {
+		if isEmpty(value) {
+			return "is required"
+		}
+		return ""
+	}
+	value = reflect.Indirect(value)
+	if !value.IsValid() || isEmpty(value) {
+		return ""
+	}
+
+	switch name {
+	case "min", "max":
+		limit := parseNumber(rule, param)
+		size, unit := measure(value)
+		if name == "min" && size < limit {
+			return fmt.Sprintf("must be at least %s%s", param, unit)
+		}
+		if name == "max" && size > limit {
+			return fmt.Sprintf("must be at most %s%s", param, unit)
+		}
+	case "gt":
+		limit := parseNumber(rule, param)
+		if size, _ := measure(value); size <= limit {
+			return "must be greater than " + param
+		}
+	case "oneof":
+		options := strings.Fields(param)
+		for _, option := range options {
+			if strings.EqualFold(value.String(), option) {
+				return ""
+			}
+		}
+		return "must be one of " + strings.Join(options, ", ")
+	case "cpf":
+		if len(domain.NormalizeCPF(value.String())) != 11 {
+			return "must be a CPF with 11 digits"
+		}
+	default:
+		panic(fmt.Sprintf("validation: unknown rule %q", rule))
+	}
+	return ""
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// measure devolve o que min, max e gt comparam: o tamanho de strings e slices ou o valor de números.
+func measure(value reflect.Value) (float64, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Map:
+		return float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), ""
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), ""
+	}
+	panic(fmt.Sprintf("validation: cannot measure %s", value.Type()))
+}
+
+func parseNumber(rule, param string) float64 {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: invalid rule %q", rule))
+	}
+	return limit
+}
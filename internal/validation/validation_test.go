@@ -0,0 +1,119 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/NicolasNSC/showcase-service-fiap/internal/validation"
+	"github.com/stretchr/testify/suite"
+)
+
+type sample struct {
+	Name     string   `json:"name" validate:"required,max=5"`
+	Nickname *string  `json:"nickname,omitempty" validate:"min=2"`
+	Price    float64  `json:"price" validate:"required,gt=0,max=100"`
+	Count    int      `json:"count" validate:"min=1"`
+	Status   string   `json:"status" validate:"oneof=OPEN CLOSED"`
+	CPF      string   `json:"cpf" validate:"cpf"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Internal string   `validate:"required"`
+	Ignored  string   `json:"ignored"`
+}
+
+func valid() sample {
+	return sample{Name: "Ana", Price: 10, Internal: "x"}
+}
+
+type ValidationSuite struct {
+	suite.Suite
+}
+
+func Test_ValidationSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ValidationSuite))
+}
+
+func (suite *ValidationSuite) Test_Struct() {
+	suite.T().Run("should accept a valid struct and a pointer to it", func(t *testing.T) {
+		input := valid()
+		suite.NoError(validation.Struct(input))
+		suite.NoError(validation.Struct(&input))
+	})
+
+	suite.T().Run("should only apply rules other than required to filled fields", func(t *testing.T) {
+		input := valid()
+		input.Count = 0
+		input.Status = ""
+		input.CPF = ""
+		suite.NoError(validation.Struct(input))
+	})
+
+	suite.T().Run("should list every invalid field by its json name", func(t *testing.T) {
+		short := "a"
+		input := sample{
+			Name:     "   ",
+			Nickname: &short,
+			Price:    -1,
+			Count:    -3,
+			Status:   "pending",
+			CPF:      "123.456",
+			Tags:     []string{"a", "b", "c"},
+		}
+
+		err := validation.Struct(input)
+
+		suite.Equal(validation.Errors{
+			{Field: "name", Message: "is required"},
+			{Field: "nickname", Message: "must be at least 2 characters"},
+			{Field: "price", Message: "must be greater than 0"},
+			{Field: "count", Message: "must be at least 1"},
+			{Field: "status", Message: "must be one of OPEN, CLOSED"},
+			{Field: "cpf", Message: "must be a CPF with 11 digits"},
+			{Field: "tags", Message: "must be at most 2 items"},
+			{Field: "Internal", Message: "is required"},
+		}, err)
+	})
+
+	suite.T().Run("should report only the first broken rule of a field", func(t *testing.T) {
+		input := valid()
+		input.Price = 150
+
+		suite.Equal(validation.Errors{{Field: "price", Message: "must be at most 100"}}, validation.Struct(input))
+	})
+
+	suite.T().Run("should count characters instead of bytes", func(t *testing.T) {
+		input := valid()
+		input.Name = "Ãéíõú"
+
+		suite.NoError(validation.Struct(input))
+	})
+
+	suite.T().Run("should compare oneof options ignoring case and accept a formatted cpf", func(t *testing.T) {
+		input := valid()
+		input.Status = "closed"
+		input.CPF = "529.982.247-25"
+
+		suite.NoError(validation.Struct(input))
+	})
+
+	suite.T().Run("should join the field errors in the error message", func(t *testing.T) {
+		input := valid()
+		input.Name = ""
+		input.Count = -1
+
+		suite.EqualError(validation.Struct(input), "name: is required; count: must be at least 1")
+	})
+
+	suite.T().Run("should panic on unknown rules and non structs", func(t *testing.T) {
+		suite.Panics(func() {
+			validation.Struct(struct {
+				Name string `validate:"email"`
+			}{Name: "x"})
+		})
+		suite.Panics(func() {
+			validation.Struct(struct {
+				Name string `validate:"max=ten"`
+			}{Name: "x"})
+		})
+		suite.Panics(func() { validation.Struct("text") })
+	})
+}